@@ -15,6 +15,21 @@ type Hitbox struct {
 	Height float32
 }
 
+// Combatant is anything combat can target: hits, effects and targeting only go through this interface.
+type Combatant interface {
+	Center() (float32, float32)
+	GetBounds() (float32, float32, float32, float32)
+	IsAlive() bool
+	GetHP() int
+	GetMaxHP() int
+	GetEffects() *[]gamedata.EffectInstance
+	GetCrowdControl() *gamedata.CrowdControlTracker
+	GetFaction() Faction
+	GetResistance(damageType gamedata.DamageType) float32
+	GetDefense() gamedata.DefenseStats
+	ApplyCombatDamage(damage int, damageType gamedata.DamageType, flash bool) int
+}
+
 type Entity struct {
 	PosX    float32
 	PosY    float32
@@ -25,6 +40,7 @@ type Entity struct {
 	Stats   *gamedata.Stats
 	Hitbox  Hitbox
 	Effects []gamedata.EffectInstance
+	CC      gamedata.CrowdControlTracker
 	Faction Faction
 	Alive   bool
 }
@@ -33,6 +49,42 @@ func (e *Entity) Center() (float32, float32) {
 	return e.PosX + e.Hitbox.Width/2, e.PosY + e.Hitbox.Height/2
 }
 
+func (e *Entity) GetBounds() (float32, float32, float32, float32) {
+	return e.PosX, e.PosY, e.Hitbox.Width, e.Hitbox.Height
+}
+
+func (e *Entity) GetHP() int {
+	return e.HP
+}
+
+func (e *Entity) GetMaxHP() int {
+	return e.MaxHP
+}
+
+func (e *Entity) GetEffects() *[]gamedata.EffectInstance {
+	return &e.Effects
+}
+
+func (e *Entity) GetCrowdControl() *gamedata.CrowdControlTracker {
+	return &e.CC
+}
+
+func (e *Entity) GetFaction() Faction {
+	return e.Faction
+}
+
+func (e *Entity) GetResistance(damageType gamedata.DamageType) float32 {
+	return 0
+}
+
+func (e *Entity) GetDefense() gamedata.DefenseStats {
+	return gamedata.DefenseStats{}
+}
+
+func (e *Entity) ApplyCombatDamage(damage int, damageType gamedata.DamageType, flash bool) int {
+	return e.ApplyDamage(damage)
+}
+
 func (e *Entity) IsAlive() bool {
 	return e != nil && e.Alive && e.HP > 0
 }
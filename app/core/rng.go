@@ -0,0 +1,95 @@
+package core
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+)
+
+type RNGStream string
+
+const (
+	RNGStreamCombatCrits RNGStream = "combat.crits"
+	RNGStreamCombatHits  RNGStream = "combat.hits"
+	RNGStreamItemProcs   RNGStream = "item.procs"
+	RNGStreamSpawns      RNGStream = "spawns"
+	RNGStreamProps       RNGStream = "props"
+	RNGStreamEvents      RNGStream = "events"
+	RNGStreamAI          RNGStream = "ai"
+)
+
+// RunRNG hands out independent, seeded random streams so one system's rolls never shift another's. Streams are
+// reseeded from (seed, room) on every room entry, so a run resumed at a room replays that room's rolls exactly.
+type RunRNG struct {
+	seed    int64
+	room    int
+	streams map[RNGStream]*rand.Rand
+}
+
+func NewRunRNG(seed int64) *RunRNG {
+	return &RunRNG{
+		seed:    seed,
+		streams: map[RNGStream]*rand.Rand{},
+	}
+}
+
+func (r *RunRNG) Seed() int64 {
+	if r == nil {
+		return 0
+	}
+	return r.seed
+}
+
+func (r *RunRNG) Room() int {
+	if r == nil {
+		return 0
+	}
+	return r.room
+}
+
+// BeginRoom restarts every stream from the seed derived for room.
+func (r *RunRNG) BeginRoom(room int) {
+	if r == nil {
+		return
+	}
+	r.room = room
+	r.streams = map[RNGStream]*rand.Rand{}
+}
+
+func (r *RunRNG) Stream(name RNGStream) *rand.Rand {
+	if r == nil {
+		return nil
+	}
+	stream, ok := r.streams[name]
+	if !ok {
+		stream = rand.New(rand.NewSource(deriveStreamSeed(r.seed, r.room, name)))
+		r.streams[name] = stream
+	}
+	return stream
+}
+
+func (r *RunRNG) Float32(name RNGStream) float32 {
+	if r == nil {
+		return rand.Float32()
+	}
+	return r.Stream(name).Float32()
+}
+
+func (r *RunRNG) Intn(name RNGStream, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	if r == nil {
+		return rand.Intn(n)
+	}
+	return r.Stream(name).Intn(n)
+}
+
+func deriveStreamSeed(seed int64, room int, name RNGStream) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(name))
+	if room != 0 {
+		_, _ = hash.Write(strconv.AppendInt([]byte{'#'}, int64(room), 10))
+	}
+	return seed ^ int64(hash.Sum64())
+}
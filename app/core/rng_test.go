@@ -0,0 +1,77 @@
+package core
+
+import "testing"
+
+func TestRunRNGStreamsAreDeterministicPerSeed(t *testing.T) {
+	a := NewRunRNG(1337)
+	b := NewRunRNG(1337)
+
+	for i := 0; i < 16; i++ {
+		left := a.Float32(RNGStreamCombatCrits)
+		right := b.Float32(RNGStreamCombatCrits)
+		if left != right {
+			t.Fatalf("expected identical crit rolls at %d, got %.6f and %.6f", i, left, right)
+		}
+	}
+}
+
+func TestRunRNGStreamsAreIndependent(t *testing.T) {
+	a := NewRunRNG(42)
+	b := NewRunRNG(42)
+
+	for i := 0; i < 8; i++ {
+		b.Float32(RNGStreamItemProcs)
+	}
+
+	for i := 0; i < 8; i++ {
+		left := a.Float32(RNGStreamCombatCrits)
+		right := b.Float32(RNGStreamCombatCrits)
+		if left != right {
+			t.Fatalf("expected item proc rolls not to shift crit stream at %d", i)
+		}
+	}
+
+	if NewRunRNG(42).Float32(RNGStreamCombatCrits) == NewRunRNG(42).Float32(RNGStreamAI) {
+		t.Fatalf("expected different streams to produce different sequences")
+	}
+}
+
+func TestRunRNGBeginRoomReseedsStreamsPerRoom(t *testing.T) {
+	a := NewRunRNG(7)
+	for i := 0; i < 5; i++ {
+		a.Float32(RNGStreamCombatCrits)
+	}
+	a.BeginRoom(2)
+	b := NewRunRNG(7)
+	b.BeginRoom(2)
+
+	for i := 0; i < 8; i++ {
+		if left, right := a.Float32(RNGStreamCombatCrits), b.Float32(RNGStreamCombatCrits); left != right {
+			t.Fatalf("expected room 2 rolls to ignore earlier draws at %d, got %.6f and %.6f", i, left, right)
+		}
+	}
+	if b.Room() != 2 {
+		t.Fatalf("expected room 2 to be tracked, got %d", b.Room())
+	}
+
+	c := NewRunRNG(7)
+	c.BeginRoom(0)
+	if c.Float32(RNGStreamCombatCrits) != NewRunRNG(7).Float32(RNGStreamCombatCrits) {
+		t.Fatalf("expected room 0 to keep the run's base stream seeds")
+	}
+	c.BeginRoom(1)
+	if c.Float32(RNGStreamCombatCrits) == NewRunRNG(7).Float32(RNGStreamCombatCrits) {
+		t.Fatalf("expected later rooms to derive different stream seeds")
+	}
+}
+
+func TestRunRNGNilFallsBackToGlobalSource(t *testing.T) {
+	var rng *RunRNG
+	value := rng.Float32(RNGStreamCombatCrits)
+	if value < 0 || value >= 1 {
+		t.Fatalf("expected fallback roll in [0,1), got %.6f", value)
+	}
+	if rng.Intn(RNGStreamSpawns, 0) != 0 {
+		t.Fatalf("expected Intn with non-positive n to return 0")
+	}
+}
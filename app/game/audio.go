@@ -1,6 +1,9 @@
 package game
 
-import "singlefantasy/app/assets"
+import (
+	"singlefantasy/app/assets"
+	"singlefantasy/app/core"
+)
 
 const (
 	sfxPlayerHit     = "sfx.player.hit"
@@ -23,6 +26,8 @@ const (
 	healingSoundPassiveOnHit healingSoundSource = iota
 	healingSoundKillReward
 	healingSoundActiveSkill
+	healingSoundShrine
+	healingSoundEnemySkill
 )
 
 func (g *Game) playSound(key string) {
@@ -72,19 +77,19 @@ func soundCooldownForKey(key string) float32 {
 	}
 }
 
-func sfxKeyForDamageTarget(target interface{}) string {
+func sfxKeyForDamageTarget(target core.Combatant) string {
 	if isPlayerTarget(target) {
 		return sfxPlayerHit
 	}
 	return sfxEnemyHit
 }
 
-func (g *Game) playDamageSFX(target interface{}) {
+func (g *Game) playDamageSFX(target core.Combatant) {
 	g.playSound(sfxKeyForDamageTarget(target))
 }
 
 func (g *Game) playHealingSFX(source healingSoundSource) {
-	if source != healingSoundActiveSkill {
+	if source != healingSoundActiveSkill && source != healingSoundShrine {
 		return
 	}
 	g.playSound(sfxPlayerHealing)
@@ -100,8 +105,15 @@ func (g *Game) healPlayerWithFeedbackSource(amount int, source healingSoundSourc
 	healed := g.Player.HP - before
 	if healed > 0 {
 		x, y := g.Player.Center()
-		g.spawnHealCombatText(x, y, healed)
-		g.playHealingSFX(source)
+		g.publish(CombatEvent{
+			Type:       EventHealed,
+			Source:     g.Player,
+			Target:     g.Player,
+			Amount:     healed,
+			X:          x,
+			Y:          y,
+			HealSource: source,
+		})
 	}
 	return healed
 }
@@ -121,11 +133,32 @@ func (g *Game) grantPlayerXP(amount int) {
 	if levelsGained <= 0 {
 		return
 	}
+	g.publish(CombatEvent{Type: EventLevelUp, Target: g.Player, Amount: levelsGained})
+}
 
-	if levelsGained > maxLevelUpSoundsPerGrant {
-		levelsGained = maxLevelUpSoundsPerGrant
-	}
-	for i := 0; i < levelsGained; i++ {
-		g.playSound(sfxPlayerLevelUp)
-	}
+func (g *Game) subscribeAudio(bus *CombatEventBus) {
+	bus.Subscribe(EventDamageDealt, func(event CombatEvent) {
+		g.playDamageSFX(event.Target)
+	})
+	bus.Subscribe(EventHealed, func(event CombatEvent) {
+		g.playHealingSFX(event.HealSource)
+	})
+	bus.Subscribe(EventSkillCast, func(event CombatEvent) {
+		g.playSkillCastSFX(event.Skill)
+	})
+	bus.Subscribe(EventEnemyCast, func(CombatEvent) {
+		g.playSound(sfxEnemyCast)
+	})
+	bus.Subscribe(EventDoorOpened, func(CombatEvent) {
+		g.playSound(sfxDoorOpen)
+	})
+	bus.Subscribe(EventLevelUp, func(event CombatEvent) {
+		levels := event.Amount
+		if levels > maxLevelUpSoundsPerGrant {
+			levels = maxLevelUpSoundsPerGrant
+		}
+		for i := 0; i < levels; i++ {
+			g.playSound(sfxPlayerLevelUp)
+		}
+	})
 }
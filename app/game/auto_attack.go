@@ -1,6 +1,7 @@
 package game
 
 import (
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 	"singlefantasy/app/systems"
@@ -23,13 +24,16 @@ func (g *Game) ApplyPlayerCombatHit(damage int, damageType gamedata.DamageType,
 		return false
 	}
 
-	g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+	result := g.applyCombatHitWithFeedback(systems.CombatHitRequest{
 		Target:        g.Player,
 		BaseDamage:    damage,
 		DamageType:    damageType,
 		Effects:       effects,
 		SuppressFlash: true,
 	})
+	if result.Damage.Avoided() {
+		return false
+	}
 	g.Player.HitFlashTimer = PlayerHitFlashDuration
 	g.Player.StartHurtIFrames(PlayerHurtIFrameDuration)
 	g.Player.ApplyKnockbackFrom(sourceX, sourceY, PlayerKnockbackImpulse)
@@ -63,7 +67,7 @@ func (g *Game) UpdateAutoAttack(dt float32) {
 	targetCenterX := targetX + targetWidth/2
 	targetCenterY := targetY + targetHeight/2
 	distance := systems.GetDistance(playerCenterX, playerCenterY, targetCenterX, targetCenterY)
-	if distance > g.Player.AttackRange {
+	if distance > g.Player.AttackRange || !g.hasLineOfSight(playerCenterX, playerCenterY, targetCenterX, targetCenterY) {
 		g.PlayerMoveTargetX = targetCenterX
 		g.PlayerMoveTargetY = targetCenterY
 		g.HasPlayerMoveTarget = true
@@ -144,37 +148,19 @@ func (g *Game) resolveMeleeAutoAttack(damage int) {
 		return
 	}
 
-	switch t := g.PlayerAttackTarget.(type) {
-	case *gameobjects.Enemy:
-		wasAlive := t.IsAlive()
-		g.applyCombatHitWithFeedback(systems.CombatHitRequest{
-			Caster:             g.Player,
-			Target:             t,
-			BaseDamage:         damage,
-			DamageType:         gamedata.DamagePhysical,
-			CritMultiplier:     1.5,
-			ApplyOnHitHooks:    true,
-			UseSourceModifiers: false,
-		})
-		if wasAlive && !t.IsAlive() {
-			g.grantPlayerXP(20)
-			g.PlayerAttackTarget = nil
-		}
-	case *gameobjects.Boss:
-		wasAlive := t.IsAlive()
-		g.applyCombatHitWithFeedback(systems.CombatHitRequest{
-			Caster:             g.Player,
-			Target:             t,
-			BaseDamage:         damage,
-			DamageType:         gamedata.DamagePhysical,
-			CritMultiplier:     1.5,
-			ApplyOnHitHooks:    true,
-			UseSourceModifiers: false,
-		})
-		if wasAlive && !t.IsAlive() {
-			g.grantPlayerXP(100)
-			g.PlayerAttackTarget = nil
-		}
+	target := g.PlayerAttackTarget
+	wasAlive := target.IsAlive()
+	g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+		Caster:             g.Player,
+		Target:             target,
+		BaseDamage:         damage,
+		DamageType:         gamedata.DamagePhysical,
+		ApplyOnHitHooks:    true,
+		UseSourceModifiers: false,
+	})
+	if wasAlive && !target.IsAlive() {
+		g.grantKillXP(target)
+		g.PlayerAttackTarget = nil
 	}
 }
 
@@ -205,7 +191,7 @@ func (g *Game) resolveRangedAutoAttack(damage int) {
 		Radius:     5,
 		Lifetime:   2.0,
 		Pierce:     0,
-		HitTargets: map[interface{}]struct{}{},
+		HitTargets: map[core.Combatant]struct{}{},
 		Alive:      true,
 		Caster:     g.Player,
 		DamageType: gamedata.DamagePhysical,
@@ -231,39 +217,20 @@ func (g *Game) resolveCasterAutoAttack(damage int) {
 		return
 	}
 
-	switch t := g.PlayerAttackTarget.(type) {
-	case *gameobjects.Enemy:
-		wasAlive := t.IsAlive()
-		g.applyCombatHitWithFeedback(systems.CombatHitRequest{
-			Caster:             g.Player,
-			Target:             t,
-			BaseDamage:         damage,
-			DamageType:         gamedata.DamageMagical,
-			CritMultiplier:     1.5,
-			ApplyOnHitHooks:    true,
-			UseSourceModifiers: false,
-		})
-		g.Player.UseMana(g.Player.Class.ManaCost)
-		if wasAlive && !t.IsAlive() {
-			g.grantPlayerXP(20)
-			g.PlayerAttackTarget = nil
-		}
-	case *gameobjects.Boss:
-		wasAlive := t.IsAlive()
-		g.applyCombatHitWithFeedback(systems.CombatHitRequest{
-			Caster:             g.Player,
-			Target:             t,
-			BaseDamage:         damage,
-			DamageType:         gamedata.DamageMagical,
-			CritMultiplier:     1.5,
-			ApplyOnHitHooks:    true,
-			UseSourceModifiers: false,
-		})
-		g.Player.UseMana(g.Player.Class.ManaCost)
-		if wasAlive && !t.IsAlive() {
-			g.grantPlayerXP(100)
-			g.PlayerAttackTarget = nil
-		}
+	target := g.PlayerAttackTarget
+	wasAlive := target.IsAlive()
+	g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+		Caster:             g.Player,
+		Target:             target,
+		BaseDamage:         damage,
+		DamageType:         gamedata.DamageMagical,
+		ApplyOnHitHooks:    true,
+		UseSourceModifiers: false,
+	})
+	g.Player.UseMana(g.Player.Class.ManaCost)
+	if wasAlive && !target.IsAlive() {
+		g.grantKillXP(target)
+		g.PlayerAttackTarget = nil
 	}
 }
 
@@ -285,21 +252,10 @@ func (g *Game) getPlayerAttackTargetBounds() (bool, float32, float32, float32, f
 		return false, 0, 0, 0, 0
 	}
 
-	switch t := g.PlayerAttackTarget.(type) {
-	case *gameobjects.Enemy:
-		if !t.IsAlive() {
-			g.PlayerAttackTarget = nil
-			return false, 0, 0, 0, 0
-		}
-		return true, t.PosX, t.PosY, t.Hitbox.Width, t.Hitbox.Height
-	case *gameobjects.Boss:
-		if t.Enemy == nil || !t.IsAlive() {
-			g.PlayerAttackTarget = nil
-			return false, 0, 0, 0, 0
-		}
-		return true, t.PosX, t.PosY, t.Hitbox.Width, t.Hitbox.Height
-	default:
+	if !g.PlayerAttackTarget.IsAlive() {
 		g.PlayerAttackTarget = nil
 		return false, 0, 0, 0, 0
 	}
+	x, y, width, height := g.PlayerAttackTarget.GetBounds()
+	return true, x, y, width, height
 }
@@ -0,0 +1,21 @@
+package game
+
+import (
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+)
+
+// spawnBossAddWaves brings in the adds of boss phase waves that came due, drawing each from the wave's archetype roster.
+func (g *Game) spawnBossAddWaves(waves []gamedata.BossAddWaveConfig) {
+	if g.Boss == nil || len(waves) == 0 {
+		return
+	}
+	bossX, bossY := g.Boss.Center()
+	for _, wave := range waves {
+		archetypes := make([]gamedata.EnemyArchetypeType, wave.Count)
+		for i := range archetypes {
+			archetypes[i] = wave.Archetypes[g.RNG.Intn(core.RNGStreamSpawns, len(wave.Archetypes))]
+		}
+		g.spawnAddsAround(bossX, bossY, actorBodyRadius(g.Boss.Enemy), archetypes)
+	}
+}
@@ -0,0 +1,36 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems"
+)
+
+func TestBossAddWavesSpawnProvokedAddsAroundBoss(t *testing.T) {
+	g := newEnemySkillTestGame()
+	g.Boss = gameobjects.NewBoss(300, 260, "forest")
+	g.invalidateCombatSpace()
+	roster := []gamedata.EnemyArchetypeType{gamedata.EnemyArchetypeSwarmling, gamedata.EnemyArchetypeRaider}
+
+	g.spawnBossAddWaves([]gamedata.BossAddWaveConfig{{Count: 3, Archetypes: roster}})
+	if len(g.Enemies) != 3 {
+		t.Fatalf("expected three boss adds, got %d", len(g.Enemies))
+	}
+	bossX, bossY := g.Boss.Center()
+	for _, add := range g.Enemies {
+		if add.Archetype != roster[0] && add.Archetype != roster[1] {
+			t.Fatalf("expected add drawn from the wave roster, got %v", add.Archetype)
+		}
+		if !add.Provoked || add.XPReward == 0 {
+			t.Fatalf("expected provoked boss add that still rewards XP")
+		}
+		addX, addY := add.Center()
+		if systems.GetDistance(bossX, bossY, addX, addY) < actorBodyRadius(g.Boss.Enemy) {
+			t.Fatalf("expected adds to spawn outside the boss body")
+		}
+	}
+}
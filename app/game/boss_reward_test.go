@@ -277,6 +277,7 @@ func TestBossMechanicsDoNotPersistAcrossRunReset(t *testing.T) {
 	g := NewGame(settings.Default())
 	g.Player = gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
 	g.Boss = gameobjects.NewBoss(100, 100, "forest")
+	g.Boss.Config.Phases = nil
 	g.Boss.AreaCooldownRemaining = 0
 	g.Boss.HeavyCooldownRemaining = 999
 
@@ -389,6 +390,37 @@ func TestBossHeavyAndAreaDamageRespectPlayerIFrames(t *testing.T) {
 	}
 }
 
+func TestBossVolleyProjectilesCarryPayloadToPlayer(t *testing.T) {
+	g := NewGame(settings.Default())
+	g.Player = gameobjects.NewPlayer(300, 0, gamedata.ClassTypeMelee)
+	g.Boss = gameobjects.NewBoss(0, 0, "forest")
+	g.Boss.Config.Phases = nil
+	g.Boss.HeavyCooldownRemaining = 999
+	g.Boss.AreaCooldownRemaining = 999
+	g.Boss.VolleyCooldown = 0
+	g.Boss.Config.Volleys = []gamedata.BossVolleyConfig{{
+		ID: "spore_shot", Pattern: gamedata.BossVolleyAimedFan, Count: 1, Cooldown: 10, Speed: 400, Radius: 8, Damage: 12,
+		DamageType: gamedata.DamageMagical, Effects: []gamedata.EffectSpec{{Type: gamedata.EffectSlow, Duration: 2, Magnitude: 0.3}},
+	}}
+	playerX, playerY := g.Player.Center()
+
+	g.Boss.Update(0.016, playerX, playerY)
+	if len(g.Boss.Projectiles) != 1 {
+		t.Fatalf("expected the boss to fire its volley, got %d projectiles", len(g.Boss.Projectiles))
+	}
+	startHP := g.Player.HP
+	projectiles := &projectilesSystem{}
+	for i := 0; i < 60 && g.Player.HP == startHP; i++ {
+		projectiles.updateBossProjectiles(g, 0.016)
+	}
+	if g.Player.HP >= startHP {
+		t.Fatalf("expected the volley shot to hit the player")
+	}
+	if !gamedata.HasEffect(&g.Player.Effects, gamedata.EffectSlow) {
+		t.Fatalf("expected the volley effect payload to apply")
+	}
+}
+
 func TestDungeonRunSystemRoomFourClearEntersMilestoneReward(t *testing.T) {
 	g := NewGame(settings.Default())
 	g.Player = gameobjects.NewPlayer(0, 0, gamedata.ClassTypeCaster)
@@ -2,7 +2,9 @@ package game
 
 import (
 	"fmt"
+	"slices"
 
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 	"singlefantasy/app/systems"
@@ -50,7 +52,7 @@ var (
 	combatStatusColor       = rl.NewColor(182, 224, 255, 255)
 )
 
-func (g *Game) applySkillWithFeedback(caster *gameobjects.Player, skill *gamedata.Skill, targets []interface{}) int {
+func (g *Game) applySkillWithFeedback(caster *gameobjects.Player, skill *gamedata.Skill, targets []core.Combatant) int {
 	if g == nil || caster == nil || skill == nil {
 		return 0
 	}
@@ -67,16 +69,42 @@ func (g *Game) applySkillWithFeedback(caster *gameobjects.Player, skill *gamedat
 			ApplyOnHitHooks:    true,
 			UseSourceModifiers: true,
 		})
+		g.applySkillHeal(caster, skill, target)
 		hits++
 	}
 	return hits
 }
 
+func (g *Game) applySkillHeal(source core.Combatant, skill *gamedata.Skill, target core.Combatant) {
+	amount := skill.Heal.Amount + int(float32(target.GetMaxHP())*skill.Heal.PercentMaxHP)
+	if amount <= 0 || !target.IsAlive() {
+		return
+	}
+	switch healed := target.(type) {
+	case *gameobjects.Player:
+		if healed == g.Player {
+			g.healPlayerFromActiveSkillWithFeedback(amount)
+		}
+	case *gameobjects.Enemy:
+		if restored := healed.Heal(amount); restored > 0 {
+			x, y := healed.Center()
+			g.publish(CombatEvent{Type: EventHealed, Source: source, Target: healed, Amount: restored, X: x, Y: y, HealSource: healingSoundEnemySkill})
+		}
+	}
+}
+
 func (g *Game) applyCombatHitWithFeedback(request systems.CombatHitRequest) systems.CombatHitResult {
 	if g == nil || request.Target == nil {
 		return systems.CombatHitResult{}
 	}
 
+	if request.RNG == nil {
+		request.RNG = g.RNG
+	}
+	if request.ProcWorld == nil {
+		request.ProcWorld = g
+	}
+
 	casterHPBefore := 0
 	if request.Caster != nil {
 		casterHPBefore = request.Caster.HP
@@ -84,22 +112,68 @@ func (g *Game) applyCombatHitWithFeedback(request systems.CombatHitRequest) syst
 
 	result := systems.ApplyCombatHit(request)
 	if result.Damage.AppliedDamage > 0 {
-		g.spawnDamageCombatText(request.Target, result.Damage.AppliedDamage, result.Damage.IsCrit, isPlayerTarget(request.Target))
-		g.playDamageSFX(request.Target)
+		g.publish(CombatEvent{
+			Type:       EventDamageDealt,
+			Source:     casterCombatant(request.Caster),
+			Target:     request.Target,
+			Amount:     result.Damage.AppliedDamage,
+			IsCrit:     result.Damage.IsCrit,
+			Outcome:    result.Damage.Outcome,
+			DamageType: feedbackDamageTypeFromRequest(request),
+			Skill:      request.Skill,
+		})
+	}
+	if result.Damage.Avoided() {
+		g.publish(CombatEvent{
+			Type:       EventHitAvoided,
+			Source:     casterCombatant(request.Caster),
+			Target:     request.Target,
+			Outcome:    result.Damage.Outcome,
+			DamageType: feedbackDamageTypeFromRequest(request),
+			Skill:      request.Skill,
+		})
 	}
 	if result.EffectsApplied > 0 {
-		g.spawnStatusPopupsForTarget(request.Target, feedbackEffectsFromRequest(request))
+		g.publish(CombatEvent{
+			Type:    EventEffectApplied,
+			Source:  casterCombatant(request.Caster),
+			Target:  request.Target,
+			Amount:  result.EffectsApplied,
+			Effects: landedEffects(feedbackEffectsFromRequest(request), result.ResistedEffects),
+			Skill:   request.Skill,
+		})
+	}
+	if len(result.ResistedEffects) > 0 {
+		g.publish(CombatEvent{
+			Type:    EventEffectResisted,
+			Source:  casterCombatant(request.Caster),
+			Target:  request.Target,
+			Amount:  len(result.ResistedEffects),
+			Effects: result.ResistedEffects,
+			Skill:   request.Skill,
+		})
 	}
 
 	if request.Caster != nil {
 		healed := request.Caster.HP - casterHPBefore
 		if healed > 0 {
 			x, y := request.Caster.Center()
-			g.spawnHealCombatText(x, y, healed)
-			g.playHealingSFX(healingSoundPassiveOnHit)
+			g.publish(CombatEvent{
+				Type:       EventHealed,
+				Source:     casterCombatant(request.Caster),
+				Target:     request.Caster,
+				Amount:     healed,
+				X:          x,
+				Y:          y,
+				HealSource: healingSoundPassiveOnHit,
+			})
 		}
 	}
 
+	if result.TargetKilled {
+		g.publish(CombatEvent{Type: EventEntityKilled, Source: casterCombatant(request.Caster), Target: request.Target, Skill: request.Skill})
+	}
+
 	return result
 }
 
@@ -113,16 +187,63 @@ func feedbackEffectsFromRequest(request systems.CombatHitRequest) []gamedata.Eff
 	return nil
 }
 
-func isPlayerTarget(target interface{}) bool {
-	_, ok := target.(*gameobjects.Player)
-	return ok
+// landedEffects drops the effects a target resisted so status popups only name what actually applied.
+func landedEffects(effects, resisted []gamedata.EffectSpec) []gamedata.EffectSpec {
+	if len(resisted) == 0 {
+		return effects
+	}
+	landed := make([]gamedata.EffectSpec, 0, len(effects))
+	for _, effect := range effects {
+		if !slices.ContainsFunc(resisted, func(r gamedata.EffectSpec) bool { return r.Type == effect.Type }) {
+			landed = append(landed, effect)
+		}
+	}
+	return landed
+}
+
+func feedbackDamageTypeFromRequest(request systems.CombatHitRequest) gamedata.DamageType {
+	if request.Skill != nil && request.Skill.DamageSpec != nil {
+		return request.Skill.DamageSpec.DamageType
+	}
+	return request.DamageType
+}
+
+func (g *Game) subscribeCombatFeedback(bus *CombatEventBus) {
+	bus.Subscribe(EventDamageDealt, func(event CombatEvent) {
+		g.spawnDamageCombatText(event.Target, event.Amount, event.IsCrit, isPlayerTarget(event.Target))
+		if event.Outcome == systems.HitOutcomeBlocked {
+			g.spawnHitOutcomeText(event.Target, event.Outcome)
+		}
+	})
+	bus.Subscribe(EventHitAvoided, func(event CombatEvent) {
+		g.spawnHitOutcomeText(event.Target, event.Outcome)
+	})
+	bus.Subscribe(EventEffectApplied, func(event CombatEvent) {
+		g.spawnStatusPopupsForTarget(event.Target, event.Effects, false)
+	})
+	bus.Subscribe(EventEffectResisted, func(event CombatEvent) {
+		g.spawnStatusPopupsForTarget(event.Target, event.Effects, true)
+	})
+	bus.Subscribe(EventHealed, func(event CombatEvent) {
+		g.spawnHealCombatText(event.X, event.Y, event.Amount)
+	})
+	bus.Subscribe(EventSkillCast, func(event CombatEvent) {
+		g.spawnSkillCastVisual(event.Skill, event.Intent)
+	})
+	bus.Subscribe(EventSkillImpact, func(event CombatEvent) {
+		g.spawnSkillImpactVisual(event.Skill, event.X, event.Y)
+	})
+}
+
+func isPlayerTarget(target core.Combatant) bool {
+	return target != nil && target.GetFaction() == core.FactionPlayer
 }
 
 func (g *Game) healPlayerWithFeedback(amount int) int {
 	return g.healPlayerWithFeedbackSource(amount, healingSoundKillReward)
 }
 
-func (g *Game) spawnDamageCombatText(target interface{}, amount int, isCrit bool, targetIsPlayer bool) {
+func (g *Game) spawnDamageCombatText(target core.Combatant, amount int, isCrit bool, targetIsPlayer bool) {
 	if g == nil || amount <= 0 {
 		return
 	}
@@ -146,6 +267,32 @@ func (g *Game) spawnDamageCombatText(target interface{}, amount int, isCrit bool
 	g.addCombatTextEvent(x, y, text, CombatTextDamage, color, CombatFeedbackTextDuration, scale, isCrit)
 }
 
+// spawnHitOutcomeText labels hits that were missed, dodged or blocked.
+func (g *Game) spawnHitOutcomeText(target core.Combatant, outcome systems.HitOutcome) {
+	label := hitOutcomeLabel(outcome)
+	if g == nil || label == "" {
+		return
+	}
+	x, y, ok := combatFeedbackTargetAnchor(target)
+	if !ok {
+		return
+	}
+	g.addCombatTextEvent(x, y, label, CombatTextStatus, combatStatusColor, CombatFeedbackStatusDuration, CombatFeedbackBaseScale, false)
+}
+
+func hitOutcomeLabel(outcome systems.HitOutcome) string {
+	switch outcome {
+	case systems.HitOutcomeMiss:
+		return "MISS"
+	case systems.HitOutcomeDodged:
+		return "DODGE"
+	case systems.HitOutcomeBlocked:
+		return "BLOCK"
+	default:
+		return ""
+	}
+}
+
 func (g *Game) spawnHealCombatText(x, y float32, amount int) {
 	if g == nil || amount <= 0 {
 		return
@@ -153,7 +300,8 @@ func (g *Game) spawnHealCombatText(x, y float32, amount int) {
 	g.addCombatTextEvent(x, y, fmt.Sprintf("+%d", amount), CombatTextHeal, combatHealColor, CombatFeedbackTextDuration, CombatFeedbackBaseScale, false)
 }
 
-func (g *Game) spawnStatusPopupsForTarget(target interface{}, effects []gamedata.EffectSpec) {
+// spawnStatusPopupsForTarget names the effects that landed, or shows a single IMMUNE when they were resisted.
+func (g *Game) spawnStatusPopupsForTarget(target core.Combatant, effects []gamedata.EffectSpec, immune bool) {
 	if g == nil || len(effects) == 0 {
 		return
 	}
@@ -161,6 +309,10 @@ func (g *Game) spawnStatusPopupsForTarget(target interface{}, effects []gamedata
 	if !ok {
 		return
 	}
+	if immune {
+		g.addCombatTextEvent(x, y, "IMMUNE", CombatTextStatus, combatStatusColor, CombatFeedbackStatusDuration, CombatFeedbackBaseScale, false)
+		return
+	}
 
 	seen := map[string]struct{}{}
 	for _, effect := range effects {
@@ -176,29 +328,13 @@ func (g *Game) spawnStatusPopupsForTarget(target interface{}, effects []gamedata
 	}
 }
 
-func combatFeedbackTargetAnchor(target interface{}) (float32, float32, bool) {
-	switch t := target.(type) {
-	case *gameobjects.Player:
-		if t == nil {
-			return 0, 0, false
-		}
-		x, y := t.Center()
-		return x, y - t.Hitbox.Height*0.55, true
-	case *gameobjects.Enemy:
-		if t == nil {
-			return 0, 0, false
-		}
-		x, y := t.Center()
-		return x, y - t.Hitbox.Height*0.55, true
-	case *gameobjects.Boss:
-		if t == nil {
-			return 0, 0, false
-		}
-		x, y := t.Center()
-		return x, y - t.Hitbox.Height*0.55, true
-	default:
+func combatFeedbackTargetAnchor(target core.Combatant) (float32, float32, bool) {
+	if target == nil {
 		return 0, 0, false
 	}
+	x, y := target.Center()
+	_, _, _, height := target.GetBounds()
+	return x, y - height*0.55, true
 }
 
 func (g *Game) addCombatTextEvent(x, y float32, text string, kind CombatTextKind, color rl.Color, duration, scale float32, isCrit bool) {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 	"singlefantasy/app/settings"
@@ -61,6 +62,78 @@ func TestApplyCombatHitWithFeedbackSpawnsCritAndStatusText(t *testing.T) {
 	}
 }
 
+func TestResistedCrowdControlSpawnsImmunePopup(t *testing.T) {
+	g := NewGame(settings.Default())
+	g.Player = gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	boss := gameobjects.NewBoss(0, 0, "forest")
+	boss.CC.Immune = []gamedata.CCCategory{gamedata.CCCategoryStun}
+
+	g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+		Target:  boss,
+		Effects: []gamedata.EffectSpec{{Type: gamedata.EffectStun, Duration: 1}, {Type: gamedata.EffectSlow, Duration: 2, Magnitude: 0.3}},
+	})
+
+	statusCounts := map[string]int{}
+	for _, event := range g.CombatTextEvents {
+		if event != nil && event.Kind == CombatTextStatus {
+			statusCounts[event.Text]++
+		}
+	}
+	if statusCounts["IMMUNE"] != 1 || statusCounts["STUNNED"] != 0 || statusCounts["SLOWED"] != 1 {
+		t.Fatalf("expected IMMUNE for the stun and SLOWED for the slow, got %v", statusCounts)
+	}
+	if gamedata.HasEffect(&boss.Effects, gamedata.EffectStun) {
+		t.Fatalf("expected the immune boss to stay unstunned")
+	}
+	if slow := boss.Effects[0]; slow.TimeLeft != 2*boss.Config.CCResistance.DurationMultiplier {
+		t.Fatalf("expected boss resistance to shorten the slow, got %.2f", slow.TimeLeft)
+	}
+}
+
+func TestAvoidedAndBlockedHitsSpawnOutcomeText(t *testing.T) {
+	g := NewGame(settings.Default())
+	g.Player = gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	g.Player.DerivedStats.DodgeChance = 0.4
+	g.Player.DerivedStats.BlockChance = 0.3
+	g.Player.DerivedStats.BlockAmount = 0.5
+	startHP := g.Player.HP
+	dodgeRoll, blockRoll := float32(0.1), float32(0.3)
+
+	dodged := g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+		Target:     g.Player,
+		BaseDamage: 20,
+		DamageType: gamedata.DamagePhysical,
+		Effects:    []gamedata.EffectSpec{{Type: gamedata.EffectStun, Duration: 1}},
+		HitRoll:    &dodgeRoll,
+	})
+	if dodged.Damage.Outcome != systems.HitOutcomeDodged || g.Player.HP != startHP || gamedata.HasEffect(&g.Player.Effects, gamedata.EffectStun) {
+		t.Fatalf("expected the dodge to negate damage and effects, got %+v", dodged)
+	}
+
+	blocked := g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+		Target:     g.Player,
+		BaseDamage: 20,
+		DamageType: gamedata.DamagePhysical,
+		HitRoll:    &blockRoll,
+	})
+	if blocked.Damage.Outcome != systems.HitOutcomeBlocked || blocked.Damage.AppliedDamage >= 20 || g.Player.HP != startHP-blocked.Damage.AppliedDamage {
+		t.Fatalf("expected the block to soften the hit, got %+v", blocked)
+	}
+
+	texts := map[string]int{}
+	for _, event := range g.CombatTextEvents {
+		if event != nil {
+			texts[event.Text]++
+		}
+	}
+	if texts["DODGE"] != 1 || texts["BLOCK"] != 1 || texts[fmt.Sprintf("%d", blocked.Damage.AppliedDamage)] != 1 {
+		t.Fatalf("expected DODGE, BLOCK and the blocked damage number, got %v", texts)
+	}
+	if g.Events.CountFrameEvents(EventHitAvoided) != 1 {
+		t.Fatalf("expected one hit avoided event")
+	}
+}
+
 func TestApplyCombatHitWithFeedbackSpawnsHealPopupFromLifesteal(t *testing.T) {
 	g := NewGame(settings.Default())
 	g.Player = gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
@@ -123,7 +196,7 @@ func TestRangedKillHealSpawnsFloatingHealPopup(t *testing.T) {
 			Damage:     10,
 			Alive:      true,
 			Lifetime:   1.0,
-			HitTargets: map[interface{}]struct{}{},
+			HitTargets: map[core.Combatant]struct{}{},
 			Caster:     g.Player,
 			DamageType: gamedata.DamagePhysical,
 		},
@@ -0,0 +1,71 @@
+//go:build raylib
+
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+func runSeededCombatLog(t *testing.T, seed int64, steps int) []string {
+	t.Helper()
+	source := systems.NewScriptedInputSource()
+	g := NewHeadlessGame(HeadlessConfig{
+		Class:    gamedata.ClassTypeMelee,
+		Seed:     seed,
+		Settings: settings.Default(),
+		Input:    source,
+	})
+	if g.RNG == nil || g.RNG.Seed() != g.Dungeon.Seed {
+		t.Fatalf("expected run RNG to be seeded from dungeon seed")
+	}
+	g.Player.DerivedStats.CritChance = 0.5
+	for _, enemy := range g.Enemies {
+		enemy.MaxHP = 5000
+		enemy.HP = enemy.MaxHP
+	}
+
+	log := []string{}
+	for i := 0; i < steps && g.State == StateRun; i++ {
+		input := systems.Input{}
+		for _, enemy := range g.Enemies {
+			if !enemy.IsAlive() {
+				continue
+			}
+			x, y := enemy.Center()
+			input = systems.Input{Attack: true, CursorWorldX: x, CursorWorldY: y}
+			break
+		}
+		source.Push(input)
+		g.StepHeadless(1)
+
+		entry := fmt.Sprintf("p=%d", g.Player.HP)
+		for _, enemy := range g.Enemies {
+			entry += fmt.Sprintf(" e=%d", enemy.HP)
+		}
+		log = append(log, entry)
+	}
+	return log
+}
+
+func TestSameSeedAndInputsProduceIdenticalDamageLogs(t *testing.T) {
+	first := runSeededCombatLog(t, world.DefaultDungeonSeed, 900)
+	second := runSeededCombatLog(t, world.DefaultDungeonSeed, 900)
+
+	if len(first) != len(second) {
+		t.Fatalf("expected equal log lengths, got %d and %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("expected identical damage logs, diverged at step %d: %q vs %q", i, first[i], second[i])
+		}
+	}
+	if len(first) == 0 || first[0] == first[len(first)-1] {
+		t.Fatalf("expected scripted combat to change HP over the run")
+	}
+}
@@ -0,0 +1,71 @@
+package game
+
+import (
+	"singlefantasy/app/core"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+// combatSpace returns the broadphase index of live enemies and room obstacles, rebuilding it if a step moved actors.
+func (g *Game) combatSpace() *systems.CombatSpace {
+	if g.Space == nil {
+		g.Space = systems.NewCombatSpace()
+		g.spaceDirty = true
+	}
+	if g.spaceDirty {
+		g.Space.Rebuild(append(systems.CombatantsFrom(g.Enemies, g.Boss), propCombatants(g.Props)...), g.CurrentRoom)
+		g.Space.SetBlockers(g.roomBlockers())
+		g.spaceDirty = false
+	}
+	return g.Space
+}
+
+func (g *Game) invalidateCombatSpace() {
+	g.spaceDirty = true
+}
+
+func (g *Game) invalidatePropBlockers() {
+	g.blockersDirty = true
+	g.spaceDirty = true
+	if g.nav != nil {
+		g.nav.Grids = nil
+	}
+}
+
+func (g *Game) roomBlockers() []world.AABB {
+	if g.blockersDirty {
+		g.blockers = propBlockers(g.Props)
+		g.blockersDirty = false
+	}
+	return g.blockers
+}
+
+func (g *Game) combatantAt(x, y float32) core.Combatant {
+	for _, combatant := range g.combatSpace().CombatantsInAABB(world.AABB{X: x, Y: y}) {
+		if !combatant.IsAlive() {
+			continue
+		}
+		minX, minY, width, height := combatant.GetBounds()
+		if pointInRect(x, y, minX, minY, width, height) {
+			return combatant
+		}
+	}
+	return nil
+}
+
+func (g *Game) grantKillXP(target core.Combatant) {
+	switch target := target.(type) {
+	case *gameobjects.Boss:
+		g.grantPlayerXP(BossKillXP)
+	case *gameobjects.Enemy:
+		g.grantPlayerXP(target.XPReward)
+	}
+}
+
+func casterCombatant(caster *gameobjects.Player) core.Combatant {
+	if caster == nil {
+		return nil
+	}
+	return caster
+}
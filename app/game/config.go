@@ -15,6 +15,7 @@ const (
 	RewardBossOfferSize      = 3
 	RewardMilestoneOfferSize = 2
 	RewardMilestoneRoomIndex = 4
+	BossKillXP               = 100
 )
 
 const (
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"math"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems"
+)
+
+const (
+	EnemySeparationPadding       float32 = 6
+	EnemySeparationSpeed         float32 = 120
+	EnemyPlayerPushSpeed         float32 = 240
+	EnemyKnockbackDecayPerSecond float32 = 900
+)
+
+type enemyPush struct {
+	X float32
+	Y float32
+}
+
+// enemySeparation computes a boid-style push for every enemy, away from overlapping neighbours and, more firmly,
+// from the player. It is measured before anyone moves so the result does not depend on update order.
+func (g *Game) enemySeparation(space *systems.CombatSpace) []enemyPush {
+	pushes := make([]enemyPush, len(g.Enemies))
+	for i, enemy := range g.Enemies {
+		if enemy == nil || !enemy.IsAlive() {
+			continue
+		}
+		x, y := enemy.Center()
+		radius := actorBodyRadius(enemy)
+
+		var push enemyPush
+		for _, candidate := range space.CombatantsInCircle(x, y, radius+space.MaxHalfExtent()+EnemySeparationPadding) {
+			neighbor, ok := candidate.(*gameobjects.Enemy)
+			if !ok || neighbor == enemy || !neighbor.IsAlive() {
+				continue
+			}
+			neighborX, neighborY := neighbor.Center()
+			addSeparation(&push, x, y, neighborX, neighborY, radius+actorBodyRadius(neighbor)+EnemySeparationPadding, i, EnemySeparationSpeed)
+		}
+		push = clampPush(push, EnemySeparationSpeed)
+
+		if g.Player != nil && g.Player.IsAlive() {
+			playerX, playerY := g.Player.Center()
+			addSeparation(&push, x, y, playerX, playerY, radius+actorBodyRadius(g.Player), i, EnemyPlayerPushSpeed)
+		}
+		pushes[i] = push
+	}
+	return pushes
+}
+
+// addSeparation adds a push that grows linearly from zero at the desired spacing to full speed when centers meet.
+// Actors on the same point split along an angle derived from the enemy's slot so they do not stay stacked.
+func addSeparation(push *enemyPush, x, y, otherX, otherY, spacing float32, slot int, speed float32) {
+	dx := x - otherX
+	dy := y - otherY
+	distance := systems.GetDistance(0, 0, dx, dy)
+	if distance >= spacing {
+		return
+	}
+	if distance <= 0.0001 {
+		angle := float64(slot) * 2.39996
+		dx, dy, distance = float32(math.Cos(angle)), float32(math.Sin(angle)), 1
+	}
+	strength := (spacing - distance) / spacing * speed
+	push.X += dx / distance * strength
+	push.Y += dy / distance * strength
+}
+
+func clampPush(push enemyPush, limit float32) enemyPush {
+	length := systems.GetDistance(0, 0, push.X, push.Y)
+	if length <= limit {
+		return push
+	}
+	return enemyPush{X: push.X / length * limit, Y: push.Y / length * limit}
+}
+
+func actorBodyRadius(actor core.Combatant) float32 {
+	_, _, width, height := actor.GetBounds()
+	return max(width, height) / 2
+}
+
+// applySkillKnockback pushes enemies hit by a skill away from the source. Bosses and props hold their ground.
+func (g *Game) applySkillKnockback(skill *gamedata.Skill, sourceX, sourceY float32, targets []core.Combatant) {
+	if skill == nil || skill.Knockback.Impulse <= 0 {
+		return
+	}
+	for _, target := range targets {
+		if enemy, ok := target.(*gameobjects.Enemy); ok {
+			enemy.ApplyKnockbackFrom(sourceX, sourceY, skill.Knockback.Impulse)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+func newCrowdingTestGame() *Game {
+	g := NewGame(settings.Default())
+	g.State = StateRun
+	g.Player = gameobjects.NewPlayer(600, 400, gamedata.ClassTypeMelee)
+	g.CurrentRoom = &world.Room{Width: 800, Height: 600}
+	g.invalidateCombatSpace()
+	return g
+}
+
+func stepEnemyMovement(g *Game, steps int) {
+	movement := &movementSystem{}
+	for i := 0; i < steps; i++ {
+		movement.updateEnemies(g, 1.0/60.0)
+		g.invalidateCombatSpace()
+	}
+}
+
+func TestStackedEnemiesSpreadApart(t *testing.T) {
+	g := newCrowdingTestGame()
+	for i := 0; i < 4; i++ {
+		g.Enemies = append(g.Enemies, gameobjects.NewEnemyFromArchetype(200, 200, gamedata.EnemyArchetypeRaider, false, 0))
+	}
+	g.invalidateCombatSpace()
+
+	stepEnemyMovement(g, 90)
+
+	for i, enemy := range g.Enemies {
+		x, y := enemy.Center()
+		for _, other := range g.Enemies[i+1:] {
+			otherX, otherY := other.Center()
+			if systems.GetDistance(x, y, otherX, otherY) < actorBodyRadius(enemy)+actorBodyRadius(other) {
+				t.Fatalf("expected stacked enemies to separate, still overlapping at (%v,%v)", x, y)
+			}
+		}
+	}
+}
+
+func TestEnemyYieldsToPlayerBody(t *testing.T) {
+	g := newCrowdingTestGame()
+	playerX, playerY := g.Player.Center()
+	enemy := gameobjects.NewEnemyFromArchetype(playerX-10, playerY-15, gamedata.EnemyArchetypeRaider, false, 0)
+	g.Enemies = []*gameobjects.Enemy{enemy}
+	g.invalidateCombatSpace()
+
+	stepEnemyMovement(g, 30)
+
+	enemyX, enemyY := enemy.Center()
+	if systems.GetDistance(enemyX, enemyY, playerX, playerY) < actorBodyRadius(enemy)+actorBodyRadius(g.Player)-1 {
+		t.Fatalf("expected enemy to be pushed out of the player, at (%v,%v)", enemyX, enemyY)
+	}
+	if movedX, movedY := g.Player.Center(); movedX != playerX || movedY != playerY {
+		t.Fatalf("expected the player to hold position")
+	}
+}
+
+func TestShockwaveSlamKnocksEnemiesBackUntilWall(t *testing.T) {
+	g := newCrowdingTestGame()
+	g.CurrentRoom.Obstacles = []world.AABB{{X: 760, Y: 300, Width: 40, Height: 200}}
+	open := gameobjects.NewEnemyFromArchetype(650, 390, gamedata.EnemyArchetypeRaider, false, 0)
+	g.Enemies = []*gameobjects.Enemy{open}
+	g.invalidateCombatSpace()
+	gamedata.ApplyEffect(&open.Effects, gamedata.Effect{Type: gamedata.EffectStun, Duration: 5})
+
+	skill := gamedata.NewSkill(gamedata.SkillTypeShockwaveSlam)
+	openX, openY := open.Center()
+	g.TryCastSkill(skill, &systems.Input{CursorWorldX: openX, CursorWorldY: openY})
+	if open.KnockbackVelX <= 0 {
+		t.Fatalf("expected slam to push the enemy away from the player, got %v", open.KnockbackVelX)
+	}
+
+	stepEnemyMovement(g, 60)
+	if open.PosX+open.Hitbox.Width > 760 {
+		t.Fatalf("expected knockback to stop at the wall, got x=%v", open.PosX)
+	}
+	if open.PosX <= 650 {
+		t.Fatalf("expected the stunned enemy to be displaced, got x=%v", open.PosX)
+	}
+	if open.KnockbackVelX != 0 {
+		t.Fatalf("expected knockback to be spent against the wall, got %v", open.KnockbackVelX)
+	}
+}
@@ -0,0 +1,336 @@
+package game
+
+import (
+	"math"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems"
+)
+
+const EnemyCastReactionWindow float32 = 0.4
+
+// chooseEnemySkill queues the first ready skill whose use conditions hold in place of this step's basic attack.
+func (g *Game) chooseEnemySkill(enemy *gameobjects.Enemy, targetX, targetY float32) {
+	if len(enemy.Skills) == 0 || !enemy.IsAlive() || enemy.State == gameobjects.EnemyStateIdle || g.enemyTargetsTotem(enemy) {
+		return
+	}
+	if !gamedata.CanCast(&enemy.Effects) {
+		return
+	}
+	for i := range enemy.Skills {
+		enemySkill := &enemy.Skills[i]
+		if !enemySkill.Skill.CanUse() || !g.enemySkillUsable(enemy, enemySkill, targetX, targetY) {
+			continue
+		}
+		enemy.PendingSkill = enemySkill
+		enemy.WantsAttack = false
+		return
+	}
+}
+
+func (g *Game) enemySkillUsable(enemy *gameobjects.Enemy, enemySkill *gamedata.EnemySkill, targetX, targetY float32) bool {
+	use := enemySkill.Use
+	skill := &enemySkill.Skill
+	enemyX, enemyY := enemy.Center()
+	distance := systems.GetDistance(enemyX, enemyY, targetX, targetY)
+	if distance < use.MinRange || (use.MaxRange > 0 && distance > use.MaxRange) {
+		return false
+	}
+	if use.SelfHPBelow > 0 && systems.HealthRatio(enemy) >= use.SelfHPBelow {
+		return false
+	}
+	allies := g.livingAlliesOf(enemy)
+	if allies < use.MinAllies || (use.MaxAllies > 0 && allies > use.MaxAllies) {
+		return false
+	}
+	if use.PlayerCasting && g.playerCastTimer <= 0 {
+		return false
+	}
+
+	if isHostileSkill(skill) {
+		if reach := enemySkillReach(skill); reach > 0 && distance > reach {
+			return false
+		}
+		return skillPiercesTerrain(skill) || g.hasLineOfSight(enemyX, enemyY, targetX, targetY)
+	}
+	if use.AllyHPBelow > 0 {
+		intent := systems.BuildCastIntentFrom(enemyX, enemyY, targetX, targetY, enemy.FacingRight)
+		targets := g.resolveEnemySkillTargets(enemy, skill, intent)
+		if len(targets) == 0 || systems.HealthRatio(targets[0]) >= use.AllyHPBelow {
+			return false
+		}
+	}
+	return true
+}
+
+func isHostileSkill(skill *gamedata.Skill) bool {
+	switch skill.Targeting.Type {
+	case gamedata.TargetEnemy, gamedata.TargetArea, gamedata.TargetDirection:
+		return true
+	default:
+		return false
+	}
+}
+
+func enemySkillReach(skill *gamedata.Skill) float32 {
+	targeting := skill.Targeting
+	var reach float32
+	switch {
+	case targeting.Type == gamedata.TargetArea && targeting.Range == 0:
+		reach = targeting.Radius
+	case targeting.Type == gamedata.TargetArea && targeting.Range > 0:
+		reach = targeting.Range + targeting.Radius
+	case targeting.Range > 0:
+		reach = targeting.Range
+	default:
+		return 0
+	}
+	if skill.SelfMovement.Mode == gamedata.SelfMovementTowardTarget {
+		reach += skill.SelfMovement.Distance
+	}
+	return reach
+}
+
+func (g *Game) livingAlliesOf(enemy *gameobjects.Enemy) int {
+	allies := 0
+	for _, other := range g.Enemies {
+		if other != nil && other != enemy && other.IsAlive() {
+			allies++
+		}
+	}
+	return allies
+}
+
+func (g *Game) castEnemySkill(enemy *gameobjects.Enemy, enemySkill *gamedata.EnemySkill) {
+	if !enemy.IsAlive() || !gamedata.CanCast(&enemy.Effects) || !enemySkill.Skill.CanUse() {
+		return
+	}
+	skill := &enemySkill.Skill
+	playerX, playerY := g.Player.Center()
+	if skill.SelfMovement.Mode == gamedata.SelfMovementTowardTarget {
+		g.chargeEnemyToward(enemy, playerX, playerY, skill.SelfMovement.Distance)
+	}
+
+	enemyX, enemyY := enemy.Center()
+	intent := systems.BuildCastIntentFrom(enemyX, enemyY, playerX, playerY, enemy.FacingRight)
+	switch skill.Delivery.Type {
+	case gamedata.DeliveryInstant:
+		g.applyEnemySkill(enemy, skill, g.resolveEnemySkillTargets(enemy, skill, intent))
+	case gamedata.DeliveryProjectile:
+		g.spawnEnemySkillProjectile(enemy, skill, intent)
+	case gamedata.DeliveryDelayed:
+		g.queueEnemyDelayedSkill(enemy, skill, intent)
+	}
+	g.summonEnemies(enemy, skill.Summon)
+
+	skill.Use()
+	enemy.AttackFlashTimer = gameobjects.EnemyAttackFlashDuration
+	g.publish(CombatEvent{Type: EventEnemyCast, Source: enemy, Skill: skill, X: enemyX, Y: enemyY})
+}
+
+func (g *Game) resolveEnemySkillTargets(enemy *gameobjects.Enemy, skill *gamedata.Skill, intent systems.CastIntent) []core.Combatant {
+	var candidates []core.Combatant
+	switch {
+	case skill.Targeting.Type == gamedata.TargetAlly:
+		candidates = g.combatSpace().TargetCandidates(enemy, intent, skill.Targeting)
+	case isHostileSkill(skill) && g.Player != nil:
+		originX, originY, ok := skillTerrainOrigin(enemy, skill, intent)
+		if !ok || !g.terrainOccludes(skill, originX, originY, g.Player) {
+			candidates = []core.Combatant{g.Player}
+		}
+	}
+	return systems.ResolveCasterTargets(enemy, intent, skill.Targeting, candidates)
+}
+
+func (g *Game) applyEnemySkill(enemy *gameobjects.Enemy, skill *gamedata.Skill, targets []core.Combatant) {
+	for _, target := range targets {
+		if target == nil {
+			continue
+		}
+		if player, ok := target.(*gameobjects.Player); ok {
+			g.applyEnemySkillToPlayer(enemy, skill, player)
+			continue
+		}
+		g.applySkillHeal(enemy, skill, target)
+		if len(skill.Effects) > 0 {
+			g.applyCombatHitWithFeedback(systems.CombatHitRequest{Target: target, Skill: skill})
+		}
+	}
+}
+
+func (g *Game) applyEnemySkillToPlayer(enemy *gameobjects.Enemy, skill *gamedata.Skill, player *gameobjects.Player) {
+	sourceX, sourceY := enemy.Center()
+	damage, damageType := enemySkillDamage(enemy, skill)
+	if damage > 0 {
+		g.ApplyPlayerCombatHit(damage, damageType, sourceX, sourceY, skill.Effects)
+		return
+	}
+	if len(skill.Effects) > 0 && player.IsAlive() && player.CanTakeDirectHit() {
+		g.applyCombatHitWithFeedback(systems.CombatHitRequest{Target: player, Skill: skill, SuppressFlash: true})
+	}
+}
+
+func enemySkillDamage(enemy *gameobjects.Enemy, skill *gamedata.Skill) (int, gamedata.DamageType) {
+	if skill.DamageSpec == nil {
+		return 0, enemy.DamageType
+	}
+	return enemy.ScaledDamage(int(skill.DamageSpec.Base)), skill.DamageSpec.DamageType
+}
+
+func (g *Game) spawnEnemySkillProjectile(enemy *gameobjects.Enemy, skill *gamedata.Skill, intent systems.CastIntent) {
+	speed := skill.Delivery.Speed
+	if speed <= 0 {
+		return
+	}
+	lifetime := skill.Delivery.Lifetime
+	if lifetime <= 0 {
+		lifetime = 2.0
+	}
+	radius := skill.Delivery.ProjectileRadius
+	if radius <= 0 {
+		radius = 6
+	}
+
+	enemyX, enemyY := enemy.Center()
+	damage, damageType := enemySkillDamage(enemy, skill)
+	g.EnemyProjectiles = append(g.EnemyProjectiles, &EnemyProjectile{
+		X:          enemyX,
+		Y:          enemyY,
+		VX:         intent.DirectionX * speed,
+		VY:         intent.DirectionY * speed,
+		Speed:      speed,
+		Damage:     damage,
+		Radius:     radius,
+		Lifetime:   lifetime,
+		Alive:      true,
+		DamageType: damageType,
+		Effects:    skill.Effects,
+	})
+}
+
+func (g *Game) queueEnemyDelayedSkill(enemy *gameobjects.Enemy, skill *gamedata.Skill, intent systems.CastIntent) {
+	delay := skill.Delivery.Delay
+	if delay <= 0 {
+		delay = 0.1
+	}
+	radius := skill.Targeting.Radius
+	if radius <= 0 {
+		radius = 40
+	}
+
+	enemyX, enemyY := enemy.Center()
+	centerX, centerY := intent.CursorX, intent.CursorY
+	if skill.Targeting.Type == gamedata.TargetArea && skill.Targeting.Range == 0 {
+		centerX, centerY = enemyX, enemyY
+	}
+	dx := centerX - enemyX
+	dy := centerY - enemyY
+	if distance := systems.GetDistance(0, 0, dx, dy); skill.Targeting.Range > 0 && distance > skill.Targeting.Range {
+		centerX = enemyX + dx/distance*skill.Targeting.Range
+		centerY = enemyY + dy/distance*skill.Targeting.Range
+	}
+	if !skillPiercesTerrain(skill) {
+		centerX, centerY, _ = g.sweepTerrain(enemyX, enemyY, centerX, centerY, 0)
+	}
+
+	g.DelayedSkillEffects = append(g.DelayedSkillEffects, &DelayedSkillEffect{
+		X:            centerX,
+		Y:            centerY,
+		Radius:       radius,
+		Delay:        delay,
+		ActiveTime:   skill.Delivery.ZoneDuration,
+		TickRate:     skill.Delivery.ZoneTickRate,
+		Alive:        true,
+		Skill:        skill,
+		EnemyCaster:  enemy,
+		Intent:       intent,
+		LastAppliedX: centerX,
+		LastAppliedY: centerY,
+	})
+}
+
+func (g *Game) applyEnemyDelayedSkill(delayed *DelayedSkillEffect) int {
+	if g.Player == nil || !g.Player.IsAlive() {
+		return 0
+	}
+	playerX, playerY := g.Player.Center()
+	if systems.GetDistance(playerX, playerY, delayed.X, delayed.Y) > delayed.Radius+g.Player.Hitbox.Width/2 {
+		return 0
+	}
+	if g.terrainOccludes(delayed.Skill, delayed.X, delayed.Y, g.Player) {
+		return 0
+	}
+	g.applyEnemySkillToPlayer(delayed.EnemyCaster, delayed.Skill, g.Player)
+	return 1
+}
+
+func (g *Game) chargeEnemyToward(enemy *gameobjects.Enemy, targetX, targetY, distance float32) {
+	enemyX, enemyY := enemy.Center()
+	dx := targetX - enemyX
+	dy := targetY - enemyY
+	gap := systems.GetDistance(0, 0, dx, dy)
+	if gap <= 0 {
+		return
+	}
+	reach := gap - actorBodyRadius(enemy)
+	if g.Player != nil {
+		reach -= actorBodyRadius(g.Player)
+	}
+	reach = min(reach, distance)
+	if reach <= 0 {
+		return
+	}
+
+	enemy.PosX, enemy.PosY = systems.ResolveEnemyMovementInSpace(
+		enemy.PosX,
+		enemy.PosY,
+		enemy.Hitbox.Width,
+		enemy.Hitbox.Height,
+		dx/gap*reach,
+		dy/gap*reach,
+		g.CurrentRoom,
+		g.combatSpace(),
+	)
+	enemy.FacingRight = dx >= 0
+	g.invalidateCombatSpace()
+}
+
+func (g *Game) summonEnemies(caster *gameobjects.Enemy, summon gamedata.SummonSpec) {
+	if summon.Count <= 0 {
+		return
+	}
+	archetypes := make([]gamedata.EnemyArchetypeType, summon.Count)
+	for i := range archetypes {
+		archetypes[i] = summon.Archetype
+	}
+	casterX, casterY := caster.Center()
+	for _, enemy := range g.spawnAddsAround(casterX, casterY, actorBodyRadius(caster), archetypes) {
+		enemy.XPReward = 0
+	}
+}
+
+func (g *Game) spawnAddsAround(centerX, centerY, innerRadius float32, archetypes []gamedata.EnemyArchetypeType) []*gameobjects.Enemy {
+	spawned := make([]*gameobjects.Enemy, 0, len(archetypes))
+	for i, archetype := range archetypes {
+		enemy := gameobjects.NewEnemyFromArchetype(0, 0, archetype, false, 0)
+		spacing := innerRadius + actorBodyRadius(enemy) + EnemySeparationPadding
+		angle := 2 * math.Pi * float64(i) / float64(len(archetypes))
+		enemy.PosX, enemy.PosY = systems.ResolveEnemyMovementInSpace(
+			centerX-enemy.Hitbox.Width/2,
+			centerY-enemy.Hitbox.Height/2,
+			enemy.Hitbox.Width,
+			enemy.Hitbox.Height,
+			float32(math.Cos(angle))*spacing,
+			float32(math.Sin(angle))*spacing,
+			g.CurrentRoom,
+			g.combatSpace(),
+		)
+		enemy.Provoked = true
+		g.Enemies = append(g.Enemies, enemy)
+		spawned = append(spawned, enemy)
+	}
+	g.invalidateCombatSpace()
+	return spawned
+}
@@ -0,0 +1,207 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+func newEnemySkillTestGame() *Game {
+	g := NewGame(settings.Default())
+	g.State = StateRun
+	g.Player = gameobjects.NewPlayer(600, 285, gamedata.ClassTypeMelee)
+	g.CurrentRoom = &world.Room{Width: 800, Height: 600}
+	g.invalidateCombatSpace()
+	g.Events.BeginFrame()
+	return g
+}
+
+func withEnemySkill(enemy *gameobjects.Enemy, id string, skill gamedata.Skill, use gamedata.EnemySkillUse) *gameobjects.Enemy {
+	skill.Type = gamedata.SkillTypeEnemy
+	enemy.Skills = append(enemy.Skills, gamedata.EnemySkill{ID: id, Skill: skill, Use: use})
+	enemy.Provoked = true
+	return enemy
+}
+
+func stepEnemySkills(g *Game) {
+	ctx := &RuntimeContext{Game: g}
+	(&aiSystem{}).Update(ctx, 0.016)
+	(&combatResolveSystem{}).Update(ctx, 0.016)
+}
+
+func TestHealerEnemyMendsMostHurtAllyWhenBelowThreshold(t *testing.T) {
+	g := newEnemySkillTestGame()
+	healer := withEnemySkill(gameobjects.NewEnemyFromArchetype(100, 100, gamedata.EnemyArchetypeHexCaller, false, 0), "mend", gamedata.Skill{
+		Cooldown:  6,
+		Targeting: gamedata.TargetingSpec{Type: gamedata.TargetAlly, Range: 200, MaxTargets: 1},
+		Delivery:  gamedata.DeliverySpec{Type: gamedata.DeliveryInstant},
+		Heal:      gamedata.HealSpec{Amount: 20},
+		Effects:   []gamedata.EffectSpec{{Type: gamedata.EffectDamageBoost, Duration: 5, Magnitude: 0.5}},
+	}, gamedata.EnemySkillUse{AllyHPBelow: 0.5})
+	ally := gameobjects.NewEnemyFromArchetype(160, 100, gamedata.EnemyArchetypeBrute, false, 0)
+	g.Enemies = []*gameobjects.Enemy{healer, ally}
+	g.invalidateCombatSpace()
+
+	stepEnemySkills(g)
+	if healer.Skills[0].Skill.CurrentCooldown != 0 {
+		t.Fatalf("expected healer to hold the mend while allies are healthy")
+	}
+
+	ally.HP = ally.MaxHP / 4
+	hurt := ally.HP
+	stepEnemySkills(g)
+	if ally.HP != hurt+20 || healer.Skills[0].Skill.CurrentCooldown == 0 {
+		t.Fatalf("expected mend to heal the wounded ally, got hp %d from %d", ally.HP, hurt)
+	}
+	if !gamedata.HasEffect(&ally.Effects, gamedata.EffectDamageBoost) || ally.ScaledDamage(10) != 15 {
+		t.Fatalf("expected mend to also buff the ally's damage")
+	}
+	if g.Events.CountFrameEvents(EventHealed) != 1 || g.Events.CountFrameEvents(EventEnemyCast) != 1 {
+		t.Fatalf("expected heal and cast events")
+	}
+}
+
+func TestChargerEnemyDashesIntoRangeAndHits(t *testing.T) {
+	g := newEnemySkillTestGame()
+	charger := withEnemySkill(gameobjects.NewEnemyFromArchetype(400, 285, gamedata.EnemyArchetypeRaider, false, 0), "charge", gamedata.Skill{
+		Cooldown:     5,
+		Targeting:    gamedata.TargetingSpec{Type: gamedata.TargetEnemy, Range: 60, MaxTargets: 1},
+		Delivery:     gamedata.DeliverySpec{Type: gamedata.DeliveryInstant},
+		DamageSpec:   &gamedata.DamageSpec{Base: 12, DamageType: gamedata.DamagePhysical},
+		SelfMovement: gamedata.SelfMovementSpec{Mode: gamedata.SelfMovementTowardTarget, Distance: 200},
+	}, gamedata.EnemySkillUse{MinRange: 100})
+	g.Enemies = []*gameobjects.Enemy{charger}
+	g.invalidateCombatSpace()
+	playerHP := g.Player.HP
+	startX := charger.PosX
+
+	stepEnemySkills(g)
+	chargerX, _ := charger.Center()
+	playerX, _ := g.Player.Center()
+	if charger.PosX <= startX+100 || playerX-chargerX > 60 {
+		t.Fatalf("expected charger to close the gap, moved from %v to %v", startX, charger.PosX)
+	}
+	if g.Player.HP >= playerHP {
+		t.Fatalf("expected the charge to hit, got hp %d from %d", g.Player.HP, playerHP)
+	}
+
+	charger.Skills[0].Skill.CurrentCooldown = 0
+	g.Player.HurtIFrameTimer = 0
+	stepEnemySkills(g)
+	if charger.Skills[0].Skill.CurrentCooldown != 0 {
+		t.Fatalf("expected charge to stay unused inside its minimum range")
+	}
+}
+
+func TestSummonerEnemyCallsAddsWhenPlayerCasts(t *testing.T) {
+	g := newEnemySkillTestGame()
+	summoner := withEnemySkill(gameobjects.NewEnemyFromArchetype(300, 285, gamedata.EnemyArchetypeHexCaller, false, 0), "call_swarm", gamedata.Skill{
+		Cooldown:  10,
+		Targeting: gamedata.TargetingSpec{Type: gamedata.TargetSelf},
+		Delivery:  gamedata.DeliverySpec{Type: gamedata.DeliveryInstant},
+		Summon:    gamedata.SummonSpec{Archetype: gamedata.EnemyArchetypeSwarmling, Count: 2},
+	}, gamedata.EnemySkillUse{MaxAllies: 1, PlayerCasting: true})
+	g.Enemies = []*gameobjects.Enemy{summoner}
+	g.invalidateCombatSpace()
+
+	stepEnemySkills(g)
+	if len(g.Enemies) != 1 {
+		t.Fatalf("expected no summon before the player casts")
+	}
+
+	g.Player.Mana = g.Player.MaxMana
+	g.TryCastSkill(g.Player.Skills[1], &systems.Input{})
+	stepEnemySkills(g)
+	if len(g.Enemies) != 3 {
+		t.Fatalf("expected two swarmlings after the player cast, got %d enemies", len(g.Enemies))
+	}
+	for _, add := range g.Enemies[1:] {
+		if add.Archetype != gamedata.EnemyArchetypeSwarmling || !add.Provoked {
+			t.Fatalf("expected provoked swarmling, got %+v", add)
+		}
+	}
+
+	summoner.Skills[0].Skill.CurrentCooldown = 0
+	g.playerCastTimer = EnemyCastReactionWindow
+	stepEnemySkills(g)
+	if len(g.Enemies) != 3 {
+		t.Fatalf("expected max_allies to stop further summons")
+	}
+}
+
+func TestKillingSummonedAddsGrantsNoXP(t *testing.T) {
+	g := newEnemySkillTestGame()
+	summoner := gameobjects.NewEnemyFromArchetype(300, 285, gamedata.EnemyArchetypeHexCaller, false, 0)
+	g.Enemies = []*gameobjects.Enemy{summoner}
+	g.summonEnemies(summoner, gamedata.SummonSpec{Archetype: gamedata.EnemyArchetypeSwarmling, Count: 2})
+	if len(g.Enemies) != 3 {
+		t.Fatalf("expected two summoned swarmlings, got %d enemies", len(g.Enemies))
+	}
+	shot, struck := g.Enemies[1], g.Enemies[2]
+	g.invalidateCombatSpace()
+	xpBefore := g.Player.XP
+
+	shotX, shotY := shot.Center()
+	projectile := &Projectile{X: shotX, Y: shotY, Damage: shot.MaxHP * 10, Radius: 5, HitTargets: map[core.Combatant]struct{}{}, Alive: true, Caster: g.Player, DamageType: gamedata.DamageTrue}
+	(&projectilesSystem{}).tryHitEnemy(g, projectile, shot)
+	if shot.IsAlive() {
+		t.Fatalf("expected the projectile to kill the summon")
+	}
+
+	struck.HP = 1
+	struck.Defense = gamedata.DefenseStats{}
+	g.Player.PosX = struck.PosX - g.Player.Hitbox.Width
+	g.Player.PosY = struck.PosY
+	g.PlayerAttackTarget = struck
+	g.resolveMeleeAutoAttack(g.Player.GetAutoAttackDamage())
+	if struck.IsAlive() {
+		t.Fatalf("expected the auto-attack to kill the summon")
+	}
+
+	if g.Player.XP != xpBefore {
+		t.Fatalf("expected summon kills to grant no XP, got %d -> %d", xpBefore, g.Player.XP)
+	}
+}
+
+func TestEnemyDelayedSkillHitsPlayerStillInZone(t *testing.T) {
+	g := newEnemySkillTestGame()
+	caster := withEnemySkill(gameobjects.NewEnemyFromArchetype(400, 285, gamedata.EnemyArchetypeHexCaller, false, 0), "hex_circle", gamedata.Skill{
+		Cooldown:   8,
+		Targeting:  gamedata.TargetingSpec{Type: gamedata.TargetArea, Range: 300, Radius: 50},
+		Delivery:   gamedata.DeliverySpec{Type: gamedata.DeliveryDelayed, Delay: 0.5},
+		DamageSpec: &gamedata.DamageSpec{Base: 15, DamageType: gamedata.DamageMagical},
+	}, gamedata.EnemySkillUse{})
+	g.Enemies = []*gameobjects.Enemy{caster}
+	g.invalidateCombatSpace()
+	playerHP := g.Player.HP
+
+	stepEnemySkills(g)
+	if len(g.DelayedSkillEffects) != 1 || g.DelayedSkillEffects[0].EnemyCaster != caster {
+		t.Fatalf("expected a telegraphed enemy zone under the player")
+	}
+	projectiles := &projectilesSystem{}
+	projectiles.updateDelayedSkillEffects(g, 0.6)
+	if g.Player.HP >= playerHP {
+		t.Fatalf("expected the zone to hit the player, got hp %d from %d", g.Player.HP, playerHP)
+	}
+	playerHP = g.Player.HP
+
+	caster.Skills[0].Skill.CurrentCooldown = 0
+	g.Player.HurtIFrameTimer = 0
+	stepEnemySkills(g)
+	if len(g.DelayedSkillEffects) != 1 {
+		t.Fatalf("expected a second telegraphed zone")
+	}
+	movePlayerCenterTo(g.Player, 700, 500)
+	projectiles.updateDelayedSkillEffects(g, 0.6)
+	if g.Player.HP != playerHP {
+		t.Fatalf("expected the player to dodge the second zone")
+	}
+}
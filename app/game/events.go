@@ -0,0 +1,171 @@
+package game
+
+import (
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/systems"
+)
+
+type CombatEventType int
+
+const (
+	EventDamageDealt CombatEventType = iota
+	EventHealed
+	EventEffectApplied
+	EventEntityKilled
+	EventLevelUp
+	EventSkillCast
+	EventSkillImpact
+	EventEnemyCast
+	EventDoorOpened
+	EventRoomCleared
+	EventRewardPicked
+	EventTrapTriggered
+	EventPropDestroyed
+	EventProjectileBlocked
+	EventEffectResisted
+	EventHitAvoided
+)
+
+func (t CombatEventType) String() string {
+	switch t {
+	case EventDamageDealt:
+		return "DamageDealt"
+	case EventHealed:
+		return "Healed"
+	case EventEffectApplied:
+		return "EffectApplied"
+	case EventEntityKilled:
+		return "EntityKilled"
+	case EventLevelUp:
+		return "LevelUp"
+	case EventSkillCast:
+		return "SkillCast"
+	case EventSkillImpact:
+		return "SkillImpact"
+	case EventEnemyCast:
+		return "EnemyCast"
+	case EventDoorOpened:
+		return "DoorOpened"
+	case EventRoomCleared:
+		return "RoomCleared"
+	case EventRewardPicked:
+		return "RewardPicked"
+	case EventTrapTriggered:
+		return "TrapTriggered"
+	case EventPropDestroyed:
+		return "PropDestroyed"
+	case EventProjectileBlocked:
+		return "ProjectileBlocked"
+	case EventEffectResisted:
+		return "EffectResisted"
+	case EventHitAvoided:
+		return "HitAvoided"
+	default:
+		return "Unknown"
+	}
+}
+
+type CombatEvent struct {
+	Type       CombatEventType
+	Source     core.Combatant
+	Target     core.Combatant
+	Amount     int
+	IsCrit     bool
+	Outcome    systems.HitOutcome
+	DamageType gamedata.DamageType
+	Effects    []gamedata.EffectSpec
+	Skill      *gamedata.Skill
+	Intent     systems.CastIntent
+	Item       *gamedata.Item
+	X          float32
+	Y          float32
+	RoomIndex  int
+	HealSource healingSoundSource
+}
+
+type CombatEventHandler func(event CombatEvent)
+
+// CombatEventBus dispatches events to subscribers as they are published and keeps the current step's events for inspection.
+type CombatEventBus struct {
+	handlers    map[CombatEventType][]CombatEventHandler
+	anyHandlers []CombatEventHandler
+	frame       []CombatEvent
+}
+
+func NewCombatEventBus() *CombatEventBus {
+	return &CombatEventBus{
+		handlers:    map[CombatEventType][]CombatEventHandler{},
+		anyHandlers: []CombatEventHandler{},
+		frame:       []CombatEvent{},
+	}
+}
+
+func (b *CombatEventBus) Subscribe(eventType CombatEventType, handler CombatEventHandler) {
+	if b == nil || handler == nil {
+		return
+	}
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *CombatEventBus) SubscribeAll(handler CombatEventHandler) {
+	if b == nil || handler == nil {
+		return
+	}
+	b.anyHandlers = append(b.anyHandlers, handler)
+}
+
+func (b *CombatEventBus) Publish(event CombatEvent) {
+	if b == nil {
+		return
+	}
+	b.frame = append(b.frame, event)
+	for _, handler := range b.handlers[event.Type] {
+		handler(event)
+	}
+	for _, handler := range b.anyHandlers {
+		handler(event)
+	}
+}
+
+func (b *CombatEventBus) BeginFrame() {
+	if b == nil {
+		return
+	}
+	b.frame = b.frame[:0]
+}
+
+func (b *CombatEventBus) FrameEvents() []CombatEvent {
+	if b == nil {
+		return nil
+	}
+	return b.frame
+}
+
+func (b *CombatEventBus) CountFrameEvents(eventType CombatEventType) int {
+	count := 0
+	for _, event := range b.FrameEvents() {
+		if event.Type == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+func (g *Game) newCombatEventBus() *CombatEventBus {
+	bus := NewCombatEventBus()
+	g.subscribeCombatFeedback(bus)
+	g.subscribeAudio(bus)
+	g.subscribeTelemetry(bus)
+	return bus
+}
+
+func (g *Game) publish(event CombatEvent) {
+	if g == nil {
+		return
+	}
+	if g.Events == nil {
+		g.Events = g.newCombatEventBus()
+	}
+	g.Events.Publish(event)
+}
@@ -0,0 +1,98 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+)
+
+func TestCombatEventBusDispatchesTypedThenCatchAllHandlers(t *testing.T) {
+	bus := NewCombatEventBus()
+	order := []string{}
+	bus.SubscribeAll(func(event CombatEvent) {
+		order = append(order, "all:"+event.Type.String())
+	})
+	bus.Subscribe(EventDamageDealt, func(event CombatEvent) {
+		order = append(order, "damage")
+	})
+
+	bus.Publish(CombatEvent{Type: EventDamageDealt, Amount: 3})
+	bus.Publish(CombatEvent{Type: EventHealed, Amount: 2})
+
+	expected := []string{"damage", "all:DamageDealt", "all:Healed"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, order)
+		}
+	}
+	if bus.CountFrameEvents(EventDamageDealt) != 1 || len(bus.FrameEvents()) != 2 {
+		t.Fatalf("expected both events in the frame log")
+	}
+
+	bus.BeginFrame()
+	if len(bus.FrameEvents()) != 0 {
+		t.Fatalf("expected frame log to reset")
+	}
+}
+
+func TestLethalHitPublishesDamageAndKillEvents(t *testing.T) {
+	g := NewGame(settings.Default())
+	g.Player = gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	enemy := gameobjects.NewEnemy(0, 0, false)
+	enemy.HP = 1
+
+	killed := 0
+	g.Events.Subscribe(EventEntityKilled, func(event CombatEvent) {
+		if event.Target == enemy {
+			killed++
+		}
+	})
+
+	g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+		Caster:     g.Player,
+		Target:     enemy,
+		BaseDamage: 10,
+		DamageType: gamedata.DamagePhysical,
+	})
+
+	if g.Events.CountFrameEvents(EventDamageDealt) != 1 {
+		t.Fatalf("expected one damage event, got %d", g.Events.CountFrameEvents(EventDamageDealt))
+	}
+	if killed != 1 {
+		t.Fatalf("expected custom subscriber to see one kill, got %d", killed)
+	}
+	if g.Telemetry.Kills != 1 || g.Telemetry.DamageDealt <= 0 {
+		t.Fatalf("expected telemetry to count the kill and damage, got %+v", g.Telemetry)
+	}
+	if len(g.CombatTextEvents) == 0 {
+		t.Fatalf("expected feedback subscriber to spawn combat text")
+	}
+}
+
+func TestLevelUpEventPlaysCappedSounds(t *testing.T) {
+	g := NewGame(settings.Default())
+	g.Player = gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	played := 0
+	g.soundPlayer = func(key string) {
+		if key == sfxPlayerLevelUp {
+			played++
+		}
+	}
+
+	g.publish(CombatEvent{Type: EventLevelUp, Target: g.Player, Amount: maxLevelUpSoundsPerGrant + 2})
+
+	if played != maxLevelUpSoundsPerGrant {
+		t.Fatalf("expected %d level-up sounds, got %d", maxLevelUpSoundsPerGrant, played)
+	}
+	if g.Telemetry.LevelUps != maxLevelUpSoundsPerGrant+2 {
+		t.Fatalf("expected telemetry to count every level, got %d", g.Telemetry.LevelUps)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"singlefantasy/app/assets"
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 	"singlefantasy/app/settings"
@@ -40,6 +41,9 @@ type Game struct {
 	Player                   *gameobjects.Player
 	Enemies                  []*gameobjects.Enemy
 	Boss                     *gameobjects.Boss
+	Props                    []*gameobjects.Prop
+	Pickups                  []*Pickup
+	RoomEvent                *EventState
 	Dungeon                  *world.Dungeon
 	Camera                   *systems.Camera
 	Projectiles              []*Projectile
@@ -61,21 +65,39 @@ type Game struct {
 	PlayerMoveTargetX        float32
 	PlayerMoveTargetY        float32
 	HasPlayerMoveTarget      bool
-	PlayerAttackTarget       interface{}
+	PlayerAttackTarget       core.Combatant
 	RoomTransitionTimer      float32
 	RoomTransitionDuration   float32
 	PendingRoomTransition    bool
 	BossRewardTriggered      bool
 	DebugOverlayEnabled      bool
+	RunStartError            string
+	HotReload                *HotReloadWatcher
 	BootCompleted            bool
 	LastFrameTime            float32
 	LastUpdateSteps          int
 	RunElapsed               float32
 	Results                  RunResults
 	RunPipeline              *RuntimePipeline
+	RNG                      *core.RunRNG
+	Events                   *CombatEventBus
+	Space                    *systems.CombatSpace
+	Telemetry                RunTelemetry
 	Settings                 settings.Settings
+	InputSource              systems.InputSource
+	RunSavePath              string
 	soundPlayer              func(string)
 	soundCooldowns           map[string]float32
+	spaceDirty               bool
+	blockers                 []world.AABB
+	blockersDirty            bool
+	nav                      *navigationState
+	playerCastTimer          float32
+	recordReplays            bool
+	replayRecorder           *replayRecorder
+	replayPlayback           *replayPlayback
+	pendingReplay            *Replay
+	hasRunSave               bool
 }
 
 type Projectile struct {
@@ -88,11 +110,13 @@ type Projectile struct {
 	Radius     float32
 	Lifetime   float32
 	Pierce     int
-	HitTargets map[interface{}]struct{}
+	HitTargets map[core.Combatant]struct{}
 	Alive      bool
 	Skill      *gamedata.Skill
 	Caster     *gameobjects.Player
 	DamageType gamedata.DamageType
+	// FromItemProc marks bolts fired by item effects; their hits skip on-hit hooks so procs cannot chain.
+	FromItemProc bool
 }
 
 type EnemyProjectile struct {
@@ -121,6 +145,7 @@ type DelayedSkillEffect struct {
 	Alive        bool
 	Skill        *gamedata.Skill
 	Caster       *gameobjects.Player
+	EnemyCaster  *gameobjects.Enemy
 	Intent       systems.CastIntent
 	LastAppliedX float32
 	LastAppliedY float32
@@ -137,7 +162,7 @@ type SkillVisualEffect struct {
 }
 
 func NewGame(cfg settings.Settings) *Game {
-	return &Game{
+	g := &Game{
 		State:                    StateBoot,
 		Player:                   nil,
 		Enemies:                  []*gameobjects.Enemy{},
@@ -175,10 +200,17 @@ func NewGame(cfg settings.Settings) *Game {
 		RunElapsed:               0,
 		Results:                  RunResults{},
 		RunPipeline:              NewRuntimePipeline(),
+		RNG:                      nil,
 		Settings:                 cfg,
+		InputSource:              &systems.RaylibInputSource{},
+		RunSavePath:              DefaultRunSavePath,
 		soundPlayer:              nil,
 		soundCooldowns:           map[string]float32{},
+		spaceDirty:               true,
+		blockersDirty:            true,
 	}
+	g.Events = g.newCombatEventBus()
+	return g
 }
 
 func (g *Game) SetFrameDiagnostics(frameTime float32, updateSteps int) {
@@ -191,6 +223,10 @@ func (g *Game) UpdateFrame() {
 		g.DebugOverlayEnabled = !g.DebugOverlayEnabled
 	}
 
+	if g.State == StateRun && g.HotReload != nil {
+		g.updateHotReload()
+	}
+
 	switch g.State {
 	case StateBoot:
 		g.updateBoot()
@@ -255,11 +291,16 @@ func (g *Game) updateBoot() {
 		g.BootCompleted = true
 	}
 
+	if g.pendingReplay != nil {
+		_ = g.StartReplay(g.pendingReplay)
+		return
+	}
 	g.EnterMainMenu()
 }
 
 func (g *Game) EnterMainMenu() {
 	g.ResetState()
+	g.hasRunSave = HasRunSave(g.RunSavePath)
 	g.State = StateMainMenu
 }
 
@@ -305,6 +346,7 @@ func (g *Game) DebugLoadRoomTemplate(templateID string) error {
 	g.RewardHistory = []gamedata.RewardOfferHistoryEntry{}
 	g.RewardContext = gamedata.RewardContextNone
 	g.RewardSeed = dungeon.Seed
+	g.RNG = core.NewRunRNG(dungeon.Seed)
 
 	g.SpawnRoomEnemies()
 	if g.CurrentRoom != nil && !g.CurrentRoom.IsBoss() {
@@ -315,14 +357,27 @@ func (g *Game) DebugLoadRoomTemplate(templateID string) error {
 	return nil
 }
 
-func (g *Game) StartRun() {
+func (g *Game) StartRun() error {
+	return g.StartRunWithSeed(world.DefaultDungeonSeed)
+}
+
+// StartRunWithSeed generates the dungeon and drops the player into its first room. A generation failure returns
+// to the main menu with the error shown in the debug overlay rather than posing as a lost run.
+func (g *Game) StartRunWithSeed(seed int64) error {
 	g.ResetState()
-	g.Dungeon = world.NewDungeon()
+	g.RunStartError = ""
+	cfg := world.DefaultDungeonGenerationConfig()
+	cfg.Seed = seed
+	dungeon, err := world.NewDungeonWithConfig(cfg)
+	if err != nil {
+		return g.failRunStart(fmt.Errorf("generate dungeon for seed %d: %w", seed, err))
+	}
+	g.Dungeon = dungeon
+	g.RNG = core.NewRunRNG(dungeon.Seed)
 	g.CurrentRoom = g.Dungeon.GetCurrentRoom()
 
 	if g.CurrentRoom == nil {
-		g.EnterResults(false, "")
-		return
+		return g.failRunStart(fmt.Errorf("dungeon for seed %d has no starting room", seed))
 	}
 
 	startX := g.CurrentRoom.X + g.CurrentRoom.Width/2
@@ -343,7 +398,16 @@ func (g *Game) StartRun() {
 	} else {
 		g.RewardSeed = world.DefaultDungeonSeed
 	}
+	g.attachReplayRecorder()
 	g.State = StateRun
+	return nil
+}
+
+func (g *Game) failRunStart(err error) error {
+	g.EnterMainMenu()
+	g.RunStartError = err.Error()
+	g.DebugOverlayEnabled = true
+	return err
 }
 
 func (g *Game) EnterReward() {
@@ -440,6 +504,9 @@ func (g *Game) EnterResults(victory bool, rewardPicked string) {
 		}
 	}
 
+	_ = DeleteRunSave(g.RunSavePath)
+	g.hasRunSave = false
+
 	g.Results = RunResults{
 		Victory:            victory,
 		RunDurationSeconds: g.RunElapsed,
@@ -458,6 +525,9 @@ func (g *Game) ResetState() {
 	g.Player = nil
 	g.Enemies = []*gameobjects.Enemy{}
 	g.Boss = nil
+	g.Props = []*gameobjects.Prop{}
+	g.Pickups = []*Pickup{}
+	g.RoomEvent = nil
 	g.Dungeon = nil
 	g.Projectiles = []*Projectile{}
 	g.EnemyProjectiles = []*EnemyProjectile{}
@@ -483,6 +553,11 @@ func (g *Game) ResetState() {
 	g.PendingRoomTransition = false
 	g.BossRewardTriggered = false
 	g.RunElapsed = 0
+	g.RNG = nil
+	g.Telemetry = RunTelemetry{}
+	g.invalidatePropBlockers()
+	g.nav = nil
+	g.playerCastTimer = 0
 	g.soundCooldowns = map[string]float32{}
 }
 
@@ -493,6 +568,7 @@ func (g *Game) SpawnRoomEnemies() {
 
 	g.Enemies = []*gameobjects.Enemy{}
 	g.Boss = nil
+	g.spawnRoomProps()
 
 	if g.CurrentRoom.IsBoss() {
 		roomCenterX := g.CurrentRoom.X + g.CurrentRoom.Width/2
@@ -511,6 +587,14 @@ func (g *Game) SpawnRoomEnemies() {
 			g.CurrentRoom.EventTimeLeft = g.CurrentRoom.EventDuration
 		}
 	}
+	g.resetRoomEvent()
+}
+
+func (g *Game) currentRoomIndex() int {
+	if g.Dungeon == nil {
+		return -1
+	}
+	return g.Dungeon.CurrentRoom
 }
 
 func (g *Game) CheckRoomCompletion() bool {
@@ -527,7 +611,10 @@ func (g *Game) CheckRoomCompletion() bool {
 	}
 
 	if g.CurrentRoom.Type == world.RoomTypeEvent {
-		if g.CurrentRoom.EventTimeLeft <= 0 {
+		if g.roomEventComplete() {
+			if !g.CurrentRoom.Completed {
+				g.finishRoomEvent()
+			}
 			g.CurrentRoom.Completed = true
 			return true
 		}
@@ -579,6 +666,7 @@ func (g *Game) AdvanceToNextRoom() {
 	g.Player.PosY = startY
 	g.Player.Alive = true
 
+	g.RNG.BeginRoom(g.Dungeon.CurrentRoom)
 	g.SpawnRoomEnemies()
 	if !g.CurrentRoom.IsBoss() {
 		g.CurrentRoom.SetDoorsLocked(true)
@@ -593,6 +681,7 @@ func (g *Game) AdvanceToNextRoom() {
 	g.RoomTransitionTimer = 0
 	g.PendingRoomTransition = false
 	g.BossRewardTriggered = false
+	systems.TriggerItemEffects(g.Player, gamedata.ItemTriggerOnRoomEnter, g.itemProcContext())
 }
 
 func (g *Game) IsMenuOpen() bool {
@@ -600,6 +689,13 @@ func (g *Game) IsMenuOpen() bool {
 }
 
 func (g *Game) updateMainMenu() {
+	if g.hasRunSave && rl.IsKeyPressed(rl.KeyC) {
+		if err := g.ContinueSavedRun(); err != nil {
+			_ = DeleteRunSave(g.RunSavePath)
+			g.hasRunSave = false
+		}
+		return
+	}
 	if rl.IsKeyPressed(rl.KeyEnter) || rl.IsKeyPressed(rl.KeySpace) {
 		g.EnterClassSelect()
 	}
@@ -616,7 +712,7 @@ func (g *Game) updateClassSelect() {
 		g.SelectedClass = gamedata.ClassTypeCaster
 	}
 	if rl.IsKeyPressed(rl.KeyEnter) || rl.IsKeyPressed(rl.KeySpace) {
-		g.StartRun()
+		_ = g.StartRun()
 	}
 }
 
@@ -631,6 +727,12 @@ func (g *Game) updateReward() {
 		g.SelectedReward = 2
 	}
 
+	if g.replayPlayback != nil {
+		g.resolveReplayRewardPick()
+		g.confirmRewardSelection()
+		return
+	}
+
 	if !rl.IsKeyPressed(rl.KeyEnter) {
 		return
 	}
@@ -643,12 +745,15 @@ func (g *Game) confirmRewardSelection() {
 		return
 	}
 
+	g.recordReplayRewardPick()
+
 	rewardPicked := "None"
 	if g.Player != nil && g.SelectedReward >= 0 && g.SelectedReward < len(g.RewardOptions) {
 		item := g.RewardOptions[g.SelectedReward]
 		if item != nil {
 			g.Player.EquipItem(item)
 			rewardPicked = item.Name
+			g.publish(CombatEvent{Type: EventRewardPicked, Target: g.Player, Item: item})
 		}
 	}
 
@@ -679,6 +784,8 @@ func (g *Game) updateRun(deltaTime float32) {
 	if g.RunPipeline == nil {
 		g.RunPipeline = NewRuntimePipeline()
 	}
+	g.Events.BeginFrame()
+	g.invalidateCombatSpace()
 	g.RunPipeline.Update(NewRuntimeContext(g), deltaTime)
 }
 
@@ -702,7 +809,10 @@ func (g *Game) Draw() {
 	}
 
 	if g.DebugOverlayEnabled {
-		systems.DrawDebugOverlay(g.GetDebugLines())
+		bottom := systems.DrawDebugOverlay(g.GetDebugLines())
+		if g.State == StateRun {
+			systems.DrawDebugTimingBars(g.debugTimingBars(), FixedDeltaTime*1000, 10, bottom+10)
+		}
 	}
 
 	rl.EndDrawing()
@@ -715,6 +825,11 @@ func (g *Game) drawBootScreen() {
 func (g *Game) drawMainMenu() {
 	rl.DrawText("Single Fantasy", WindowWidth/2-140, WindowHeight/2-120, 48, rl.Black)
 	rl.DrawText("Press ENTER or SPACE to Start", WindowWidth/2-170, WindowHeight/2-20, 24, rl.DarkGray)
+	if g.hasRunSave {
+		rl.DrawText("Press C to Continue", WindowWidth/2-110, WindowHeight/2+20, 24, rl.DarkGray)
+		rl.DrawText("F3 toggles debug overlay", WindowWidth/2-130, WindowHeight/2+60, 20, rl.Gray)
+		return
+	}
 	rl.DrawText("F3 toggles debug overlay", WindowWidth/2-130, WindowHeight/2+20, 20, rl.Gray)
 }
 
@@ -767,9 +882,35 @@ func (g *Game) drawRun() {
 		systems.DrawSkillCastPulse(visual.X, visual.Y, visual.Radius, visual.TimeLeft/visual.Duration, visual.Skill, visual.Filled, g.Camera)
 	}
 
-	queue := make([]systems.RenderQueueItem, 0, len(g.Enemies)+len(g.Projectiles)+len(g.EnemyProjectiles)+4)
+	g.drawRoomEvent()
+
+	for _, pickup := range g.Pickups {
+		if pickup == nil || !pickup.Alive {
+			continue
+		}
+		systems.DrawPickup(pickup.X, pickup.Y, pickup.Radius, pickup.Type, g.Camera)
+	}
+
+	queue := make([]systems.RenderQueueItem, 0, len(g.Enemies)+len(g.Props)+len(g.Projectiles)+len(g.EnemyProjectiles)+4)
 	stableID := 0
 
+	for _, prop := range g.Props {
+		if prop == nil || !prop.IsAlive() {
+			continue
+		}
+		depthY, depthX := systems.DepthSortKey(prop.PosX+prop.Hitbox.Width/2, prop.PosY+prop.Hitbox.Height)
+		targetProp := prop
+		queue = append(queue, systems.RenderQueueItem{
+			DepthY:   depthY,
+			DepthX:   depthX,
+			StableID: stableID,
+			Draw: func() {
+				systems.DrawProp(targetProp, g.Camera)
+			},
+		})
+		stableID++
+	}
+
 	for _, proj := range g.Projectiles {
 		if !proj.Alive {
 			continue
@@ -897,6 +1038,9 @@ func (g *Game) drawRun() {
 			text := fmt.Sprintf("%s: %d", stat, statValues[i])
 			rl.DrawText(text, WindowWidth/2-180, WindowHeight/2-45+int32(i*25), 18, rl.White)
 		}
+		for i, line := range combatStatLines(g.Player.DerivedStats) {
+			rl.DrawText(line, WindowWidth/2+10, WindowHeight/2-45+int32(i*20), 16, rl.LightGray)
+		}
 	}
 
 	if g.RoomTransitionTimer > 0 && g.RoomTransitionDuration > 0 {
@@ -1084,6 +1228,9 @@ func (g *Game) GetDebugLines() []string {
 		fmt.Sprintf("Fixed updates/frame: %d", g.LastUpdateSteps),
 		fmt.Sprintf("Frame time (clamped): %.3f s", g.LastFrameTime),
 	}
+	if g.RunStartError != "" {
+		lines = append(lines, "Run start error: "+g.RunStartError)
+	}
 
 	if g.State == StateRun {
 		roomIndex := 0
@@ -1131,7 +1278,7 @@ func (g *Game) GetDebugLines() []string {
 			lines = append(lines, fmt.Sprintf("Template: %s", g.CurrentRoom.TemplateID))
 			lines = append(lines, fmt.Sprintf("Room type: %s (rot %d)", g.CurrentRoom.Type.String(), g.CurrentRoom.Rotation))
 			if g.CurrentRoom.Type == world.RoomTypeEvent {
-				lines = append(lines, fmt.Sprintf("Event timer: %.1fs", g.CurrentRoom.EventTimeLeft))
+				lines = append(lines, fmt.Sprintf("Event: %s timer %.1fs", g.roomEventKind(), g.CurrentRoom.EventTimeLeft))
 			}
 		}
 		lines = append(lines, fmt.Sprintf("Enemies (alive/total): %d/%d", aliveEnemies, len(g.Enemies)))
@@ -1160,7 +1307,7 @@ func (g *Game) GetDebugLines() []string {
 
 		lines = append(lines, fmt.Sprintf("Projectiles (player/enemy/boss): %d/%d/%d", activeProjectiles, enemyProjectiles, bossProjectiles))
 		if g.Boss != nil && g.Boss.IsAlive() {
-			lines = append(lines, fmt.Sprintf("Boss phase: %s", g.Boss.Phase.String()))
+			lines = append(lines, fmt.Sprintf("Boss phase: %s", g.Boss.PhaseName()))
 			lines = append(lines, fmt.Sprintf("Boss heavy: %s (%.2fs)", g.Boss.HeavyState.String(), g.Boss.HeavyTimeRemaining()))
 			lines = append(lines, fmt.Sprintf("Boss zones active: %d", g.Boss.ActiveZoneCount()))
 		}
@@ -1171,8 +1318,11 @@ func (g *Game) GetDebugLines() []string {
 			}
 		}
 		lines = append(lines, fmt.Sprintf("Delayed skill effects: %d", activeDelayed))
+		lines = append(lines, g.Telemetry.DebugLine())
+		lines = append(lines, g.HotReload.DebugLines()...)
 		if g.RunPipeline != nil {
 			lines = append(lines, fmt.Sprintf("Pipeline: %s", g.RunPipeline.OrderString()))
+			lines = append(lines, fmt.Sprintf("Step cost (avg): %.2f ms / %.2f ms budget", durationMs(g.RunPipeline.Profiler().TotalAverage()), FixedDeltaTime*1000))
 		}
 	}
 
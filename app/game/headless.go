@@ -0,0 +1,73 @@
+package game
+
+import (
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+type HeadlessConfig struct {
+	Class    gamedata.ClassType
+	Seed     int64
+	Settings settings.Settings
+	Input    systems.InputSource
+	Record   bool
+}
+
+// NewHeadlessGame starts a run that never touches the window, asset manager, or audio device.
+func NewHeadlessGame(cfg HeadlessConfig) *Game {
+	if cfg.Seed == 0 {
+		cfg.Seed = world.DefaultDungeonSeed
+	}
+	if cfg.Input == nil {
+		cfg.Input = systems.NewScriptedInputSource()
+	}
+
+	g := NewGame(cfg.Settings)
+	g.InputSource = cfg.Input
+	g.RunSavePath = ""
+	g.soundPlayer = func(string) {}
+	g.BootCompleted = true
+	g.SelectedClass = cfg.Class
+	if cfg.Record {
+		g.EnableReplayRecording()
+	}
+	_ = g.StartRunWithSeed(cfg.Seed)
+	return g
+}
+
+// NewHeadlessReplayGame plays a recorded replay back through the same fixed-step pipeline.
+func NewHeadlessReplayGame(replay *Replay, cfg settings.Settings) *Game {
+	g := NewGame(cfg)
+	g.RunSavePath = ""
+	g.soundPlayer = func(string) {}
+	g.BootCompleted = true
+	_ = g.StartReplay(replay)
+	return g
+}
+
+// StepHeadless advances the run by fixed steps, auto-confirming the highlighted reward,
+// and stops early once results are reached. It returns the number of steps taken.
+func (g *Game) StepHeadless(steps int) int {
+	if g == nil {
+		return 0
+	}
+
+	taken := 0
+	for taken < steps {
+		switch g.State {
+		case StateResults:
+			return taken
+		case StateReward:
+			g.resolveReplayRewardPick()
+			g.confirmRewardSelection()
+		case StateRun:
+			g.UpdateFixed(FixedDeltaTime)
+		default:
+			return taken
+		}
+		taken++
+	}
+	return taken
+}
@@ -0,0 +1,135 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+func newTestHeadlessGame(t *testing.T, source *systems.ScriptedInputSource) *Game {
+	t.Helper()
+	g := NewHeadlessGame(HeadlessConfig{
+		Class:    gamedata.ClassTypeMelee,
+		Seed:     world.DefaultDungeonSeed,
+		Settings: settings.Default(),
+		Input:    source,
+	})
+	if g.State != StateRun {
+		t.Fatalf("expected headless game to start in run state, got %s", g.GetStateName())
+	}
+	if g.Player == nil || g.CurrentRoom == nil {
+		t.Fatalf("expected headless run to create player and room")
+	}
+	return g
+}
+
+func TestHeadlessGameStepsPipelineWithoutWindow(t *testing.T) {
+	g := newTestHeadlessGame(t, systems.NewScriptedInputSource())
+
+	taken := g.StepHeadless(60)
+	if taken != 60 {
+		t.Fatalf("expected 60 headless steps, got %d", taken)
+	}
+	if g.RunElapsed < 0.99 || g.RunElapsed > 1.01 {
+		t.Fatalf("expected about one second of run time, got %.3f", g.RunElapsed)
+	}
+}
+
+func TestHeadlessScriptedMoveInputDrivesPlayer(t *testing.T) {
+	source := systems.NewScriptedInputSource()
+	g := newTestHeadlessGame(t, source)
+	for _, enemy := range g.Enemies {
+		enemy.Alive = false
+	}
+
+	startX := g.Player.PosX
+	centerX, centerY := g.Player.Center()
+	source.Push(systems.Input{
+		MoveToX:       centerX + 80,
+		MoveToY:       centerY,
+		HasMoveTarget: true,
+		CursorWorldX:  centerX + 80,
+		CursorWorldY:  centerY,
+	})
+
+	g.StepHeadless(30)
+	if g.Player.PosX <= startX {
+		t.Fatalf("expected scripted move target to push player east, start=%.2f now=%.2f", startX, g.Player.PosX)
+	}
+}
+
+func TestHeadlessStatAllocationInputSpendsPoints(t *testing.T) {
+	source := systems.NewScriptedInputSource()
+	g := newTestHeadlessGame(t, source)
+	g.Player.StatPoints = 2
+	g.LevelUpMenu = true
+	strBefore := g.Player.Stats.STR
+
+	source.Push(systems.Input{StatAllocations: []gamedata.StatType{gamedata.StatTypeSTR, gamedata.StatTypeSTR}})
+	g.StepHeadless(1)
+
+	if g.Player.Stats.STR != strBefore+2 {
+		t.Fatalf("expected STR to increase by 2, got %d -> %d", strBefore, g.Player.Stats.STR)
+	}
+	if g.Player.StatPoints != 0 || g.LevelUpMenu {
+		t.Fatalf("expected level-up menu to close after spending points, points=%d menu=%v", g.Player.StatPoints, g.LevelUpMenu)
+	}
+}
+
+func TestHeadlessRunClearsRoomAndWalksThroughDoor(t *testing.T) {
+	source := systems.NewScriptedInputSource()
+	g := newTestHeadlessGame(t, source)
+	for _, enemy := range g.Enemies {
+		enemy.Alive = false
+	}
+
+	door := g.CurrentRoom.DoorByDirection(world.DoorDirectionEast)
+	if door == nil {
+		t.Fatalf("expected start room to have an east door")
+	}
+	targetX := door.Bounds.X + door.Bounds.Width/2
+	targetY := door.Bounds.Y + door.Bounds.Height/2
+	source.Push(systems.Input{
+		MoveToX:       targetX,
+		MoveToY:       targetY,
+		HasMoveTarget: true,
+		CursorWorldX:  targetX,
+		CursorWorldY:  targetY,
+	})
+
+	for i := 0; i < 900 && g.Dungeon.CurrentRoom == 0; i++ {
+		g.StepHeadless(1)
+	}
+
+	if g.Dungeon.CurrentRoom != 1 {
+		t.Fatalf("expected headless run to advance to room 1, still in room %d", g.Dungeon.CurrentRoom)
+	}
+	if !g.Dungeon.Rooms[0].Completed {
+		t.Fatalf("expected first room to be marked completed")
+	}
+	if g.State != StateRun {
+		t.Fatalf("expected run to continue after room transition, got %s", g.GetStateName())
+	}
+}
+
+func TestStartRunWithSeedReportsDungeonGenerationFailure(t *testing.T) {
+	t.Chdir(t.TempDir())
+	g := NewGame(settings.Default())
+	g.RunSavePath = ""
+
+	err := g.StartRunWithSeed(world.DefaultDungeonSeed)
+	if err == nil {
+		t.Fatalf("expected missing room templates to fail the run start")
+	}
+	if g.State != StateMainMenu || g.Results.TotalRooms != 0 {
+		t.Fatalf("expected a failed start to return to the main menu instead of results, got %s", g.GetStateName())
+	}
+	if g.RunStartError != err.Error() || !g.DebugOverlayEnabled {
+		t.Fatalf("expected the error to be kept for the debug overlay, got %q", g.RunStartError)
+	}
+}
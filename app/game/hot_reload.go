@@ -0,0 +1,184 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/world"
+)
+
+const HotReloadPollInterval = 500 * time.Millisecond
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// HotReloadWatcher polls the room template and content data trees for modified files.
+type HotReloadWatcher struct {
+	RoomsRoot  string
+	DataRoot   string
+	LastReload string
+	LastError  string
+	roomStamps map[string]fileStamp
+	dataStamps map[string]fileStamp
+	lastPoll   time.Time
+	now        func() time.Time
+}
+
+func NewHotReloadWatcher(roomsRoot, dataRoot string) *HotReloadWatcher {
+	watcher := &HotReloadWatcher{
+		RoomsRoot: world.ResolveRoomsRoot(roomsRoot),
+		DataRoot:  gamedata.ResolveDataRoot(dataRoot),
+		now:       time.Now,
+	}
+	watcher.roomStamps = scanFileStamps(watcher.RoomsRoot)
+	watcher.dataStamps = scanFileStamps(watcher.DataRoot)
+	watcher.lastPoll = watcher.now()
+	return watcher
+}
+
+// Poll reports which trees changed since the last poll, at most once per HotReloadPollInterval.
+func (w *HotReloadWatcher) Poll() (roomsChanged bool, dataChanged bool) {
+	if w == nil {
+		return false, false
+	}
+	now := w.now()
+	if now.Sub(w.lastPoll) < HotReloadPollInterval {
+		return false, false
+	}
+	w.lastPoll = now
+
+	roomStamps := scanFileStamps(w.RoomsRoot)
+	dataStamps := scanFileStamps(w.DataRoot)
+	roomsChanged = !maps.Equal(roomStamps, w.roomStamps)
+	dataChanged = !maps.Equal(dataStamps, w.dataStamps)
+	w.roomStamps = roomStamps
+	w.dataStamps = dataStamps
+	return roomsChanged, dataChanged
+}
+
+func (w *HotReloadWatcher) DebugLines() []string {
+	if w == nil {
+		return nil
+	}
+	lines := []string{}
+	if w.LastReload != "" {
+		lines = append(lines, "Hot reload: "+w.LastReload)
+	}
+	if w.LastError != "" {
+		lines = append(lines, "Reload error: "+w.LastError)
+	}
+	return lines
+}
+
+func scanFileStamps(root string) map[string]fileStamp {
+	stamps := map[string]fileStamp{}
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	return stamps
+}
+
+func (g *Game) EnableHotReload(roomsRoot, dataRoot string) {
+	g.HotReload = NewHotReloadWatcher(roomsRoot, dataRoot)
+}
+
+func (g *Game) updateHotReload() {
+	roomsChanged, dataChanged := g.HotReload.Poll()
+	if !roomsChanged && !dataChanged {
+		return
+	}
+
+	errs := []error{}
+	reloaded := []string{}
+	if dataChanged {
+		if err := g.reloadContent(); err != nil {
+			errs = append(errs, err)
+		} else {
+			reloaded = append(reloaded, "content data")
+		}
+	}
+	if roomsChanged {
+		if err := g.reloadRoomTemplates(); err != nil {
+			errs = append(errs, err)
+		} else if g.CurrentRoom != nil {
+			reloaded = append(reloaded, fmt.Sprintf("room %s", g.CurrentRoom.TemplateID))
+		} else {
+			reloaded = append(reloaded, "room templates")
+		}
+	}
+
+	if len(reloaded) > 0 {
+		g.HotReload.LastReload = strings.Join(reloaded, ", ")
+	}
+	g.HotReload.LastError = ""
+	if len(errs) > 0 {
+		g.HotReload.LastError = errors.Join(errs...).Error()
+		g.DebugOverlayEnabled = true
+	}
+}
+
+func (g *Game) reloadContent() error {
+	content, err := gamedata.LoadContent(g.HotReload.DataRoot)
+	if err != nil {
+		return err
+	}
+	gamedata.ApplyContent(content)
+
+	if g.Player != nil {
+		g.Player.RefreshClassData()
+	}
+	for _, enemy := range g.Enemies {
+		enemy.RefreshArchetype()
+	}
+	g.Boss.RefreshConfig()
+	g.invalidateCombatSpace()
+	return nil
+}
+
+// reloadRoomTemplates validates every template and rebuilds the current room in place from its new layout.
+func (g *Game) reloadRoomTemplates() error {
+	registry, err := world.LoadRoomTemplateRegistry(g.HotReload.RoomsRoot)
+	if err != nil {
+		return err
+	}
+	if g.Dungeon == nil || g.CurrentRoom == nil {
+		return nil
+	}
+	room, err := g.Dungeon.RebuildCurrentRoom(registry)
+	if err != nil {
+		return err
+	}
+
+	g.CurrentRoom = room
+	if !room.Completed {
+		g.SpawnRoomEnemies()
+	} else {
+		g.spawnRoomProps()
+	}
+	g.invalidateCombatSpace()
+	if g.Player == nil {
+		return nil
+	}
+	bounds := world.AABB{X: g.Player.PosX, Y: g.Player.PosY, Width: g.Player.Hitbox.Width, Height: g.Player.Hitbox.Height}
+	if g.combatSpace().OverlapsObstacle(bounds) {
+		g.Player.PosX = room.X + room.Width/2 - g.Player.Hitbox.Width/2
+		g.Player.PosY = room.Y + room.Height/2 - g.Player.Hitbox.Height/2
+	}
+	return nil
+}
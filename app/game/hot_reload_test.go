@@ -0,0 +1,77 @@
+//go:build raylib
+
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/settings"
+)
+
+func TestHotReloadAppliesContentToLiveEntitiesAndReportsErrors(t *testing.T) {
+	t.Cleanup(gamedata.ResetContent)
+	roomsRoot := t.TempDir()
+	dataRoot := t.TempDir()
+
+	g := NewGame(settings.Default())
+	g.State = StateRun
+	g.Player = gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	g.Player.Skills[0].CurrentCooldown = 6
+	enemy := gameobjects.NewEnemyFromArchetype(100, 100, gamedata.EnemyArchetypeRaider, false, gamedata.EliteModifierScorching)
+	enemy.HP = enemy.MaxHP / 2
+	g.Enemies = []*gameobjects.Enemy{enemy}
+
+	clock := time.Unix(0, 0)
+	g.EnableHotReload(roomsRoot, dataRoot)
+	g.HotReload.now = func() time.Time { return clock }
+	g.HotReload.lastPoll = clock
+
+	writeHotReloadFile(t, dataRoot, gamedata.SkillsFile, `{"skills": [{"id": "power_strike", "name": "Power Strike", "cooldown": 3, "targeting": {"type": "enemy", "range": 60, "max_targets": 1}, "delivery": {"type": "instant"}}]}`)
+	writeHotReloadFile(t, dataRoot, gamedata.EnemiesFile, `{"archetypes": [{"id": "raider", "name": "Raider", "max_hp": 200, "damage": 30, "move_speed": 128, "attack_cooldown": 1, "attack_range": 56, "aggro_range": 320, "width": 30, "height": 30, "attack_mode": "melee", "damage_type": "physical"}]}`)
+
+	g.updateHotReload()
+	if enemy.Damage == 30 {
+		t.Fatalf("expected no reload before the poll interval elapses")
+	}
+
+	clock = clock.Add(HotReloadPollInterval)
+	g.updateHotReload()
+	if enemy.Damage != 30 || enemy.MaxHP != 200 || enemy.HP != 100 {
+		t.Fatalf("expected live enemy retuned with HP ratio kept, got dmg=%d hp=%d/%d", enemy.Damage, enemy.HP, enemy.MaxHP)
+	}
+	if g.Player.Skills[0].Cooldown != 3 || g.Player.Skills[0].CurrentCooldown != 3 {
+		t.Fatalf("expected live skill cooldown clamped to new value, got %+v", g.Player.Skills[0])
+	}
+	if g.HotReload.LastError != "" || !strings.Contains(g.HotReload.LastReload, "content") {
+		t.Fatalf("expected successful content reload status, got %+v", g.HotReload.DebugLines())
+	}
+
+	writeHotReloadFile(t, dataRoot, gamedata.EnemiesFile, `{"archetypes": [{"id": "raider", "name": ""}]}`)
+	clock = clock.Add(HotReloadPollInterval)
+	g.updateHotReload()
+	if !strings.Contains(g.HotReload.LastError, gamedata.EnemiesFile) || !g.DebugOverlayEnabled {
+		t.Fatalf("expected validation error in overlay, got %+v", g.HotReload.DebugLines())
+	}
+	if enemy.Damage != 30 || gamedata.GetEnemyArchetype(gamedata.EnemyArchetypeRaider).Damage != 30 {
+		t.Fatalf("expected failed reload to keep previous content")
+	}
+}
+
+func writeHotReloadFile(t *testing.T, root, name, body string) {
+	t.Helper()
+	path := filepath.Join(root, name)
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	// Bump the mtime so rewrites inside the filesystem timestamp granularity still register.
+	stamp := time.Now().Add(time.Duration(len(body)) * time.Second)
+	if err := os.Chtimes(path, stamp, stamp); err != nil {
+		t.Fatalf("touch %s: %v", name, err)
+	}
+}
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems"
+)
+
+const (
+	ItemProcVisualLength    = 0.3
+	ItemProcProjectileRange = 600
+)
+
+func (g *Game) ItemAreaDamage(owner *gameobjects.Player, x, y, radius float32, damage int, damageType gamedata.DamageType) {
+	if g == nil || damage <= 0 || radius <= 0 {
+		return
+	}
+
+	g.SkillVisualEffects = append(g.SkillVisualEffects, &SkillVisualEffect{
+		X:        x,
+		Y:        y,
+		Radius:   radius,
+		Duration: ItemProcVisualLength,
+		TimeLeft: ItemProcVisualLength,
+	})
+
+	for _, target := range g.combatSpace().CombatantsInCircle(x, y, radius) {
+		if !target.IsAlive() {
+			continue
+		}
+		targetX, targetY := target.Center()
+		if systems.GetDistance(x, y, targetX, targetY) > radius {
+			continue
+		}
+		g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+			Caster:     owner,
+			Target:     target,
+			BaseDamage: damage,
+			DamageType: damageType,
+		})
+	}
+}
+
+func (g *Game) ItemProjectile(owner *gameobjects.Player, x, y float32, target core.Combatant, damage int, damageType gamedata.DamageType, speed float32) {
+	if g == nil || damage <= 0 || speed <= 0 {
+		return
+	}
+	if target == nil || !target.IsAlive() {
+		target = g.nearestItemProcTarget(x, y)
+	}
+	if target == nil {
+		return
+	}
+
+	targetX, targetY := target.Center()
+	dx := targetX - x
+	dy := targetY - y
+	distance := systems.GetDistance(0, 0, dx, dy)
+	if distance <= 0 {
+		return
+	}
+
+	g.Projectiles = append(g.Projectiles, &Projectile{
+		X:            x,
+		Y:            y,
+		VX:           (dx / distance) * speed,
+		VY:           (dy / distance) * speed,
+		Speed:        speed,
+		Damage:       damage,
+		Radius:       5,
+		Lifetime:     ItemProcProjectileRange / speed,
+		HitTargets:   map[core.Combatant]struct{}{},
+		Alive:        true,
+		Caster:       owner,
+		DamageType:   damageType,
+		FromItemProc: true,
+	})
+}
+
+func (g *Game) nearestItemProcTarget(x, y float32) core.Combatant {
+	var nearest core.Combatant
+	nearestDistance := float32(ItemProcProjectileRange)
+	for _, candidate := range systems.CombatantsFrom(g.Enemies, g.Boss) {
+		if candidate == nil || !candidate.IsAlive() {
+			continue
+		}
+		candidateX, candidateY := candidate.Center()
+		if distance := systems.GetDistance(x, y, candidateX, candidateY); distance <= nearestDistance {
+			nearest = candidate
+			nearestDistance = distance
+		}
+	}
+	return nearest
+}
+
+func (g *Game) itemProcContext() systems.ItemProcContext {
+	return systems.ItemProcContext{RNG: g.RNG, World: g}
+}
@@ -0,0 +1,93 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems"
+)
+
+func equipItemEffects(player *gameobjects.Player, slot gamedata.ItemSlot, effects ...gamedata.ItemEffect) {
+	player.EquipItem(gamedata.NewCuratedItem("test_proc", "Test Proc", "", slot, map[gamedata.StatType]int{}, gamedata.ClassTypeAny, gamedata.ItemMetadata{Effects: effects}))
+}
+
+func TestOnDamagedItemAreaDamageHitsNearbyEnemies(t *testing.T) {
+	g := newPropTestGame()
+	equipItemEffects(g.Player, gamedata.ItemSlotChest, gamedata.ItemEffect{
+		Trigger: gamedata.ItemTriggerOnDamaged,
+		Action:  gamedata.ItemAction{Type: gamedata.ItemActionAreaDamage, Amount: 12, Radius: 90, DamageType: gamedata.DamageTrue},
+	})
+	g.Player.DerivedStats.CritChance = 0
+	playerX, playerY := g.Player.Center()
+	near := gameobjects.NewEnemy(playerX+30, playerY-15, false)
+	far := gameobjects.NewEnemy(playerX+300, playerY, false)
+	g.Enemies = []*gameobjects.Enemy{near, far}
+	g.invalidateCombatSpace()
+
+	g.applyCombatHitWithFeedback(systems.CombatHitRequest{Target: g.Player, BaseDamage: 5, DamageType: gamedata.DamageTrue})
+
+	if near.MaxHP-near.HP != 12 {
+		t.Fatalf("expected thorns proc to hit the nearby enemy for 12, got hp %d/%d", near.HP, near.MaxHP)
+	}
+	if far.HP != far.MaxHP {
+		t.Fatalf("expected enemy outside the radius to be unharmed")
+	}
+	if len(g.SkillVisualEffects) != 1 {
+		t.Fatalf("expected one area proc visual, got %d", len(g.SkillVisualEffects))
+	}
+}
+
+func TestItemProjectileAimsAtNearestEnemyAndSkipsOnHitProcs(t *testing.T) {
+	g := newPropTestGame()
+	equipItemEffects(g.Player, gamedata.ItemSlotWeapon, gamedata.ManaOnHit(5))
+	playerX, playerY := g.Player.Center()
+	enemy := gameobjects.NewEnemy(playerX+120, playerY, false)
+	g.Enemies = []*gameobjects.Enemy{enemy}
+	g.invalidateCombatSpace()
+
+	g.ItemProjectile(g.Player, playerX, playerY, nil, 10, gamedata.DamagePhysical, 420)
+	if len(g.Projectiles) != 1 || !g.Projectiles[0].FromItemProc || g.Projectiles[0].VX <= 0 {
+		t.Fatalf("expected one item proc bolt aimed at the enemy, got %+v", g.Projectiles)
+	}
+
+	g.Player.Mana = 0
+	(&projectilesSystem{}).applyProjectileHit(g, g.Projectiles[0], enemy)
+	if enemy.HP >= enemy.MaxHP {
+		t.Fatalf("expected the proc bolt to damage the enemy")
+	}
+	if g.Player.Mana != 0 {
+		t.Fatalf("expected proc bolt hits not to trigger on-hit item effects, got mana %d", g.Player.Mana)
+	}
+}
+
+func TestRoomEnterAndSkillCastTriggerItemEffects(t *testing.T) {
+	g := newPropTestGame()
+	equipItemEffects(g.Player, gamedata.ItemSlotChest, gamedata.ItemEffect{
+		Trigger: gamedata.ItemTriggerOnRoomEnter,
+		Action:  gamedata.ItemAction{Type: gamedata.ItemActionApplyEffect, OnSelf: true, Effect: gamedata.EffectSpec{Type: gamedata.EffectMoveSpeedBoost, Duration: 4, Magnitude: 0.2}},
+	})
+	equipItemEffects(g.Player, gamedata.ItemSlotHead, gamedata.ItemEffect{
+		Trigger: gamedata.ItemTriggerOnSkillCast,
+		Action:  gamedata.ItemAction{Type: gamedata.ItemActionReduceCooldowns, Amount: 1},
+	})
+
+	systems.TriggerItemEffects(g.Player, gamedata.ItemTriggerOnRoomEnter, g.itemProcContext())
+	if !gamedata.HasEffect(&g.Player.Effects, gamedata.EffectMoveSpeedBoost) {
+		t.Fatalf("expected room entry to grant the move speed boost")
+	}
+
+	other := g.Player.Skills[1]
+	other.CurrentCooldown = 3
+	cast := g.Player.Skills[0]
+	g.Player.Mana = g.Player.MaxMana
+	g.TryCastSkill(cast, nil)
+	if cast.CurrentCooldown != cast.Cooldown {
+		t.Fatalf("expected the cast skill to start its full cooldown, got %.2f", cast.CurrentCooldown)
+	}
+	if other.CurrentCooldown != 2 {
+		t.Fatalf("expected casting to shave 1s off other cooldowns, got %.2f", other.CurrentCooldown)
+	}
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"math"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+const (
+	NavRepathInterval     = 0.5
+	NavRepathGoalDistance = 48
+	NavWaypointReach      = 8
+	NavClearanceStep      = 4
+)
+
+// navigationState caches the current room's nav grids (one per clearance bucket) and each actor's path.
+type navigationState struct {
+	Room     *world.Room
+	Blockers []world.AABB
+	Grids    map[int]*world.NavGrid
+	Paths    map[core.Combatant]*navPath
+}
+
+type navPath struct {
+	Waypoints   []world.NavPoint
+	GoalX       float32
+	GoalY       float32
+	RepathTimer float32
+}
+
+func (g *Game) navigation() *navigationState {
+	if g.CurrentRoom == nil {
+		g.nav = nil
+		return nil
+	}
+	if g.nav == nil || g.nav.Room != g.CurrentRoom {
+		g.nav = &navigationState{
+			Room:  g.CurrentRoom,
+			Paths: map[core.Combatant]*navPath{},
+		}
+	}
+	if g.nav.Grids == nil {
+		g.nav.Blockers = g.roomBlockers()
+		g.nav.Grids = map[int]*world.NavGrid{}
+		g.nav.prunePaths()
+	}
+	return g.nav
+}
+
+func (nav *navigationState) prunePaths() {
+	for actor := range nav.Paths {
+		if !actor.IsAlive() {
+			delete(nav.Paths, actor)
+		}
+	}
+}
+
+func (g *Game) dropNavPath(actor core.Combatant) {
+	if g.nav != nil {
+		delete(g.nav.Paths, actor)
+	}
+}
+
+func (nav *navigationState) navGrid(width, height float32) *world.NavGrid {
+	bucket := int(math.Ceil(float64(max(width, height) / 2 / NavClearanceStep)))
+	grid, ok := nav.Grids[bucket]
+	if !ok {
+		grid = world.NewNavGrid(nav.Room, float32(bucket*NavClearanceStep), nav.Blockers...)
+		nav.Grids[bucket] = grid
+	}
+	return grid
+}
+
+// navWaypoint returns the goal while the straight line is clear, otherwise the next waypoint of the actor's A* path.
+func (g *Game) navWaypoint(actor core.Combatant, goalX, goalY, dt float32) (float32, float32) {
+	nav := g.navigation()
+	if nav == nil || actor == nil {
+		return goalX, goalY
+	}
+	_, _, width, height := actor.GetBounds()
+	grid := nav.navGrid(width, height)
+	if grid == nil {
+		return goalX, goalY
+	}
+
+	x, y := actor.Center()
+	if grid.LineWalkable(x, y, goalX, goalY) {
+		delete(nav.Paths, actor)
+		return goalX, goalY
+	}
+
+	path := nav.Paths[actor]
+	if path != nil {
+		path.RepathTimer -= dt
+	}
+	if path == nil || path.RepathTimer <= 0 || len(path.Waypoints) == 0 || systems.GetDistance(path.GoalX, path.GoalY, goalX, goalY) > NavRepathGoalDistance {
+		waypoints, ok := grid.FindPath(x, y, goalX, goalY)
+		if !ok {
+			delete(nav.Paths, actor)
+			return goalX, goalY
+		}
+		path = &navPath{Waypoints: waypoints, GoalX: goalX, GoalY: goalY, RepathTimer: NavRepathInterval}
+		nav.Paths[actor] = path
+	}
+
+	for len(path.Waypoints) > 1 {
+		next := path.Waypoints[0]
+		after := path.Waypoints[1]
+		if systems.GetDistance(x, y, next.X, next.Y) > NavWaypointReach && !grid.LineWalkable(x, y, after.X, after.Y) {
+			break
+		}
+		path.Waypoints = path.Waypoints[1:]
+	}
+	next := path.Waypoints[0]
+	if len(path.Waypoints) == 1 && systems.GetDistance(x, y, next.X, next.Y) <= NavWaypointReach {
+		return goalX, goalY
+	}
+	return next.X, next.Y
+}
+
+func (g *Game) steerEnemyAlongPath(enemy *gameobjects.Enemy, targetX, targetY, dt float32) {
+	if enemy == nil {
+		return
+	}
+	if !enemy.IsAlive() {
+		g.dropNavPath(enemy)
+		return
+	}
+	if enemy.State != gameobjects.EnemyStateChasing {
+		return
+	}
+	if enemy.IntentMoveX == 0 && enemy.IntentMoveY == 0 {
+		return
+	}
+	waypointX, waypointY := g.navWaypoint(enemy, targetX, targetY, dt)
+	gameobjects.SteerEnemyToward(enemy, waypointX, waypointY)
+}
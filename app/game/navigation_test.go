@@ -0,0 +1,105 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+func newNavigationTestGame() *Game {
+	g := NewGame(settings.Default())
+	g.State = StateRun
+	g.Player = gameobjects.NewPlayer(480, 180, gamedata.ClassTypeMelee)
+	g.CurrentRoom = &world.Room{
+		Width:     640,
+		Height:    480,
+		Obstacles: []world.AABB{{X: 288, Y: 0, Width: 64, Height: 384}},
+	}
+	g.invalidateCombatSpace()
+	return g
+}
+
+func TestMeleeEnemyPathsAroundPillarToReachPlayer(t *testing.T) {
+	g := newNavigationTestGame()
+	enemy := gameobjects.NewEnemyFromArchetype(100, 180, gamedata.EnemyArchetypeBrute, false, 0)
+	enemy.Provoked = true
+	g.Enemies = []*gameobjects.Enemy{enemy}
+	g.invalidateCombatSpace()
+
+	ctx := &RuntimeContext{Game: g}
+	ai := &aiSystem{}
+	movement := &movementSystem{}
+	dt := float32(1.0 / 60.0)
+	for step := 0; step < 60*20 && enemy.State != gameobjects.EnemyStateAttacking; step++ {
+		ai.Update(ctx, dt)
+		movement.updateEnemies(g, dt)
+		g.invalidateCombatSpace()
+	}
+
+	if enemy.State != gameobjects.EnemyStateAttacking {
+		enemyX, enemyY := enemy.Center()
+		t.Fatalf("expected brute to path around the pillar into attack range, stuck at (%v,%v)", enemyX, enemyY)
+	}
+}
+
+func TestPlayerMoveToFollowsWaypointsAroundWall(t *testing.T) {
+	g := newNavigationTestGame()
+	g.Player.PosX = 100
+	g.Player.PosY = 160
+	g.PlayerMoveTargetX = 500
+	g.PlayerMoveTargetY = 180
+	g.HasPlayerMoveTarget = true
+
+	ctx := &RuntimeContext{Game: g, Input: &systems.Input{}}
+	movement := &movementSystem{}
+	dt := float32(1.0 / 60.0)
+	for step := 0; step < 60*20 && g.HasPlayerMoveTarget; step++ {
+		movement.Update(ctx, dt)
+	}
+
+	playerX, playerY := g.Player.Center()
+	if g.HasPlayerMoveTarget || systems.GetDistance(playerX, playerY, 500, 180) > PlayerMoveTargetStopDistance {
+		t.Fatalf("expected player to walk around the wall to the target, stopped at (%v,%v)", playerX, playerY)
+	}
+	if g.nav == nil || len(g.nav.Grids) == 0 {
+		t.Fatalf("expected move-to to build a nav grid for the room")
+	}
+}
+
+func TestNavigationCachesBlockersAndPrunesDeadPathsOnRebuild(t *testing.T) {
+	g := newNavigationTestGame()
+	crate := gameobjects.NewProp(400, 100, gamedata.PropCrate)
+	g.Props = []*gameobjects.Prop{crate}
+	g.invalidatePropBlockers()
+	enemy := gameobjects.NewEnemyFromArchetype(100, 180, gamedata.EnemyArchetypeBrute, false, 0)
+	g.Enemies = []*gameobjects.Enemy{enemy}
+
+	g.navWaypoint(enemy, 480, 180, 0.016)
+	if g.nav.Paths[enemy] == nil {
+		t.Fatalf("expected the enemy to get a path around the pillar")
+	}
+	_, _, width, height := enemy.GetBounds()
+	grid := g.nav.navGrid(width, height)
+	g.navWaypoint(enemy, 480, 180, 0.016)
+	if g.nav.navGrid(width, height) != grid || &g.roomBlockers()[0] != &g.nav.Blockers[0] {
+		t.Fatalf("expected repeated queries to reuse the cached blockers and grid")
+	}
+
+	enemy.HP = 0
+	enemy.Alive = false
+	crate.ApplyCombatDamage(crate.MaxHP, gamedata.DamagePhysical, true)
+	g.updateProps(0.016)
+	g.navigation()
+	if _, ok := g.nav.Paths[enemy]; ok {
+		t.Fatalf("expected the dead enemy's path to be pruned when the grid is rebuilt")
+	}
+	if len(g.nav.Blockers) != 0 {
+		t.Fatalf("expected the broken crate to leave the blocker set, got %d", len(g.nav.Blockers))
+	}
+}
@@ -0,0 +1,167 @@
+package game
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"singlefantasy/app/systems"
+)
+
+const ProfilerWindowSize = 240
+
+type SystemTiming struct {
+	Name    string
+	Last    time.Duration
+	Min     time.Duration
+	Avg     time.Duration
+	Max     time.Duration
+	Samples int
+}
+
+// PipelineProfiler keeps a rolling window of per-system update durations, one sample per fixed step.
+type PipelineProfiler struct {
+	names   []string
+	samples [][]time.Duration
+	next    int
+	count   int
+	now     func() time.Time
+}
+
+func NewPipelineProfiler(names []string, window int) *PipelineProfiler {
+	if window <= 0 {
+		window = ProfilerWindowSize
+	}
+	// One extra slot holds the step currently being recorded.
+	samples := make([][]time.Duration, window+1)
+	for i := range samples {
+		samples[i] = make([]time.Duration, len(names))
+	}
+	return &PipelineProfiler{
+		names:   append([]string{}, names...),
+		samples: samples,
+		now:     time.Now,
+	}
+}
+
+func (p *PipelineProfiler) Record(index int, duration time.Duration) {
+	if p == nil || index < 0 || index >= len(p.names) {
+		return
+	}
+	p.samples[p.next][index] = duration
+}
+
+func (p *PipelineProfiler) EndStep() {
+	if p == nil {
+		return
+	}
+	p.next = (p.next + 1) % len(p.samples)
+	if p.count < len(p.samples)-1 {
+		p.count++
+	}
+	for i := range p.samples[p.next] {
+		p.samples[p.next][i] = 0
+	}
+}
+
+func (p *PipelineProfiler) Timings() []SystemTiming {
+	if p == nil {
+		return nil
+	}
+
+	timings := make([]SystemTiming, len(p.names))
+	for i, name := range p.names {
+		timings[i].Name = name
+		timings[i].Samples = p.count
+	}
+	if p.count == 0 {
+		return timings
+	}
+
+	last := (p.next - 1 + len(p.samples)) % len(p.samples)
+	totals := make([]time.Duration, len(p.names))
+	for n := 0; n < p.count; n++ {
+		row := p.samples[(last-n+len(p.samples))%len(p.samples)]
+		for i, sample := range row {
+			if n == 0 {
+				timings[i].Last = sample
+				timings[i].Min = sample
+				timings[i].Max = sample
+			}
+			if sample < timings[i].Min {
+				timings[i].Min = sample
+			}
+			if sample > timings[i].Max {
+				timings[i].Max = sample
+			}
+			totals[i] += sample
+		}
+	}
+	for i := range timings {
+		timings[i].Avg = totals[i] / time.Duration(p.count)
+	}
+	return timings
+}
+
+func (p *PipelineProfiler) TotalAverage() time.Duration {
+	total := time.Duration(0)
+	for _, timing := range p.Timings() {
+		total += timing.Avg
+	}
+	return total
+}
+
+// WriteCSV dumps the retained window oldest first, one row per fixed step with a column per system in microseconds.
+func (p *PipelineProfiler) WriteCSV(path string) error {
+	if p == nil {
+		return fmt.Errorf("profiler is nil")
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := append([]string{"step"}, p.names...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	first := (p.next - p.count + len(p.samples)) % len(p.samples)
+	for n := 0; n < p.count; n++ {
+		row := p.samples[(first+n)%len(p.samples)]
+		record := make([]string, 0, len(row)+1)
+		record = append(record, strconv.Itoa(n))
+		for _, sample := range row {
+			record = append(record, strconv.FormatInt(sample.Microseconds(), 10))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func (g *Game) debugTimingBars() []systems.DebugTimingBar {
+	if g.RunPipeline == nil {
+		return nil
+	}
+	timings := g.RunPipeline.Profiler().Timings()
+	bars := make([]systems.DebugTimingBar, 0, len(timings))
+	for _, timing := range timings {
+		bars = append(bars, systems.DebugTimingBar{
+			Label: timing.Name,
+			AvgMs: durationMs(timing.Avg),
+			MaxMs: durationMs(timing.Max),
+		})
+	}
+	return bars
+}
+
+func durationMs(duration time.Duration) float32 {
+	return float32(duration.Microseconds()) / 1000
+}
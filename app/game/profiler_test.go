@@ -0,0 +1,69 @@
+//go:build raylib
+
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/settings"
+)
+
+func TestPipelineProfilerKeepsRollingMinAvgMax(t *testing.T) {
+	profiler := NewPipelineProfiler([]string{"AI", "Projectiles"}, 3)
+	for _, sample := range []time.Duration{9, 1, 2, 3} {
+		profiler.Record(0, sample*time.Millisecond)
+		profiler.Record(1, time.Millisecond)
+		profiler.EndStep()
+	}
+
+	timings := profiler.Timings()
+	ai := timings[0]
+	if ai.Samples != 3 || ai.Last != 3*time.Millisecond {
+		t.Fatalf("expected 3 retained samples with last 3ms, got %d and %s", ai.Samples, ai.Last)
+	}
+	if ai.Min != time.Millisecond || ai.Max != 3*time.Millisecond || ai.Avg != 2*time.Millisecond {
+		t.Fatalf("expected oldest 9ms sample to roll out, got min=%s avg=%s max=%s", ai.Min, ai.Avg, ai.Max)
+	}
+
+	path := filepath.Join(t.TempDir(), "profile.csv")
+	if err := profiler.WriteCSV(path); err != nil {
+		t.Fatalf("expected csv to write: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read csv: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 4 || lines[0] != "step,AI,Projectiles" || lines[1] != "0,1000,1000" {
+		t.Fatalf("unexpected csv contents: %q", string(content))
+	}
+}
+
+func TestRuntimePipelineTimesEverySystem(t *testing.T) {
+	g := NewHeadlessGame(HeadlessConfig{Class: gamedata.ClassTypeMelee, Settings: settings.Default()})
+	tick := time.Unix(0, 0)
+	g.RunPipeline.Profiler().now = func() time.Time {
+		tick = tick.Add(time.Millisecond)
+		return tick
+	}
+
+	g.StepHeadless(2)
+
+	timings := g.RunPipeline.Profiler().Timings()
+	if len(timings) != 9 {
+		t.Fatalf("expected a timing per pipeline system, got %d", len(timings))
+	}
+	for _, timing := range timings {
+		if timing.Samples != 2 || timing.Avg != time.Millisecond {
+			t.Fatalf("expected 2 samples of 1ms for %s, got %d/%s", timing.Name, timing.Samples, timing.Avg)
+		}
+	}
+	if len(g.debugTimingBars()) != 9 {
+		t.Fatalf("expected a debug bar per system")
+	}
+}
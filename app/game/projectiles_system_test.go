@@ -3,6 +3,7 @@
 package game
 
 import (
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 	"singlefantasy/app/settings"
@@ -18,7 +19,7 @@ func TestPlayerProjectileExpiresByLifetime(t *testing.T) {
 			Y:          0,
 			Alive:      true,
 			Lifetime:   0.05,
-			HitTargets: map[interface{}]struct{}{},
+			HitTargets: map[core.Combatant]struct{}{},
 		},
 	}
 
@@ -48,7 +49,7 @@ func TestPlayerProjectileCollisionAppliesDamage(t *testing.T) {
 			Damage:     10,
 			Alive:      true,
 			Lifetime:   1.0,
-			HitTargets: map[interface{}]struct{}{},
+			HitTargets: map[core.Combatant]struct{}{},
 		},
 	}
 
@@ -85,7 +86,7 @@ func TestPlayerProjectilePierceHitsMultipleTargets(t *testing.T) {
 			Alive:      true,
 			Lifetime:   1.0,
 			Pierce:     1,
-			HitTargets: map[interface{}]struct{}{},
+			HitTargets: map[core.Combatant]struct{}{},
 		},
 	}
 
@@ -119,7 +120,7 @@ func TestPlayerProjectileDoesNotRehitSameTarget(t *testing.T) {
 			Alive:      true,
 			Lifetime:   2.0,
 			Pierce:     2,
-			HitTargets: map[interface{}]struct{}{},
+			HitTargets: map[core.Combatant]struct{}{},
 		},
 	}
 
@@ -0,0 +1,181 @@
+package game
+
+import (
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+const (
+	PickupRadius              = 10
+	PickupLifetime            = 20
+	PropExplosionVisualLength = 0.35
+)
+
+type Pickup struct {
+	X        float32
+	Y        float32
+	Radius   float32
+	Type     gamedata.PropDropType
+	Amount   int
+	Lifetime float32
+	Alive    bool
+}
+
+func (g *Game) spawnRoomProps() {
+	g.Props = []*gameobjects.Prop{}
+	g.Pickups = []*Pickup{}
+	g.invalidatePropBlockers()
+	if g.CurrentRoom == nil {
+		return
+	}
+	for _, ref := range g.CurrentRoom.Props {
+		if ref == nil {
+			continue
+		}
+		g.Props = append(g.Props, gameobjects.NewProp(ref.X, ref.Y, ref.Type))
+	}
+}
+
+func propCombatants(props []*gameobjects.Prop) []core.Combatant {
+	combatants := make([]core.Combatant, 0, len(props))
+	for _, prop := range props {
+		if prop != nil {
+			combatants = append(combatants, prop)
+		}
+	}
+	return combatants
+}
+
+func propBlockers(props []*gameobjects.Prop) []world.AABB {
+	blockers := make([]world.AABB, 0, len(props))
+	for _, prop := range props {
+		if prop == nil || !prop.IsAlive() {
+			continue
+		}
+		x, y, width, height := prop.GetBounds()
+		blockers = append(blockers, world.AABB{X: x, Y: y, Width: width, Height: height})
+	}
+	return blockers
+}
+
+func (g *Game) projectileBlockedByProp(x, y, radius float32) bool {
+	return g.combatSpace().OverlapsBlocker(world.AABB{X: x - radius, Y: y - radius, Width: radius * 2, Height: radius * 2})
+}
+
+func isPropTarget(target core.Combatant) bool {
+	_, isProp := target.(*gameobjects.Prop)
+	return isProp
+}
+
+func (g *Game) updateProps(dt float32) {
+	for _, prop := range g.Props {
+		if prop == nil {
+			continue
+		}
+		prop.Update(dt)
+		if prop.IsAlive() || prop.Resolved {
+			continue
+		}
+		prop.Resolved = true
+		g.resolvePropDestroyed(prop)
+	}
+	g.updatePickups(dt)
+}
+
+func (g *Game) resolvePropDestroyed(prop *gameobjects.Prop) {
+	spec := prop.Spec()
+	x, y := prop.Center()
+	g.publish(CombatEvent{Type: EventPropDestroyed, Target: prop, X: x, Y: y, RoomIndex: g.currentRoomIndex()})
+	g.invalidatePropBlockers()
+
+	if spec.Explodes() {
+		g.explodeProp(spec, x, y)
+		return
+	}
+	if spec.DropType == gamedata.PropDropNone || spec.DropAmount <= 0 {
+		return
+	}
+	if g.RNG.Float32(core.RNGStreamProps) >= spec.DropChance {
+		return
+	}
+	g.Pickups = append(g.Pickups, &Pickup{
+		X:        x,
+		Y:        y,
+		Radius:   PickupRadius,
+		Type:     spec.DropType,
+		Amount:   spec.DropAmount,
+		Lifetime: PickupLifetime,
+		Alive:    true,
+	})
+}
+
+func (g *Game) explodeProp(spec gamedata.PropSpec, x, y float32) {
+	g.SkillVisualEffects = append(g.SkillVisualEffects, &SkillVisualEffect{
+		X:        x,
+		Y:        y,
+		Radius:   spec.ExplosionRadius,
+		Duration: PropExplosionVisualLength,
+		TimeLeft: PropExplosionVisualLength,
+		Filled:   true,
+	})
+
+	if g.Player != nil && g.Player.IsAlive() {
+		playerX, playerY := g.Player.Center()
+		if systems.GetDistance(x, y, playerX, playerY) <= spec.ExplosionRadius {
+			g.ApplyPlayerCombatHit(spec.ExplosionDamage, spec.ExplosionDamageType, x, y, spec.ExplosionEffects)
+		}
+	}
+
+	for _, target := range g.combatSpace().CombatantsInCircle(x, y, spec.ExplosionRadius) {
+		if !target.IsAlive() {
+			continue
+		}
+		targetX, targetY := target.Center()
+		if systems.GetDistance(x, y, targetX, targetY) > spec.ExplosionRadius {
+			continue
+		}
+		g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+			Target:     target,
+			BaseDamage: spec.ExplosionDamage,
+			DamageType: spec.ExplosionDamageType,
+			Effects:    spec.ExplosionEffects,
+		})
+	}
+}
+
+func (g *Game) updatePickups(dt float32) {
+	alive := g.Pickups[:0]
+	for _, pickup := range g.Pickups {
+		if pickup == nil || !pickup.Alive {
+			continue
+		}
+		pickup.Lifetime -= dt
+		if pickup.Lifetime <= 0 {
+			continue
+		}
+		if g.Player != nil && g.Player.IsAlive() && g.playerTouchesPickup(pickup) {
+			g.collectPickup(pickup)
+			continue
+		}
+		alive = append(alive, pickup)
+	}
+	g.Pickups = alive
+}
+
+func (g *Game) playerTouchesPickup(pickup *Pickup) bool {
+	playerX, playerY := g.Player.Center()
+	return systems.GetDistance(pickup.X, pickup.Y, playerX, playerY) <= pickup.Radius+g.Player.Hitbox.Width/2
+}
+
+func (g *Game) collectPickup(pickup *Pickup) {
+	pickup.Alive = false
+	switch pickup.Type {
+	case gamedata.PropDropHealthOrb:
+		g.healPlayerWithFeedbackSource(pickup.Amount, healingSoundKillReward)
+	case gamedata.PropDropManaOrb:
+		g.Player.GainMana(pickup.Amount)
+	}
+}
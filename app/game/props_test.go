@@ -0,0 +1,126 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+func newPropTestGame() *Game {
+	g := NewGame(settings.Default())
+	g.State = StateRun
+	g.Player = gameobjects.NewPlayer(600, 600, gamedata.ClassTypeMelee)
+	g.CurrentRoom = &world.Room{Width: 1000, Height: 1000}
+	return g
+}
+
+func TestExplosivePropDamagesEnemiesAndChainsIntoOtherProps(t *testing.T) {
+	g := newPropTestGame()
+	urn := gameobjects.NewProp(200, 200, gamedata.PropExplosiveUrn)
+	brazier := gameobjects.NewProp(260, 200, gamedata.PropBrazier)
+	brazier.HP = 1
+	enemy := gameobjects.NewEnemyFromArchetype(220, 240, gamedata.EnemyArchetypeRaider, false, 0)
+	g.Props = []*gameobjects.Prop{urn, brazier}
+	g.Enemies = []*gameobjects.Enemy{enemy}
+	g.invalidateCombatSpace()
+
+	playerHP := g.Player.HP
+	urn.ApplyCombatDamage(urn.MaxHP, gamedata.DamagePhysical, true)
+	g.Events.BeginFrame()
+	g.updateProps(0.016)
+
+	urnDamage := gamedata.GetPropSpec(gamedata.PropExplosiveUrn).ExplosionDamage
+	if enemy.MaxHP-enemy.HP < urnDamage {
+		t.Fatalf("expected urn blast to hit the enemy, got hp %d/%d", enemy.HP, enemy.MaxHP)
+	}
+	if brazier.IsAlive() || !brazier.Resolved {
+		t.Fatalf("expected blast to break and resolve the neighbouring brazier")
+	}
+	if g.Events.CountFrameEvents(EventPropDestroyed) != 2 {
+		t.Fatalf("expected two prop destroyed events, got %d", g.Events.CountFrameEvents(EventPropDestroyed))
+	}
+	if g.Player.HP != playerHP {
+		t.Fatalf("expected player outside the blast to be unharmed")
+	}
+	if g.Telemetry.Kills != 0 {
+		t.Fatalf("expected props not to count as kills, got %d", g.Telemetry.Kills)
+	}
+}
+
+func TestIntactPropsBlockMovementAndProjectiles(t *testing.T) {
+	g := newPropTestGame()
+	crate := gameobjects.NewProp(300, 300, gamedata.PropCrate)
+	g.Props = []*gameobjects.Prop{crate}
+	g.invalidatePropBlockers()
+
+	if !g.combatSpace().OverlapsObstacle(world.AABB{X: 295, Y: 295, Width: 10, Height: 10}) {
+		t.Fatalf("expected intact crate to block movement")
+	}
+	if !g.projectileBlockedByProp(300, 300, 4) {
+		t.Fatalf("expected intact crate to block enemy projectiles")
+	}
+
+	crate.ApplyCombatDamage(crate.MaxHP, gamedata.DamagePhysical, true)
+	g.updateProps(0.016)
+	g.invalidateCombatSpace()
+	if g.combatSpace().OverlapsObstacle(world.AABB{X: 295, Y: 295, Width: 10, Height: 10}) {
+		t.Fatalf("expected broken crate to stop blocking")
+	}
+}
+
+func TestEnemiesCollideWithAndPathAroundIntactProps(t *testing.T) {
+	g := newPropTestGame()
+	crate := gameobjects.NewProp(300, 300, gamedata.PropCrate)
+	enemy := gameobjects.NewEnemyFromArchetype(200, 310, gamedata.EnemyArchetypeRaider, false, 0)
+	g.Props = []*gameobjects.Prop{crate}
+	g.Enemies = []*gameobjects.Enemy{enemy}
+	g.invalidatePropBlockers()
+
+	newX, newY := enemy.PosX, enemy.PosY
+	for step := 0; step < 20; step++ {
+		newX, newY = systems.ResolveEnemyMovementInSpace(newX, newY, enemy.Hitbox.Width, enemy.Hitbox.Height, 10, 0, g.CurrentRoom, g.combatSpace())
+	}
+	if newX+enemy.Hitbox.Width > crate.PosX {
+		t.Fatalf("expected the crate to stop the enemy at x<=%v, got right edge %v", crate.PosX, newX+enemy.Hitbox.Width)
+	}
+
+	_, enemyY := enemy.Center()
+	waypointX, waypointY := g.navWaypoint(enemy, 450, enemyY, 0.016)
+	if waypointX == 450 && waypointY == enemyY {
+		t.Fatalf("expected the enemy to path around the crate instead of heading straight at the goal")
+	}
+
+	crate.ApplyCombatDamage(crate.MaxHP, gamedata.DamagePhysical, true)
+	g.updateProps(0.016)
+	g.invalidateCombatSpace()
+	waypointX, waypointY = g.navWaypoint(enemy, 450, enemyY, 0.016)
+	if waypointX != 450 || waypointY != enemyY {
+		t.Fatalf("expected a broken crate to clear the path, got waypoint (%v,%v)", waypointX, waypointY)
+	}
+}
+
+func TestPickupOrbsRestoreResourcesOnContact(t *testing.T) {
+	g := newPropTestGame()
+	g.Player.HP = g.Player.MaxHP - 30
+	g.Player.Mana = 0
+	playerX, playerY := g.Player.Center()
+	g.Pickups = []*Pickup{
+		{X: playerX, Y: playerY, Radius: PickupRadius, Type: gamedata.PropDropHealthOrb, Amount: 20, Lifetime: PickupLifetime, Alive: true},
+		{X: playerX, Y: playerY, Radius: PickupRadius, Type: gamedata.PropDropManaOrb, Amount: 15, Lifetime: PickupLifetime, Alive: true},
+		{X: playerX + 400, Y: playerY, Radius: PickupRadius, Type: gamedata.PropDropHealthOrb, Amount: 20, Lifetime: 0.01, Alive: true},
+	}
+
+	g.updatePickups(0.016)
+	if g.Player.HP != g.Player.MaxHP-10 || g.Player.Mana != 15 {
+		t.Fatalf("expected orbs to restore hp and mana, got hp=%d/%d mana=%d", g.Player.HP, g.Player.MaxHP, g.Player.Mana)
+	}
+	if len(g.Pickups) != 0 {
+		t.Fatalf("expected collected and expired orbs removed, got %d", len(g.Pickups))
+	}
+}
@@ -0,0 +1,232 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/systems"
+)
+
+const ReplayFormatVersion = 1
+
+type Replay struct {
+	Version     int                `json:"version"`
+	Seed        int64              `json:"seed"`
+	Class       gamedata.ClassType `json:"class"`
+	Steps       []ReplayStep       `json:"steps"`
+	RewardPicks []int              `json:"reward_picks"`
+}
+
+type ReplayStep struct {
+	MoveToX         float32             `json:"move_x,omitempty"`
+	MoveToY         float32             `json:"move_y,omitempty"`
+	HasMoveTarget   bool                `json:"move,omitempty"`
+	CursorWorldX    float32             `json:"cursor_x"`
+	CursorWorldY    float32             `json:"cursor_y"`
+	Attack          bool                `json:"attack,omitempty"`
+	Skill1          bool                `json:"skill_1,omitempty"`
+	Skill2          bool                `json:"skill_2,omitempty"`
+	Skill3          bool                `json:"skill_3,omitempty"`
+	Skill4          bool                `json:"skill_4,omitempty"`
+	Interact        bool                `json:"interact,omitempty"`
+	StatAllocations []gamedata.StatType `json:"stats,omitempty"`
+}
+
+func NewReplay(seed int64, class gamedata.ClassType) *Replay {
+	return &Replay{
+		Version:     ReplayFormatVersion,
+		Seed:        seed,
+		Class:       class,
+		Steps:       []ReplayStep{},
+		RewardPicks: []int{},
+	}
+}
+
+func newReplayStep(input *systems.Input) ReplayStep {
+	if input == nil {
+		return ReplayStep{}
+	}
+	return ReplayStep{
+		MoveToX:         input.MoveToX,
+		MoveToY:         input.MoveToY,
+		HasMoveTarget:   input.HasMoveTarget,
+		CursorWorldX:    input.CursorWorldX,
+		CursorWorldY:    input.CursorWorldY,
+		Attack:          input.Attack,
+		Skill1:          input.Skill1,
+		Skill2:          input.Skill2,
+		Skill3:          input.Skill3,
+		Skill4:          input.Skill4,
+		Interact:        input.Interact,
+		StatAllocations: append([]gamedata.StatType(nil), input.StatAllocations...),
+	}
+}
+
+func (s ReplayStep) Input() *systems.Input {
+	return &systems.Input{
+		MoveToX:         s.MoveToX,
+		MoveToY:         s.MoveToY,
+		HasMoveTarget:   s.HasMoveTarget,
+		CursorWorldX:    s.CursorWorldX,
+		CursorWorldY:    s.CursorWorldY,
+		Attack:          s.Attack,
+		Skill1:          s.Skill1,
+		Skill2:          s.Skill2,
+		Skill3:          s.Skill3,
+		Skill4:          s.Skill4,
+		Interact:        s.Interact,
+		StatAllocations: append([]gamedata.StatType(nil), s.StatAllocations...),
+	}
+}
+
+func LoadReplay(path string) (*Replay, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read replay %q: %w", path, err)
+	}
+
+	var replay Replay
+	if err := json.Unmarshal(content, &replay); err != nil {
+		return nil, fmt.Errorf("parse replay %q: %w", path, err)
+	}
+	if replay.Version != ReplayFormatVersion {
+		return nil, fmt.Errorf("replay %q has unsupported version %d", path, replay.Version)
+	}
+	if replay.Steps == nil {
+		replay.Steps = []ReplayStep{}
+	}
+	if replay.RewardPicks == nil {
+		replay.RewardPicks = []int{}
+	}
+	return &replay, nil
+}
+
+func SaveReplay(path string, replay *Replay) error {
+	if replay == nil {
+		return fmt.Errorf("replay is nil")
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	payload, err := json.Marshal(replay)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, payload, 0o644)
+}
+
+type replayRecorder struct {
+	replay *Replay
+}
+
+func (r *replayRecorder) record(input *systems.Input) {
+	r.replay.Steps = append(r.replay.Steps, newReplayStep(input))
+}
+
+type replayPlayback struct {
+	replay     *Replay
+	step       int
+	rewardPick int
+}
+
+func (p *replayPlayback) Poll(_ *systems.Camera) *systems.Input {
+	if p.step >= len(p.replay.Steps) {
+		return &systems.Input{}
+	}
+	input := p.replay.Steps[p.step].Input()
+	p.step++
+	return input
+}
+
+func (p *replayPlayback) Finished() bool {
+	return p.step >= len(p.replay.Steps)
+}
+
+func (p *replayPlayback) nextRewardPick(fallback int) int {
+	if p.rewardPick >= len(p.replay.RewardPicks) {
+		return fallback
+	}
+	pick := p.replay.RewardPicks[p.rewardPick]
+	p.rewardPick++
+	return pick
+}
+
+// EnableReplayRecording records every run started afterwards; CurrentReplay returns the latest one.
+func (g *Game) EnableReplayRecording() {
+	g.recordReplays = true
+}
+
+func (g *Game) CurrentReplay() *Replay {
+	if g.replayRecorder == nil {
+		return nil
+	}
+	return g.replayRecorder.replay
+}
+
+func (g *Game) IsReplayPlayback() bool {
+	return g.replayPlayback != nil
+}
+
+// QueueReplay starts the replay as soon as boot finishes instead of showing the main menu.
+func (g *Game) QueueReplay(replay *Replay) {
+	g.pendingReplay = replay
+}
+
+func (g *Game) StartReplay(replay *Replay) error {
+	if replay == nil {
+		return nil
+	}
+	g.pendingReplay = nil
+	g.SelectedClass = replay.Class
+	if err := g.StartRunWithSeed(replay.Seed); err != nil {
+		return err
+	}
+	g.replayPlayback = &replayPlayback{replay: replay}
+	return nil
+}
+
+func (g *Game) attachReplayRecorder() {
+	g.replayPlayback = nil
+	g.replayRecorder = nil
+	if !g.recordReplays || g.Dungeon == nil {
+		return
+	}
+	g.replayRecorder = &replayRecorder{replay: NewReplay(g.Dungeon.Seed, g.SelectedClass)}
+}
+
+func (g *Game) pollRunInput() *systems.Input {
+	var source systems.InputSource = g.InputSource
+	if g.replayPlayback != nil {
+		source = g.replayPlayback
+	}
+	if source == nil {
+		source = &systems.RaylibInputSource{}
+	}
+
+	input := source.Poll(g.Camera)
+	if input == nil {
+		input = &systems.Input{}
+	}
+	if g.replayRecorder != nil {
+		g.replayRecorder.record(input)
+	}
+	return input
+}
+
+func (g *Game) resolveReplayRewardPick() {
+	if g.replayPlayback != nil {
+		g.SelectedReward = g.replayPlayback.nextRewardPick(g.SelectedReward)
+	}
+}
+
+func (g *Game) recordReplayRewardPick() {
+	if g.replayRecorder != nil {
+		g.replayRecorder.replay.RewardPicks = append(g.replayRecorder.replay.RewardPicks, g.SelectedReward)
+	}
+}
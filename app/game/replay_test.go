@@ -0,0 +1,90 @@
+//go:build raylib
+
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+func TestReplayRecordSaveLoadAndPlaybackMatches(t *testing.T) {
+	source := systems.NewScriptedInputSource()
+	recorded := NewHeadlessGame(HeadlessConfig{
+		Class:    gamedata.ClassTypeRanged,
+		Seed:     world.DefaultDungeonSeed,
+		Settings: settings.Default(),
+		Input:    source,
+		Record:   true,
+	})
+	for _, enemy := range recorded.Enemies {
+		enemy.Alive = false
+	}
+
+	centerX, centerY := recorded.Player.Center()
+	source.Push(
+		systems.Input{MoveToX: centerX + 90, MoveToY: centerY + 40, HasMoveTarget: true, CursorWorldX: centerX + 90, CursorWorldY: centerY + 40},
+		systems.Input{CursorWorldX: centerX, CursorWorldY: centerY},
+		systems.Input{StatAllocations: []gamedata.StatType{gamedata.StatTypeDEX}},
+	)
+	recorded.StepHeadless(45)
+
+	replay := recorded.CurrentReplay()
+	if replay == nil {
+		t.Fatalf("expected recording to produce a replay")
+	}
+	if len(replay.Steps) != 45 {
+		t.Fatalf("expected 45 recorded steps, got %d", len(replay.Steps))
+	}
+	if replay.Seed != world.DefaultDungeonSeed || replay.Class != gamedata.ClassTypeRanged {
+		t.Fatalf("expected replay to capture seed and class, got seed=%d class=%d", replay.Seed, replay.Class)
+	}
+
+	path := filepath.Join(t.TempDir(), "run.replay.json")
+	if err := SaveReplay(path, replay); err != nil {
+		t.Fatalf("expected replay save to succeed: %v", err)
+	}
+	loaded, err := LoadReplay(path)
+	if err != nil {
+		t.Fatalf("expected replay load to succeed: %v", err)
+	}
+	if len(loaded.Steps) != len(replay.Steps) {
+		t.Fatalf("expected loaded replay to keep %d steps, got %d", len(replay.Steps), len(loaded.Steps))
+	}
+	if !loaded.Steps[0].HasMoveTarget || len(loaded.Steps[2].StatAllocations) != 1 {
+		t.Fatalf("expected loaded replay to keep move target and stat allocation")
+	}
+
+	played := NewHeadlessReplayGame(loaded, settings.Default())
+	if !played.IsReplayPlayback() {
+		t.Fatalf("expected replay game to be in playback mode")
+	}
+	for _, enemy := range played.Enemies {
+		enemy.Alive = false
+	}
+	played.StepHeadless(45)
+
+	if played.Player.PosX != recorded.Player.PosX || played.Player.PosY != recorded.Player.PosY {
+		t.Fatalf("expected playback to reproduce player position, recorded=(%.3f,%.3f) played=(%.3f,%.3f)",
+			recorded.Player.PosX, recorded.Player.PosY, played.Player.PosX, played.Player.PosY)
+	}
+	if played.RunElapsed != recorded.RunElapsed {
+		t.Fatalf("expected playback run time %.3f, got %.3f", recorded.RunElapsed, played.RunElapsed)
+	}
+}
+
+func TestLoadReplayRejectsUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.replay.json")
+	if err := os.WriteFile(path, []byte(`{"version":99,"seed":1,"class":0,"steps":[]}`), 0o644); err != nil {
+		t.Fatalf("failed to write replay fixture: %v", err)
+	}
+
+	if _, err := LoadReplay(path); err == nil {
+		t.Fatalf("expected unknown replay version to be rejected")
+	}
+}
@@ -0,0 +1,323 @@
+package game
+
+import (
+	"fmt"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+const (
+	EventWaveStartDelay      = 2.0
+	EventTotemRadius         = 26
+	EventTotemHitFlashLength = 0.12
+)
+
+var eventAltarStats = []gamedata.StatType{
+	gamedata.StatTypeSTR,
+	gamedata.StatTypeAGI,
+	gamedata.StatTypeVIT,
+	gamedata.StatTypeINT,
+	gamedata.StatTypeDEX,
+	gamedata.StatTypeLUK,
+}
+
+// EventState is the runtime side of the current event room.
+type EventState struct {
+	Interacted   bool
+	Outcome      string
+	WavesSpawned int
+	WaveTimer    float32
+	Totem        *EventTotem
+	WaveEnemies  map[*gameobjects.Enemy]bool
+	TotemTargets map[*gameobjects.Enemy]bool
+}
+
+type EventTotem struct {
+	X             float32
+	Y             float32
+	Radius        float32
+	HP            int
+	MaxHP         int
+	HitFlashTimer float32
+}
+
+func (t *EventTotem) IsAlive() bool {
+	return t != nil && t.HP > 0
+}
+
+func (g *Game) resetRoomEvent() {
+	g.RoomEvent = nil
+	if g.CurrentRoom == nil || g.CurrentRoom.Type != world.RoomTypeEvent {
+		return
+	}
+
+	state := &EventState{
+		WaveEnemies:  map[*gameobjects.Enemy]bool{},
+		TotemTargets: map[*gameobjects.Enemy]bool{},
+	}
+	event := g.CurrentRoom.Event
+	if event != nil && event.Config.Kind == world.EventKindDefendTotem {
+		state.WaveTimer = EventWaveStartDelay
+		state.Totem = &EventTotem{
+			X:      event.X,
+			Y:      event.Y,
+			Radius: EventTotemRadius,
+			HP:     event.Config.TotemHP,
+			MaxHP:  event.Config.TotemHP,
+		}
+	}
+	g.RoomEvent = state
+}
+
+func (g *Game) roomEventKind() world.EventKind {
+	if g.CurrentRoom == nil || g.CurrentRoom.Event == nil {
+		return world.EventKindSurvive
+	}
+	return g.CurrentRoom.Event.Config.Kind
+}
+
+func (g *Game) updateRoomEvent(input *systems.Input, dt float32) {
+	room := g.CurrentRoom
+	if room == nil || room.Type != world.RoomTypeEvent || room.Completed || g.Player == nil {
+		return
+	}
+	if g.RoomEvent == nil {
+		g.resetRoomEvent()
+	}
+
+	playerX, playerY := g.Player.Center()
+	switch g.roomEventKind() {
+	case world.EventKindHealingShrine, world.EventKindAltar:
+		if input != nil && input.Interact && !g.RoomEvent.Interacted && room.Event.ContainsPoint(playerX, playerY) {
+			g.interactWithRoomEvent()
+		}
+	case world.EventKindCapturePoint:
+		if room.Event.ContainsPoint(playerX, playerY) {
+			g.tickRoomEventTimer(dt)
+		}
+	case world.EventKindDefendTotem:
+		g.updateTotemEvent(dt)
+	default:
+		g.tickRoomEventTimer(dt)
+	}
+}
+
+func (g *Game) tickRoomEventTimer(dt float32) {
+	g.CurrentRoom.EventTimeLeft -= dt
+	if g.CurrentRoom.EventTimeLeft < 0 {
+		g.CurrentRoom.EventTimeLeft = 0
+	}
+}
+
+func (g *Game) interactWithRoomEvent() {
+	state := g.RoomEvent
+	config := g.CurrentRoom.Event.Config
+	state.Interacted = true
+
+	switch config.Kind {
+	case world.EventKindHealingShrine:
+		healed := g.healPlayerWithFeedbackSource(int(float32(g.Player.MaxHP)*config.HealPercent), healingSoundShrine)
+		state.Outcome = fmt.Sprintf("Shrine restored %d HP", healed)
+	case world.EventKindAltar:
+		state.Outcome = g.applyAltarModifier(config)
+	}
+
+	x, y := g.Player.Center()
+	g.addCombatTextEvent(x, y-g.Player.Hitbox.Height, state.Outcome, CombatTextStatus, combatStatusColor, CombatFeedbackStatusDuration, CombatFeedbackBaseScale, false)
+}
+
+func (g *Game) applyAltarModifier(config world.EventConfig) string {
+	if g.Player.Stats == nil {
+		g.Player.Stats = gamedata.NewStats()
+	}
+	blessed := g.RNG.Float32(core.RNGStreamEvents) < config.BlessingChance
+	stat := eventAltarStats[g.RNG.Intn(core.RNGStreamEvents, len(eventAltarStats))]
+
+	amount := config.StatAmount
+	if !blessed {
+		amount = -min(config.StatAmount, g.Player.Stats.GetStat(stat)-1)
+	}
+	g.Player.Stats.AddStat(stat, amount)
+	g.Player.ApplyStats()
+
+	if blessed {
+		return fmt.Sprintf("Blessing: %+d %s", amount, stat)
+	}
+	return fmt.Sprintf("Curse: %+d %s", amount, stat)
+}
+
+func (g *Game) updateTotemEvent(dt float32) {
+	state := g.RoomEvent
+	totem := state.Totem
+	if totem == nil {
+		return
+	}
+	if totem.HitFlashTimer > 0 {
+		totem.HitFlashTimer -= dt
+	}
+	if !totem.IsAlive() {
+		return
+	}
+
+	waves := g.CurrentRoom.Event.Waves
+	if state.WavesSpawned >= len(waves) {
+		return
+	}
+	state.WaveTimer -= dt
+	if state.WaveTimer > 0 {
+		return
+	}
+	g.spawnEventWave(waves[state.WavesSpawned])
+	state.WavesSpawned++
+	state.WaveTimer += g.CurrentRoom.Event.Config.WaveInterval
+}
+
+func (g *Game) spawnEventWave(refs []*world.EnemyRef) {
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+		enemy := gameobjects.NewEnemyFromArchetype(ref.X, ref.Y, ref.Type, ref.IsElite, ref.EliteModifier)
+		enemy.Provoked = true
+		g.Enemies = append(g.Enemies, enemy)
+		g.RoomEvent.WaveEnemies[enemy] = true
+	}
+	g.invalidateCombatSpace()
+}
+
+// enemyTarget sends wave enemies at a living totem unless the player is within the event radius.
+func (g *Game) enemyTarget(enemy *gameobjects.Enemy, playerX, playerY float32) (float32, float32) {
+	state := g.RoomEvent
+	if state == nil || state.TotemTargets == nil {
+		return playerX, playerY
+	}
+	delete(state.TotemTargets, enemy)
+	if !state.WaveEnemies[enemy] || !state.Totem.IsAlive() || g.CurrentRoom == nil || g.CurrentRoom.Event == nil {
+		return playerX, playerY
+	}
+	enemyX, enemyY := enemy.Center()
+	if systems.GetDistance(enemyX, enemyY, playerX, playerY) <= g.CurrentRoom.Event.Config.Radius {
+		return playerX, playerY
+	}
+	state.TotemTargets[enemy] = true
+	return state.Totem.X, state.Totem.Y
+}
+
+func (g *Game) enemyTargetsTotem(enemy *gameobjects.Enemy) bool {
+	return g.RoomEvent != nil && g.RoomEvent.TotemTargets[enemy] && g.RoomEvent.Totem.IsAlive()
+}
+
+func (g *Game) damageEventTotem(damage int) {
+	totem := g.RoomEvent.Totem
+	if !totem.IsAlive() || damage <= 0 {
+		return
+	}
+	totem.HP -= damage
+	totem.HitFlashTimer = EventTotemHitFlashLength
+	g.addCombatTextEvent(totem.X, totem.Y-totem.Radius*2, fmt.Sprintf("%d", damage), CombatTextDamage, combatDamagePlayerColor, CombatFeedbackTextDuration, CombatFeedbackBaseScale, false)
+	if totem.HP > 0 {
+		return
+	}
+	totem.HP = 0
+	g.RoomEvent.Outcome = "Totem destroyed"
+	g.addCombatTextEvent(totem.X, totem.Y-totem.Radius*2, g.RoomEvent.Outcome, CombatTextStatus, combatStatusColor, CombatFeedbackStatusDuration, CombatFeedbackBaseScale, false)
+}
+
+func (g *Game) roomEventComplete() bool {
+	switch g.roomEventKind() {
+	case world.EventKindHealingShrine, world.EventKindAltar:
+		return g.RoomEvent != nil && g.RoomEvent.Interacted
+	case world.EventKindDefendTotem:
+		if g.RoomEvent == nil {
+			return false
+		}
+		if g.RoomEvent.Totem.IsAlive() && g.RoomEvent.WavesSpawned < len(g.CurrentRoom.Event.Waves) {
+			return false
+		}
+		for _, enemy := range g.Enemies {
+			if enemy != nil && enemy.Alive {
+				return false
+			}
+		}
+		return true
+	default:
+		return g.CurrentRoom.EventTimeLeft <= 0
+	}
+}
+
+func (g *Game) finishRoomEvent() {
+	state := g.RoomEvent
+	if state == nil || g.roomEventKind() != world.EventKindDefendTotem {
+		return
+	}
+	if !state.Totem.IsAlive() {
+		state.Outcome = "Totem destroyed: no reward"
+		return
+	}
+	reward := g.CurrentRoom.Event.Config.RewardXP
+	g.grantPlayerXP(reward)
+	state.Outcome = fmt.Sprintf("Totem defended: +%d XP", reward)
+	x, y := g.Player.Center()
+	g.addCombatTextEvent(x, y-g.Player.Hitbox.Height, state.Outcome, CombatTextStatus, combatStatusColor, CombatFeedbackStatusDuration, CombatFeedbackBaseScale, false)
+}
+
+func (g *Game) roomEventObjective() string {
+	room := g.CurrentRoom
+	if room == nil || room.Type != world.RoomTypeEvent {
+		return ""
+	}
+	if room.Completed {
+		if g.RoomEvent != nil {
+			return g.RoomEvent.Outcome
+		}
+		return ""
+	}
+
+	switch g.roomEventKind() {
+	case world.EventKindHealingShrine:
+		return fmt.Sprintf("Shrine: press %s nearby to heal", g.Settings.KeybindDisplay.Interact)
+	case world.EventKindAltar:
+		return fmt.Sprintf("Altar: press %s nearby to accept its blessing or curse", g.Settings.KeybindDisplay.Interact)
+	case world.EventKindCapturePoint:
+		return fmt.Sprintf("Hold the point: %.1fs", room.EventTimeLeft)
+	case world.EventKindDefendTotem:
+		if g.RoomEvent == nil || g.RoomEvent.Totem == nil {
+			return ""
+		}
+		if !g.RoomEvent.Totem.IsAlive() {
+			return "Totem destroyed: clear the room"
+		}
+		return fmt.Sprintf("Defend the totem %d/%d  wave %d/%d", g.RoomEvent.Totem.HP, g.RoomEvent.Totem.MaxHP, g.RoomEvent.WavesSpawned, len(room.Event.Waves))
+	default:
+		return fmt.Sprintf("Survive: %.1fs", room.EventTimeLeft)
+	}
+}
+
+func (g *Game) drawRoomEvent() {
+	room := g.CurrentRoom
+	if room == nil || room.Event == nil || room.Type != world.RoomTypeEvent || room.Event.Config.Kind == world.EventKindSurvive {
+		return
+	}
+
+	event := room.Event
+	progress := float32(0)
+	if event.Config.Kind == world.EventKindCapturePoint && room.EventDuration > 0 {
+		progress = 1 - room.EventTimeLeft/room.EventDuration
+	}
+	spent := room.Completed || (g.RoomEvent != nil && g.RoomEvent.Interacted)
+	radius := event.Config.Radius
+	if event.Config.Kind == world.EventKindDefendTotem {
+		radius = 0
+	}
+	systems.DrawEventSite(event.X, event.Y, radius, event.Config.Kind, progress, spent, g.Camera)
+
+	if g.RoomEvent == nil || !g.RoomEvent.Totem.IsAlive() {
+		return
+	}
+	totem := g.RoomEvent.Totem
+	systems.DrawEventTotem(totem.X, totem.Y, totem.Radius, float32(totem.HP)/float32(totem.MaxHP), totem.HitFlashTimer > 0, g.Camera)
+}
@@ -0,0 +1,230 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+func newRoomEventTestGame(t *testing.T, config world.EventConfig) *Game {
+	t.Helper()
+	g := NewGame(settings.Default())
+	g.State = StateRun
+	g.Player = gameobjects.NewPlayer(10, 10, gamedata.ClassTypeMelee)
+	g.CurrentRoom = &world.Room{
+		Width:  960,
+		Height: 960,
+		Type:   world.RoomTypeEvent,
+		Event:  &world.RoomEvent{Config: config, X: 480, Y: 480},
+	}
+	if config.UsesTimer() {
+		g.CurrentRoom.EventDuration = config.Duration
+		g.CurrentRoom.EventTimeLeft = config.Duration
+	}
+	g.SpawnRoomEnemies()
+	g.Enemies = []*gameobjects.Enemy{}
+	g.invalidateCombatSpace()
+	return g
+}
+
+func movePlayerCenterTo(player *gameobjects.Player, x, y float32) {
+	player.PosX = x - player.Hitbox.Width/2
+	player.PosY = y - player.Hitbox.Height/2
+}
+
+func TestHealingShrineHealsOnlyWhenPlayerInteractsInRange(t *testing.T) {
+	g := newRoomEventTestGame(t, world.EventConfig{Kind: world.EventKindHealingShrine, Radius: 100, HealPercent: 0.5})
+	g.Player.HP = 10
+
+	g.updateRoomEvent(&systems.Input{Interact: true}, 0.1)
+	if g.Player.HP != 10 || g.CheckRoomCompletion() {
+		t.Fatalf("expected out-of-range interact to do nothing, got hp=%d", g.Player.HP)
+	}
+
+	movePlayerCenterTo(g.Player, 480, 480)
+	g.updateRoomEvent(&systems.Input{}, 0.1)
+	if g.CheckRoomCompletion() {
+		t.Fatalf("expected shrine room to stay open until used")
+	}
+
+	g.updateRoomEvent(&systems.Input{Interact: true}, 0.1)
+	if g.Player.HP != 10+g.Player.MaxHP/2 {
+		t.Fatalf("expected shrine to heal half max hp, got %d", g.Player.HP)
+	}
+	if !g.CheckRoomCompletion() {
+		t.Fatalf("expected used shrine to complete the room")
+	}
+}
+
+func TestAltarAppliesRunLongStatModifier(t *testing.T) {
+	cases := []struct {
+		name   string
+		chance float32
+		sign   int
+	}{
+		{name: "blessing", chance: 1, sign: 1},
+		{name: "curse", chance: 0, sign: -1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newRoomEventTestGame(t, world.EventConfig{Kind: world.EventKindAltar, Radius: 100, BlessingChance: tc.chance, StatAmount: 2})
+			g.RNG = core.NewRunRNG(7)
+			before := *g.Player.Stats
+			movePlayerCenterTo(g.Player, 480, 480)
+
+			g.updateRoomEvent(&systems.Input{Interact: true}, 0.1)
+			if !g.CheckRoomCompletion() {
+				t.Fatalf("expected used altar to complete the room")
+			}
+
+			delta := 0
+			for _, stat := range eventAltarStats {
+				delta += g.Player.Stats.GetStat(stat) - before.GetStat(stat)
+			}
+			if delta != 2*tc.sign {
+				t.Fatalf("expected base stats to change by %d, got %d (%s)", 2*tc.sign, delta, g.RoomEvent.Outcome)
+			}
+
+			after := *g.Player.Stats
+			g.updateRoomEvent(&systems.Input{Interact: true}, 0.1)
+			if *g.Player.Stats != after {
+				t.Fatalf("expected the altar to apply only once")
+			}
+		})
+	}
+}
+
+func TestCapturePointOnlyProgressesWhilePlayerHoldsZone(t *testing.T) {
+	g := newRoomEventTestGame(t, world.EventConfig{Kind: world.EventKindCapturePoint, Duration: 1, Radius: 120})
+
+	g.updateRoomEvent(nil, 0.5)
+	if g.CurrentRoom.EventTimeLeft != 1 {
+		t.Fatalf("expected no capture progress outside the zone, got %v", g.CurrentRoom.EventTimeLeft)
+	}
+
+	movePlayerCenterTo(g.Player, 520, 480)
+	g.updateRoomEvent(nil, 0.5)
+	if g.CheckRoomCompletion() {
+		t.Fatalf("expected capture point to need the full duration")
+	}
+	g.updateRoomEvent(nil, 0.5)
+	if !g.CheckRoomCompletion() {
+		t.Fatalf("expected held capture point to complete the room")
+	}
+}
+
+func TestDefendTotemSpawnsWavesAndCompletesWhenCleared(t *testing.T) {
+	config := world.EventConfig{Kind: world.EventKindDefendTotem, Radius: 150, Waves: 2, WaveSize: 1, WaveInterval: 4, TotemHP: 50, RewardXP: 40}
+	g := newRoomEventTestGame(t, config)
+	g.CurrentRoom.Event.Waves = [][]*world.EnemyRef{
+		{{X: 860, Y: 480, Type: gamedata.EnemyArchetypeRaider}},
+		{{X: 860, Y: 400, Type: gamedata.EnemyArchetypeRaider}},
+	}
+	g.resetRoomEvent()
+
+	g.updateRoomEvent(nil, EventWaveStartDelay)
+	if len(g.Enemies) != 1 || g.RoomEvent.WavesSpawned != 1 {
+		t.Fatalf("expected first wave after the start delay, got %d enemies", len(g.Enemies))
+	}
+
+	g.Enemies[0].HP = 0
+	g.Enemies[0].Alive = false
+	if g.CheckRoomCompletion() {
+		t.Fatalf("expected room to stay open while waves remain")
+	}
+
+	g.updateRoomEvent(nil, config.WaveInterval)
+	if len(g.Enemies) != 2 || g.RoomEvent.WavesSpawned != 2 {
+		t.Fatalf("expected second wave, got %d enemies", len(g.Enemies))
+	}
+	if g.CheckRoomCompletion() {
+		t.Fatalf("expected room to stay open while wave enemies live")
+	}
+
+	g.Enemies[1].HP = 0
+	g.Enemies[1].Alive = false
+	xp := g.Player.XP
+	if !g.CheckRoomCompletion() {
+		t.Fatalf("expected cleared final wave to complete the room")
+	}
+	if g.Player.XP != xp+config.RewardXP || g.RoomEvent.Outcome != "Totem defended: +40 XP" {
+		t.Fatalf("expected the defended totem to grant its reward, got xp %d -> %d (%s)", xp, g.Player.XP, g.RoomEvent.Outcome)
+	}
+	g.CheckRoomCompletion()
+	if g.Player.XP != xp+config.RewardXP {
+		t.Fatalf("expected the reward to be granted once, got %d", g.Player.XP)
+	}
+}
+
+func TestDefendTotemFailsWithoutRewardWhenTotemDies(t *testing.T) {
+	config := world.EventConfig{Kind: world.EventKindDefendTotem, Radius: 150, Waves: 2, WaveSize: 1, WaveInterval: 4, TotemHP: 20, RewardXP: 40}
+	g := newRoomEventTestGame(t, config)
+	g.CurrentRoom.Event.Waves = [][]*world.EnemyRef{
+		{{X: 860, Y: 480, Type: gamedata.EnemyArchetypeRaider}},
+		{{X: 860, Y: 400, Type: gamedata.EnemyArchetypeRaider}},
+	}
+	g.resetRoomEvent()
+	g.updateRoomEvent(nil, EventWaveStartDelay)
+
+	g.damageEventTotem(config.TotemHP)
+	g.updateRoomEvent(nil, config.WaveInterval)
+	if g.RoomEvent.WavesSpawned != 1 {
+		t.Fatalf("expected no further waves once the totem falls, got %d", g.RoomEvent.WavesSpawned)
+	}
+
+	g.Enemies[0].HP = 0
+	g.Enemies[0].Alive = false
+	xp := g.Player.XP
+	if !g.CheckRoomCompletion() {
+		t.Fatalf("expected the room to open once cleared after the totem falls")
+	}
+	if g.Player.XP != xp || g.RoomEvent.Outcome != "Totem destroyed: no reward" {
+		t.Fatalf("expected a failed defense to grant nothing, got xp %d -> %d (%s)", xp, g.Player.XP, g.RoomEvent.Outcome)
+	}
+	if g.roomEventObjective() != g.RoomEvent.Outcome {
+		t.Fatalf("expected the HUD to report the failure, got %q", g.roomEventObjective())
+	}
+}
+
+func TestWaveEnemiesAttackTotemWhilePlayerIsAway(t *testing.T) {
+	config := world.EventConfig{Kind: world.EventKindDefendTotem, Radius: 150, Waves: 1, WaveSize: 1, WaveInterval: 4, TotemHP: 30}
+	g := newRoomEventTestGame(t, config)
+	g.CurrentRoom.Event.Waves = [][]*world.EnemyRef{{{X: 490, Y: 470, Type: gamedata.EnemyArchetypeRaider}}}
+	g.resetRoomEvent()
+	g.updateRoomEvent(nil, EventWaveStartDelay)
+	enemy := g.Enemies[0]
+
+	playerX, playerY := g.Player.Center()
+	targetX, targetY := g.enemyTarget(enemy, playerX, playerY)
+	if targetX != 480 || targetY != 480 || !g.enemyTargetsTotem(enemy) {
+		t.Fatalf("expected wave enemy to go for the totem, got (%v,%v)", targetX, targetY)
+	}
+
+	playerHP := g.Player.HP
+	gameobjects.ResolveEnemyIntent(enemy, targetX, targetY)
+	(&combatResolveSystem{}).Update(&RuntimeContext{Game: g}, 0.1)
+	if g.RoomEvent.Totem.HP != config.TotemHP-enemy.Damage || g.Player.HP != playerHP {
+		t.Fatalf("expected the attack to land on the totem, got totem=%d player=%d/%d", g.RoomEvent.Totem.HP, g.Player.HP, playerHP)
+	}
+
+	movePlayerCenterTo(g.Player, 520, 480)
+	playerX, playerY = g.Player.Center()
+	if targetX, _ := g.enemyTarget(enemy, playerX, playerY); targetX != playerX || g.enemyTargetsTotem(enemy) {
+		t.Fatalf("expected a nearby player to pull the wave enemy off the totem")
+	}
+
+	g.RoomEvent.Totem.HP = 1
+	g.damageEventTotem(5)
+	enemy.HP = 0
+	enemy.Alive = false
+	if g.RoomEvent.Totem.IsAlive() || !g.CheckRoomCompletion() {
+		t.Fatalf("expected a fallen totem to end the event once the room is clear")
+	}
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"singlefantasy/app/core"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+func (g *Game) updateRoomHazards(dt float32) {
+	room := g.CurrentRoom
+	if room == nil || g.Player == nil {
+		return
+	}
+	if room.Hazards.Update(dt) {
+		g.applyHazardTick(room.Hazards)
+	}
+	for _, trap := range room.Traps {
+		if trap.Update(dt) {
+			g.triggerTrap(trap)
+		}
+	}
+}
+
+func (g *Game) applyHazardTick(field *world.HazardField) {
+	config := field.Config
+	if config.Damage <= 0 && len(config.Effects) == 0 {
+		return
+	}
+	targets := []core.Combatant{}
+	if g.Player.IsAlive() {
+		targets = append(targets, g.Player)
+	}
+	targets = append(targets, g.combatSpace().Combatants()...)
+	for _, target := range targets {
+		if !target.IsAlive() {
+			continue
+		}
+		x, y := target.Center()
+		if !field.ContainsPoint(x, y) {
+			continue
+		}
+		g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+			Target:        target,
+			BaseDamage:    config.Damage,
+			DamageType:    config.DamageType,
+			Effects:       config.Effects,
+			SuppressFlash: true,
+		})
+	}
+}
+
+func (g *Game) triggerTrap(trap *world.Trap) {
+	config := trap.Config
+	sourceX := trap.Bounds.X + trap.Bounds.Width/2
+	sourceY := trap.Bounds.Y + trap.Bounds.Height/2
+	g.publish(CombatEvent{Type: EventTrapTriggered, X: sourceX, Y: sourceY, RoomIndex: g.currentRoomIndex()})
+
+	playerBounds := world.AABB{X: g.Player.PosX, Y: g.Player.PosY, Width: g.Player.Hitbox.Width, Height: g.Player.Hitbox.Height}
+	if systems.AABBOverlap(playerBounds, trap.Strike) {
+		g.ApplyPlayerCombatHit(config.Damage, config.DamageType, sourceX, sourceY, config.Effects)
+	}
+
+	for _, target := range g.combatSpace().CombatantsInAABB(trap.Strike) {
+		if !target.IsAlive() {
+			continue
+		}
+		x, y, width, height := target.GetBounds()
+		if !systems.AABBOverlap(world.AABB{X: x, Y: y, Width: width, Height: height}, trap.Strike) {
+			continue
+		}
+		g.applyCombatHitWithFeedback(systems.CombatHitRequest{
+			Target:     target,
+			BaseDamage: config.Damage,
+			DamageType: config.DamageType,
+			Effects:    config.Effects,
+		})
+	}
+}
@@ -0,0 +1,88 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/world"
+)
+
+func TestRoomHazardTickDamagesEveryActorStandingOnIt(t *testing.T) {
+	g := NewGame(settings.Default())
+	g.State = StateRun
+	g.Player = gameobjects.NewPlayer(10, 10, gamedata.ClassTypeMelee)
+	onHazard := gameobjects.NewEnemyFromArchetype(20, 20, gamedata.EnemyArchetypeRaider, false, 0)
+	offHazard := gameobjects.NewEnemyFromArchetype(400, 400, gamedata.EnemyArchetypeRaider, false, 0)
+	g.Enemies = []*gameobjects.Enemy{onHazard, offHazard}
+	g.CurrentRoom = &world.Room{
+		Width:  600,
+		Height: 600,
+		Hazards: &world.HazardField{
+			Config: world.HazardConfig{
+				Damage:       5,
+				TickInterval: 0.5,
+				DamageType:   gamedata.DamageTrue,
+				Effects:      []gamedata.EffectSpec{{Type: gamedata.EffectSlow, Duration: 1, Magnitude: 0.3}},
+			},
+			Tiles:     []world.AABB{{X: 0, Y: 0, Width: 96, Height: 96}},
+			TickTimer: 0.5,
+		},
+	}
+	g.invalidateCombatSpace()
+
+	playerHP := g.Player.HP
+	g.updateRoomHazards(0.25)
+	if g.Player.HP != playerHP || onHazard.HP != onHazard.MaxHP {
+		t.Fatalf("expected no damage before the first tick")
+	}
+
+	g.updateRoomHazards(0.25)
+	if g.Player.HP != playerHP-5 || onHazard.HP != onHazard.MaxHP-5 || offHazard.HP != offHazard.MaxHP {
+		t.Fatalf("expected one tick on actors over the hazard, got player=%d/%d on=%d off=%d", g.Player.HP, playerHP, onHazard.HP, offHazard.HP)
+	}
+	if !gamedata.HasEffect(&g.Player.Effects, gamedata.EffectSlow) {
+		t.Fatalf("expected hazard effect applied to the player")
+	}
+
+	g.Player.PosX, g.Player.PosY = 300, 300
+	g.updateRoomHazards(0.5)
+	if g.Player.HP != playerHP-5 {
+		t.Fatalf("expected player off the hazard to take no damage")
+	}
+}
+
+func TestRoomTrapStrikesActorsInLaneWhenItFires(t *testing.T) {
+	g := NewGame(settings.Default())
+	g.State = StateRun
+	g.Player = gameobjects.NewPlayer(300, 300, gamedata.ClassTypeMelee)
+	inLane := gameobjects.NewEnemyFromArchetype(130, 30, gamedata.EnemyArchetypeRaider, false, 0)
+	g.Enemies = []*gameobjects.Enemy{inLane}
+	trap := &world.Trap{
+		Config: world.TrapConfig{Kind: world.TrapKindArrowLauncher, Damage: 12, DamageType: gamedata.DamageTrue, ArmDuration: 2, TelegraphDuration: 0.5, ActiveDuration: 0.25},
+		Bounds: world.AABB{X: 0, Y: 0, Width: 96, Height: 96},
+		Strike: world.AABB{X: 96, Y: 0, Width: 288, Height: 96},
+		Phase:  world.TrapPhaseTelegraph,
+		Timer:  0.1,
+	}
+	g.CurrentRoom = &world.Room{Width: 600, Height: 600, Traps: []*world.Trap{trap}}
+	g.invalidateCombatSpace()
+
+	playerHP := g.Player.HP
+	g.Events.BeginFrame()
+	g.updateRoomHazards(0.125)
+	if trap.Phase != world.TrapPhaseActive || g.Events.CountFrameEvents(EventTrapTriggered) != 1 {
+		t.Fatalf("expected trap to fire, got phase=%v", trap.Phase)
+	}
+	if inLane.HP != inLane.MaxHP-12 || g.Player.HP != playerHP {
+		t.Fatalf("expected only the enemy in the lane hit, got enemy=%d player=%d", inLane.HP, g.Player.HP)
+	}
+
+	g.updateRoomHazards(0.125)
+	if inLane.HP != inLane.MaxHP-12 {
+		t.Fatalf("expected a single strike per activation")
+	}
+}
@@ -0,0 +1,339 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/world"
+)
+
+const (
+	RunSaveFormatVersion = 1
+	DefaultRunSavePath   = "savegame.json"
+)
+
+type RunSave struct {
+	Version                  int                    `json:"version"`
+	Seed                     int64                  `json:"seed"`
+	Class                    gamedata.ClassType     `json:"class"`
+	RoomIndex                int                    `json:"room_index"`
+	CompletedRooms           []bool                 `json:"completed_rooms"`
+	RunElapsed               float32                `json:"run_elapsed"`
+	RewardSeed               int64                  `json:"reward_seed"`
+	RewardHistory            []RunSaveRewardOffer   `json:"reward_history"`
+	MilestoneRewardTriggered bool                   `json:"milestone_reward_triggered"`
+	BossRewardTriggered      bool                   `json:"boss_reward_triggered"`
+	PendingReward            gamedata.RewardContext `json:"pending_reward,omitempty"`
+	Player                   RunSavePlayer          `json:"player"`
+}
+
+type RunSaveRewardOffer struct {
+	Context  gamedata.RewardContext `json:"context"`
+	ItemIDs  []string               `json:"item_ids"`
+	OfferKey string                 `json:"offer_key"`
+}
+
+type RunSavePlayer struct {
+	HP                 int                       `json:"hp"`
+	Mana               int                       `json:"mana"`
+	Level              int                       `json:"level"`
+	XP                 int                       `json:"xp"`
+	XPToNext           int                       `json:"xp_to_next"`
+	StatPoints         int                       `json:"stat_points"`
+	Stats              gamedata.Stats            `json:"stats"`
+	Equipment          []RunSaveEquipment        `json:"equipment"`
+	Skills             []RunSaveSkill            `json:"skills"`
+	Effects            []gamedata.EffectInstance `json:"effects"`
+	ManaShieldActive   bool                      `json:"mana_shield_active"`
+	ManaShieldAmount   int                       `json:"mana_shield_amount"`
+	ManaShieldTimeLeft float32                   `json:"mana_shield_time_left"`
+}
+
+type RunSaveEquipment struct {
+	Slot   gamedata.ItemSlot `json:"slot"`
+	ItemID string            `json:"item_id"`
+}
+
+type RunSaveSkill struct {
+	Type            gamedata.SkillType `json:"type"`
+	CurrentCooldown float32            `json:"current_cooldown"`
+}
+
+func (g *Game) BuildRunSave() (*RunSave, error) {
+	if g == nil || !g.isRunSaveState() || g.Player == nil || g.Dungeon == nil {
+		return nil, fmt.Errorf("no run in progress")
+	}
+
+	pendingReward := gamedata.RewardContextNone
+	if g.State == StateReward {
+		pendingReward = g.RewardContext
+	}
+
+	completed := make([]bool, len(g.Dungeon.Rooms))
+	for i, room := range g.Dungeon.Rooms {
+		completed[i] = room != nil && room.Completed
+	}
+
+	history := make([]RunSaveRewardOffer, 0, len(g.RewardHistory))
+	for _, entry := range g.RewardHistory {
+		history = append(history, RunSaveRewardOffer{
+			Context:  entry.Context,
+			ItemIDs:  append([]string(nil), entry.ItemIDs...),
+			OfferKey: entry.OfferKey,
+		})
+	}
+
+	return &RunSave{
+		Version:                  RunSaveFormatVersion,
+		Seed:                     g.Dungeon.Seed,
+		Class:                    g.SelectedClass,
+		RoomIndex:                g.Dungeon.CurrentRoom,
+		CompletedRooms:           completed,
+		RunElapsed:               g.RunElapsed,
+		RewardSeed:               g.RewardSeed,
+		RewardHistory:            history,
+		MilestoneRewardTriggered: g.MilestoneRewardTriggered,
+		BossRewardTriggered:      g.BossRewardTriggered,
+		PendingReward:            pendingReward,
+		Player:                   buildRunSavePlayer(g.Player),
+	}, nil
+}
+
+// isRunSaveState reports whether the run can be saved: mid-room or on a reward screen between rooms.
+func (g *Game) isRunSaveState() bool {
+	return g.State == StateRun || g.State == StateReward
+}
+
+func buildRunSavePlayer(player *gameobjects.Player) RunSavePlayer {
+	saved := RunSavePlayer{
+		HP:                 player.HP,
+		Mana:               player.Mana,
+		Level:              player.Level,
+		XP:                 player.XP,
+		XPToNext:           player.XPToNext,
+		StatPoints:         player.StatPoints,
+		Equipment:          []RunSaveEquipment{},
+		Skills:             []RunSaveSkill{},
+		Effects:            append([]gamedata.EffectInstance{}, player.Effects...),
+		ManaShieldActive:   player.ManaShieldActive,
+		ManaShieldAmount:   player.ManaShieldAmount,
+		ManaShieldTimeLeft: player.ManaShieldTimeLeft,
+	}
+	if player.Stats != nil {
+		saved.Stats = *player.Stats
+	}
+
+	for _, slot := range []gamedata.ItemSlot{gamedata.ItemSlotWeapon, gamedata.ItemSlotHead, gamedata.ItemSlotChest, gamedata.ItemSlotLower} {
+		item := player.Equipment[slot]
+		if item == nil {
+			continue
+		}
+		saved.Equipment = append(saved.Equipment, RunSaveEquipment{Slot: slot, ItemID: item.ID})
+	}
+	for _, skill := range player.Skills {
+		if skill == nil {
+			continue
+		}
+		saved.Skills = append(saved.Skills, RunSaveSkill{Type: skill.Type, CurrentCooldown: skill.CurrentCooldown})
+	}
+	return saved
+}
+
+func SaveRunToPath(path string, save *RunSave) error {
+	if save == nil {
+		return fmt.Errorf("run save is nil")
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	payload, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, payload, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, path)
+}
+
+func LoadRunSave(path string) (*RunSave, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read run save %q: %w", path, err)
+	}
+
+	var save RunSave
+	if err := json.Unmarshal(content, &save); err != nil {
+		return nil, fmt.Errorf("parse run save %q: %w", path, err)
+	}
+	if save.Version != RunSaveFormatVersion {
+		return nil, fmt.Errorf("run save %q has unsupported version %d", path, save.Version)
+	}
+	return &save, nil
+}
+
+func HasRunSave(path string) bool {
+	if path == "" {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func DeleteRunSave(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ResumeRun rebuilds the dungeon from the saved seed and drops the player at the saved room's entry. The run RNG is
+// reseeded for that room, so the room replays the same spawn, crit and proc rolls it had when first entered.
+func (g *Game) ResumeRun(save *RunSave) error {
+	if g == nil || save == nil {
+		return fmt.Errorf("run save is nil")
+	}
+
+	cfg := world.DefaultDungeonGenerationConfig()
+	cfg.Seed = save.Seed
+	dungeon, err := world.NewDungeonWithConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if save.RoomIndex < 0 || save.RoomIndex >= len(dungeon.Rooms) {
+		return fmt.Errorf("run save room index %d outside dungeon with %d rooms", save.RoomIndex, len(dungeon.Rooms))
+	}
+
+	g.ResetState()
+	g.SelectedClass = save.Class
+	g.Dungeon = dungeon
+	g.RNG = core.NewRunRNG(dungeon.Seed)
+	g.RNG.BeginRoom(save.RoomIndex)
+	for i, room := range dungeon.Rooms {
+		if i < len(save.CompletedRooms) {
+			room.Completed = save.CompletedRooms[i]
+		}
+	}
+	g.Dungeon.CurrentRoom = save.RoomIndex
+	g.CurrentRoom = g.Dungeon.GetCurrentRoom()
+
+	entryX, entryY := g.CurrentRoom.EntryPoint()
+	if save.RoomIndex == 0 {
+		entryX = g.CurrentRoom.X + g.CurrentRoom.Width/2
+		entryY = g.CurrentRoom.Y + g.CurrentRoom.Height/2
+	}
+	g.Player = gameobjects.NewPlayer(0, 0, save.Class)
+	restoreRunSavePlayer(g.Player, save.Player)
+	g.Player.PosX = entryX - g.Player.Hitbox.Width/2
+	g.Player.PosY = entryY - g.Player.Hitbox.Height/2
+
+	g.SpawnRoomEnemies()
+	if g.CurrentRoom.Completed {
+		g.Enemies = []*gameobjects.Enemy{}
+		g.Boss = nil
+	}
+	if !g.CurrentRoom.IsBoss() {
+		g.CurrentRoom.SetDoorsLocked(!g.CurrentRoom.Completed)
+	}
+
+	g.RunElapsed = save.RunElapsed
+	g.RewardSeed = save.RewardSeed
+	g.MilestoneRewardTriggered = save.MilestoneRewardTriggered
+	g.BossRewardTriggered = save.BossRewardTriggered
+	g.RewardHistory = make([]gamedata.RewardOfferHistoryEntry, 0, len(save.RewardHistory))
+	for _, entry := range save.RewardHistory {
+		g.RewardHistory = append(g.RewardHistory, gamedata.RewardOfferHistoryEntry{
+			Context:  entry.Context,
+			ItemIDs:  append([]string(nil), entry.ItemIDs...),
+			OfferKey: entry.OfferKey,
+		})
+	}
+	g.attachReplayRecorder()
+	g.State = StateRun
+	if save.PendingReward != gamedata.RewardContextNone {
+		g.openReward(save.PendingReward)
+	}
+	return nil
+}
+
+func restoreRunSavePlayer(player *gameobjects.Player, saved RunSavePlayer) {
+	stats := saved.Stats
+	player.Stats = &stats
+	for _, equipped := range saved.Equipment {
+		item := gamedata.GetItemByID(equipped.ItemID)
+		if item == nil {
+			continue
+		}
+		player.Equipment[item.Slot] = item
+	}
+	player.ApplyStats()
+
+	player.Level = saved.Level
+	player.XP = saved.XP
+	player.XPToNext = saved.XPToNext
+	player.StatPoints = saved.StatPoints
+	player.HP = clampInt(saved.HP, 1, player.MaxHP)
+	player.Mana = clampInt(saved.Mana, 0, player.MaxMana)
+	player.Effects = append([]gamedata.EffectInstance{}, saved.Effects...)
+	player.ManaShieldActive = saved.ManaShieldActive
+	player.ManaShieldAmount = saved.ManaShieldAmount
+	player.ManaShieldTimeLeft = saved.ManaShieldTimeLeft
+
+	for _, savedSkill := range saved.Skills {
+		for _, skill := range player.Skills {
+			if skill != nil && skill.Type == savedSkill.Type {
+				skill.CurrentCooldown = savedSkill.CurrentCooldown
+			}
+		}
+	}
+}
+
+func clampInt(value, minValue, maxValue int) int {
+	if value < minValue {
+		return minValue
+	}
+	if value > maxValue {
+		return maxValue
+	}
+	return value
+}
+
+// SaveRunIfActive persists an in-progress run, including one paused on a reward screen, to RunSavePath; finished
+// runs are left alone.
+func (g *Game) SaveRunIfActive() error {
+	if g == nil || g.RunSavePath == "" || !g.isRunSaveState() || g.Player == nil || !g.Player.IsAlive() {
+		return nil
+	}
+	save, err := g.BuildRunSave()
+	if err != nil {
+		return err
+	}
+	return SaveRunToPath(g.RunSavePath, save)
+}
+
+// ContinueSavedRun resumes the saved run and consumes the save, so the same snapshot cannot be resumed twice.
+func (g *Game) ContinueSavedRun() error {
+	save, err := LoadRunSave(g.RunSavePath)
+	if err != nil {
+		return err
+	}
+	if err := g.ResumeRun(save); err != nil {
+		return err
+	}
+	g.hasRunSave = false
+	return DeleteRunSave(g.RunSavePath)
+}
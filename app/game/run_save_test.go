@@ -0,0 +1,240 @@
+//go:build raylib
+
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/world"
+)
+
+func TestRunSaveRoundTripRestoresPlayerAndDungeonProgress(t *testing.T) {
+	g := NewHeadlessGame(HeadlessConfig{
+		Class:    gamedata.ClassTypeRanged,
+		Seed:     world.DefaultDungeonSeed,
+		Settings: settings.Default(),
+	})
+	g.Dungeon.Rooms[0].Completed = true
+	g.AdvanceToNextRoom()
+	g.Dungeon.Rooms[1].Completed = true
+	g.AdvanceToNextRoom()
+
+	g.Player.Level = 3
+	g.Player.XP = 17
+	g.Player.XPToNext = gamedata.XPToNextLevel(3)
+	g.Player.StatPoints = 2
+	g.Player.Stats.DEX += 4
+	g.Player.EquipItem(gamedata.GetItemByID("ranged_hunter_bow"))
+	g.Player.HP = g.Player.MaxHP - 10
+	g.Player.Skills[0].CurrentCooldown = 2.5
+	gamedata.ApplyEffect(&g.Player.Effects, gamedata.Effect{Type: gamedata.EffectSlow, Duration: 3, Magnitude: 0.4})
+	g.RunElapsed = 42
+	g.MilestoneRewardTriggered = true
+	g.RewardHistory = append(g.RewardHistory, gamedata.RewardOfferHistoryEntry{
+		Context:  gamedata.RewardContextMilestone,
+		ItemIDs:  []string{"ranged_hunter_bow"},
+		OfferKey: "ranged_hunter_bow",
+	})
+
+	save, err := g.BuildRunSave()
+	if err != nil {
+		t.Fatalf("expected run save to build: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "savegame.json")
+	if err := SaveRunToPath(path, save); err != nil {
+		t.Fatalf("expected run save to write: %v", err)
+	}
+	if !HasRunSave(path) {
+		t.Fatalf("expected saved run to be detected")
+	}
+
+	loaded, err := LoadRunSave(path)
+	if err != nil {
+		t.Fatalf("expected run save to load: %v", err)
+	}
+	resumed := NewGame(settings.Default())
+	resumed.RunSavePath = ""
+	if err := resumed.ResumeRun(loaded); err != nil {
+		t.Fatalf("expected run to resume: %v", err)
+	}
+
+	if resumed.State != StateRun || resumed.Dungeon.CurrentRoom != 2 {
+		t.Fatalf("expected resumed run in room 2, got state=%s room=%d", resumed.GetStateName(), resumed.Dungeon.CurrentRoom)
+	}
+	if len(resumed.Dungeon.Rooms) != len(g.Dungeon.Rooms) || resumed.CurrentRoom.TemplateID != g.CurrentRoom.TemplateID {
+		t.Fatalf("expected dungeon to be rebuilt from seed")
+	}
+	if !resumed.Dungeon.Rooms[0].Completed || !resumed.Dungeon.Rooms[1].Completed || resumed.Dungeon.Rooms[2].Completed {
+		t.Fatalf("expected room completion flags to be restored")
+	}
+	entryX, entryY := resumed.CurrentRoom.EntryPoint()
+	centerX, centerY := resumed.Player.Center()
+	if centerX != entryX || centerY != entryY {
+		t.Fatalf("expected player at room entry (%.1f,%.1f), got (%.1f,%.1f)", entryX, entryY, centerX, centerY)
+	}
+
+	player := resumed.Player
+	if player.Level != 3 || player.XP != 17 || player.StatPoints != 2 {
+		t.Fatalf("expected progression to be restored, got level=%d xp=%d points=%d", player.Level, player.XP, player.StatPoints)
+	}
+	if player.Stats.DEX != g.Player.Stats.DEX {
+		t.Fatalf("expected DEX %d, got %d", g.Player.Stats.DEX, player.Stats.DEX)
+	}
+	if weapon := player.Equipment[gamedata.ItemSlotWeapon]; weapon == nil || weapon.ID != "ranged_hunter_bow" {
+		t.Fatalf("expected equipped weapon to be restored")
+	}
+	if player.HP != g.Player.HP || player.MaxHP != g.Player.MaxHP {
+		t.Fatalf("expected HP %d/%d, got %d/%d", g.Player.HP, g.Player.MaxHP, player.HP, player.MaxHP)
+	}
+	if player.Skills[0].CurrentCooldown != 2.5 {
+		t.Fatalf("expected skill cooldown to be restored, got %.2f", player.Skills[0].CurrentCooldown)
+	}
+	if !gamedata.HasEffect(&player.Effects, gamedata.EffectSlow) {
+		t.Fatalf("expected active effects to be restored")
+	}
+	if resumed.RunElapsed != 42 || !resumed.MilestoneRewardTriggered || len(resumed.RewardHistory) != 1 {
+		t.Fatalf("expected run timers, milestone flag and reward history to be restored")
+	}
+
+	resumed.StepHeadless(5)
+	if resumed.State != StateRun {
+		t.Fatalf("expected resumed run to keep stepping, got %s", resumed.GetStateName())
+	}
+}
+
+func TestResumedRunReplaysRoomRNGFromRoomEntry(t *testing.T) {
+	g := NewHeadlessGame(HeadlessConfig{Class: gamedata.ClassTypeMelee, Seed: world.DefaultDungeonSeed, Settings: settings.Default()})
+	for i := 0; i < 7; i++ {
+		g.RNG.Float32(core.RNGStreamCombatCrits)
+		g.RNG.Float32(core.RNGStreamItemProcs)
+	}
+	g.Dungeon.Rooms[0].Completed = true
+	g.AdvanceToNextRoom()
+
+	save, err := g.BuildRunSave()
+	if err != nil {
+		t.Fatalf("expected run save to build: %v", err)
+	}
+	resumed := NewGame(settings.Default())
+	resumed.RunSavePath = ""
+	if err := resumed.ResumeRun(save); err != nil {
+		t.Fatalf("expected run to resume: %v", err)
+	}
+
+	if len(resumed.Enemies) != len(g.Enemies) {
+		t.Fatalf("expected the same room spawns, got %d and %d enemies", len(g.Enemies), len(resumed.Enemies))
+	}
+	for i, enemy := range g.Enemies {
+		if other := resumed.Enemies[i]; other.Name != enemy.Name || other.IsElite != enemy.IsElite || other.MaxHP != enemy.MaxHP {
+			t.Fatalf("expected spawn %d to match, got %s/%v and %s/%v", i, enemy.Name, enemy.IsElite, other.Name, other.IsElite)
+		}
+	}
+	for _, stream := range []core.RNGStream{core.RNGStreamCombatCrits, core.RNGStreamCombatHits, core.RNGStreamItemProcs} {
+		for i := 0; i < 4; i++ {
+			if want, got := g.RNG.Float32(stream), resumed.RNG.Float32(stream); want != got {
+				t.Fatalf("expected resumed %s roll %d to match the original run, got %.6f and %.6f", stream, i, want, got)
+			}
+		}
+	}
+}
+
+func TestRunSaveIsDeletedWhenRunEnds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "savegame.json")
+	g := NewHeadlessGame(HeadlessConfig{Class: gamedata.ClassTypeMelee, Settings: settings.Default()})
+	g.RunSavePath = path
+
+	if err := g.SaveRunIfActive(); err != nil {
+		t.Fatalf("expected active run to save: %v", err)
+	}
+	if !HasRunSave(path) {
+		t.Fatalf("expected save file after saving active run")
+	}
+
+	g.EnterResults(false, "")
+	if HasRunSave(path) {
+		t.Fatalf("expected save file to be removed once the run ends")
+	}
+}
+
+func TestRunSaveOnRewardScreenReopensTheSameOffer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "savegame.json")
+	g := NewHeadlessGame(HeadlessConfig{Class: gamedata.ClassTypeCaster, Seed: world.DefaultDungeonSeed, Settings: settings.Default()})
+	g.RunSavePath = path
+	g.Dungeon.Rooms[0].Completed = true
+	g.EnterMilestoneReward()
+	if g.State != StateReward {
+		t.Fatalf("expected milestone reward screen, got %s", g.GetStateName())
+	}
+	offered := rewardOptionIDs(g.RewardOptions)
+
+	if err := g.SaveRunIfActive(); err != nil || !HasRunSave(path) {
+		t.Fatalf("expected the reward screen to save the run, got %v", err)
+	}
+
+	resumed := NewGame(settings.Default())
+	resumed.RunSavePath = path
+	if err := resumed.ContinueSavedRun(); err != nil {
+		t.Fatalf("expected saved run to continue: %v", err)
+	}
+	if resumed.State != StateReward || resumed.RewardContext != gamedata.RewardContextMilestone {
+		t.Fatalf("expected resume to reopen the milestone reward, got %s", resumed.GetStateName())
+	}
+	if got := rewardOptionIDs(resumed.RewardOptions); !slices.Equal(got, offered) {
+		t.Fatalf("expected the same reward offer %v, got %v", offered, got)
+	}
+	if !resumed.Dungeon.Rooms[0].Completed {
+		t.Fatalf("expected the cleared room to stay cleared")
+	}
+
+	resumed.confirmRewardSelection()
+	if resumed.State != StateRun || len(resumed.RewardHistory) != 1 {
+		t.Fatalf("expected picking the reward to return to the run, got %s", resumed.GetStateName())
+	}
+}
+
+func TestContinueSavedRunConsumesTheSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "savegame.json")
+	g := NewHeadlessGame(HeadlessConfig{Class: gamedata.ClassTypeMelee, Settings: settings.Default()})
+	g.RunSavePath = path
+	if err := g.SaveRunIfActive(); err != nil {
+		t.Fatalf("expected active run to save: %v", err)
+	}
+
+	resumed := NewGame(settings.Default())
+	resumed.RunSavePath = path
+	if err := resumed.ContinueSavedRun(); err != nil {
+		t.Fatalf("expected saved run to continue: %v", err)
+	}
+	if HasRunSave(path) {
+		t.Fatalf("expected the save to be consumed once resumed")
+	}
+	again := NewGame(settings.Default())
+	again.RunSavePath = path
+	if err := again.ContinueSavedRun(); err == nil {
+		t.Fatalf("expected a second continue from the same save to fail")
+	}
+}
+
+func rewardOptionIDs(items []*gamedata.Item) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestLoadRunSaveRejectsUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "savegame.json")
+	if err := os.WriteFile(path, []byte(`{"version":7}`), 0o644); err != nil {
+		t.Fatalf("failed to write save fixture: %v", err)
+	}
+	if _, err := LoadRunSave(path); err == nil {
+		t.Fatalf("expected unknown save version to be rejected")
+	}
+}
@@ -4,12 +4,11 @@ import (
 	"sort"
 	"strings"
 
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 	"singlefantasy/app/systems"
 	"singlefantasy/app/world"
-
-	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 type RuntimeContext struct {
@@ -21,6 +20,7 @@ type RuntimeContext struct {
 	Dungeon     **world.Dungeon
 	CurrentRoom **world.Room
 	Camera      **systems.Camera
+	RNG         *core.RunRNG
 	Input       *systems.Input
 	IsMenuOpen  bool
 }
@@ -35,6 +35,7 @@ func NewRuntimeContext(game *Game) *RuntimeContext {
 		Dungeon:     &game.Dungeon,
 		CurrentRoom: &game.CurrentRoom,
 		Camera:      &game.Camera,
+		RNG:         game.RNG,
 	}
 }
 
@@ -44,11 +45,12 @@ type RuntimeSystem interface {
 }
 
 type RuntimePipeline struct {
-	systems []RuntimeSystem
+	systems  []RuntimeSystem
+	profiler *PipelineProfiler
 }
 
 func NewRuntimePipeline() *RuntimePipeline {
-	return &RuntimePipeline{
+	pipeline := &RuntimePipeline{
 		systems: []RuntimeSystem{
 			// Required fixed runtime order: Input -> AI -> Casting -> Projectiles -> Movement -> Combat Resolve -> Effects -> Dungeon/Run -> UI/Render Prep.
 			&inputSystem{},
@@ -62,20 +64,33 @@ func NewRuntimePipeline() *RuntimePipeline {
 			&uiRenderPrepSystem{},
 		},
 	}
+	pipeline.profiler = NewPipelineProfiler(pipeline.SystemNames(), ProfilerWindowSize)
+	return pipeline
 }
 
 func (p *RuntimePipeline) Update(ctx *RuntimeContext, dt float32) {
-	for _, system := range p.systems {
+	for i, system := range p.systems {
+		start := p.profiler.now()
 		system.Update(ctx, dt)
+		p.profiler.Record(i, p.profiler.now().Sub(start))
 	}
+	p.profiler.EndStep()
 }
 
-func (p *RuntimePipeline) OrderString() string {
-	parts := make([]string, 0, len(p.systems))
+func (p *RuntimePipeline) Profiler() *PipelineProfiler {
+	return p.profiler
+}
+
+func (p *RuntimePipeline) SystemNames() []string {
+	names := make([]string, 0, len(p.systems))
 	for _, system := range p.systems {
-		parts = append(parts, system.Name())
+		names = append(names, system.Name())
 	}
-	return strings.Join(parts, " -> ")
+	return names
+}
+
+func (p *RuntimePipeline) OrderString() string {
+	return strings.Join(p.SystemNames(), " -> ")
 }
 
 type inputSystem struct{}
@@ -84,7 +99,7 @@ func (s *inputSystem) Name() string { return "Input" }
 
 func (s *inputSystem) Update(ctx *RuntimeContext, _ float32) {
 	g := ctx.Game
-	ctx.Input = systems.UpdateInput(g.Camera)
+	ctx.Input = g.pollRunInput()
 	ctx.IsMenuOpen = g.IsMenuOpen()
 
 	if g.RoomTransitionTimer > 0 || g.PendingRoomTransition {
@@ -105,25 +120,9 @@ func (s *inputSystem) Update(ctx *RuntimeContext, _ float32) {
 	worldX := ctx.Input.CursorWorldX
 	worldY := ctx.Input.CursorWorldY
 
-	for _, enemy := range g.Enemies {
-		if !enemy.IsAlive() {
-			continue
-		}
-
-		if worldX >= enemy.PosX && worldX <= enemy.PosX+enemy.Hitbox.Width &&
-			worldY >= enemy.PosY && worldY <= enemy.PosY+enemy.Hitbox.Height {
-			g.PlayerAttackTarget = enemy
-			g.HasPlayerMoveTarget = false
-			return
-		}
-	}
-
-	if g.Boss != nil && g.Boss.IsAlive() {
-		if worldX >= g.Boss.PosX && worldX <= g.Boss.PosX+g.Boss.Hitbox.Width &&
-			worldY >= g.Boss.PosY && worldY <= g.Boss.PosY+g.Boss.Hitbox.Height {
-			g.PlayerAttackTarget = g.Boss
-			g.HasPlayerMoveTarget = false
-		}
+	if target := g.combatantAt(worldX, worldY); target != nil {
+		g.PlayerAttackTarget = target
+		g.HasPlayerMoveTarget = false
 	}
 }
 
@@ -137,13 +136,18 @@ func (s *aiSystem) Update(ctx *RuntimeContext, dt float32) {
 		return
 	}
 
+	g.playerCastTimer = max(0, g.playerCastTimer-dt)
 	playerX, playerY := g.Player.Center()
 	for _, enemy := range g.Enemies {
 		if enemy == nil {
 			continue
 		}
 		enemy.Update(dt)
-		gameobjects.ResolveEnemyIntent(enemy, playerX, playerY)
+		targetX, targetY := g.enemyTarget(enemy, playerX, playerY)
+		g.updateEnemySight(enemy, targetX, targetY)
+		gameobjects.ResolveEnemyIntent(enemy, targetX, targetY)
+		g.steerEnemyAlongPath(enemy, targetX, targetY, dt)
+		g.chooseEnemySkill(enemy, targetX, targetY)
 	}
 
 	if g.Boss == nil {
@@ -205,14 +209,19 @@ func (s *projectilesSystem) updateBossProjectiles(g *Game, dt float32) {
 			continue
 		}
 
-		proj.X += proj.VX * dt
-		proj.Y += proj.VY * dt
+		var hitTerrain bool
+		proj.X, proj.Y, hitTerrain = g.sweepTerrain(proj.X, proj.Y, proj.X+proj.VX*dt, proj.Y+proj.VY*dt, proj.Radius)
 
 		playerCenterX, playerCenterY := g.Player.Center()
 		distance := systems.GetDistance(proj.X, proj.Y, playerCenterX, playerCenterY)
 		if distance <= proj.Radius+g.Player.Hitbox.Width/2 {
-			g.ApplyPlayerDirectHit(proj.Damage, proj.X, proj.Y)
+			g.ApplyPlayerCombatHit(proj.Damage, proj.DamageType, proj.X, proj.Y, proj.Effects)
 			proj.Alive = false
+		} else if g.projectileBlockedByProp(proj.X, proj.Y, proj.Radius) {
+			proj.Alive = false
+		} else if hitTerrain {
+			proj.Alive = false
+			g.spawnTerrainImpact(proj.X, proj.Y, nil)
 		}
 
 		if g.CurrentRoom != nil {
@@ -239,10 +248,20 @@ func (s *projectilesSystem) updatePlayerProjectiles(g *Game, dt float32) {
 			continue
 		}
 
-		proj.X += proj.VX * dt
-		proj.Y += proj.VY * dt
+		nextX := proj.X + proj.VX*dt
+		nextY := proj.Y + proj.VY*dt
+		hitTerrain := false
+		if skillPiercesTerrain(proj.Skill) {
+			proj.X, proj.Y = nextX, nextY
+		} else {
+			proj.X, proj.Y, hitTerrain = g.sweepTerrain(proj.X, proj.Y, nextX, nextY, proj.Radius)
+		}
 
 		s.resolvePlayerProjectileHits(g, proj)
+		if hitTerrain && proj.Alive {
+			proj.Alive = false
+			g.spawnTerrainImpact(proj.X, proj.Y, proj.Skill)
+		}
 
 		if g.CurrentRoom != nil {
 			if proj.X < g.CurrentRoom.X || proj.X > g.CurrentRoom.X+g.CurrentRoom.Width ||
@@ -272,14 +291,19 @@ func (s *projectilesSystem) updateEnemyProjectiles(g *Game, dt float32) {
 			continue
 		}
 
-		proj.X += proj.VX * dt
-		proj.Y += proj.VY * dt
+		var hitTerrain bool
+		proj.X, proj.Y, hitTerrain = g.sweepTerrain(proj.X, proj.Y, proj.X+proj.VX*dt, proj.Y+proj.VY*dt, proj.Radius)
 
 		playerCenterX, playerCenterY := g.Player.Center()
 		distance := systems.GetDistance(proj.X, proj.Y, playerCenterX, playerCenterY)
 		if distance <= proj.Radius+g.Player.Hitbox.Width/2 {
 			g.ApplyPlayerCombatHit(proj.Damage, proj.DamageType, proj.X, proj.Y, proj.Effects)
 			proj.Alive = false
+		} else if g.projectileBlockedByProp(proj.X, proj.Y, proj.Radius) {
+			proj.Alive = false
+		} else if hitTerrain {
+			proj.Alive = false
+			g.spawnTerrainImpact(proj.X, proj.Y, nil)
 		}
 
 		if g.CurrentRoom != nil {
@@ -296,19 +320,42 @@ func (s *projectilesSystem) updateEnemyProjectiles(g *Game, dt float32) {
 }
 
 func (s *projectilesSystem) resolvePlayerProjectileHits(g *Game, proj *Projectile) {
-	for _, enemy := range g.Enemies {
-		if enemy == nil || !enemy.IsAlive() || !proj.Alive {
+	space := g.combatSpace()
+	for _, target := range space.CombatantsInCircle(proj.X, proj.Y, proj.Radius+space.MaxHalfExtent()) {
+		if !proj.Alive {
+			return
+		}
+		if !target.IsAlive() {
 			continue
 		}
-		if s.tryHitEnemy(g, proj, enemy) {
-			return
+		switch t := target.(type) {
+		case *gameobjects.Boss:
+			s.tryHitBoss(g, proj, t)
+		case *gameobjects.Enemy:
+			if s.tryHitEnemy(g, proj, t) {
+				return
+			}
+		case *gameobjects.Prop:
+			if s.tryHitProp(g, proj, t) {
+				return
+			}
 		}
 	}
+}
 
-	if g.Boss == nil || !g.Boss.IsAlive() || !proj.Alive {
-		return
+// tryHitProp always stops the projectile: props block shots even when they pierce enemies.
+func (s *projectilesSystem) tryHitProp(g *Game, proj *Projectile, prop *gameobjects.Prop) bool {
+	x, y, width, height := prop.GetBounds()
+	radius := proj.Radius
+	if !systems.AABBOverlap(world.AABB{X: proj.X - radius, Y: proj.Y - radius, Width: radius * 2, Height: radius * 2}, world.AABB{X: x, Y: y, Width: width, Height: height}) {
+		return false
 	}
-	s.tryHitBoss(g, proj, g.Boss)
+
+	s.applyProjectileHit(g, proj, prop)
+	centerX, centerY := prop.Center()
+	g.publish(CombatEvent{Type: EventSkillImpact, Source: casterCombatant(proj.Caster), Target: prop, Skill: proj.Skill, X: centerX, Y: centerY})
+	proj.Alive = false
+	return true
 }
 
 func (s *projectilesSystem) tryHitEnemy(g *Game, proj *Projectile, enemy *gameobjects.Enemy) bool {
@@ -325,13 +372,9 @@ func (s *projectilesSystem) tryHitEnemy(g *Game, proj *Projectile, enemy *gameob
 	wasAlive := enemy.IsAlive()
 	s.applyProjectileHit(g, proj, enemy)
 	markProjectileTargetHit(proj, enemy)
-	g.spawnSkillImpactVisual(proj.Skill, enemyX, enemyY)
+	g.publish(CombatEvent{Type: EventSkillImpact, Source: casterCombatant(proj.Caster), Target: enemy, Skill: proj.Skill, X: enemyX, Y: enemyY})
 	if wasAlive && !enemy.IsAlive() {
-		reward := enemy.XPReward
-		if reward <= 0 {
-			reward = 20
-		}
-		g.grantPlayerXP(reward)
+		g.grantKillXP(enemy)
 		if g.Player.Class.Type == gamedata.ClassTypeRanged {
 			g.healPlayerWithFeedback(g.Player.Class.KillHealAmount)
 		}
@@ -359,9 +402,9 @@ func (s *projectilesSystem) tryHitBoss(g *Game, proj *Projectile, boss *gameobje
 	wasAlive := boss.IsAlive()
 	s.applyProjectileHit(g, proj, boss)
 	markProjectileTargetHit(proj, boss)
-	g.spawnSkillImpactVisual(proj.Skill, bossX, bossY)
+	g.publish(CombatEvent{Type: EventSkillImpact, Source: casterCombatant(proj.Caster), Target: boss, Skill: proj.Skill, X: bossX, Y: bossY})
 	if wasAlive && !boss.IsAlive() {
-		g.grantPlayerXP(100)
+		g.grantKillXP(boss)
 		if g.Player.Class.Type == gamedata.ClassTypeRanged {
 			g.healPlayerWithFeedback(g.Player.Class.KillHealAmount * 5)
 		}
@@ -375,12 +418,13 @@ func (s *projectilesSystem) tryHitBoss(g *Game, proj *Projectile, boss *gameobje
 	return true
 }
 
-func (s *projectilesSystem) applyProjectileHit(g *Game, proj *Projectile, target interface{}) {
+func (s *projectilesSystem) applyProjectileHit(g *Game, proj *Projectile, target core.Combatant) {
 	if g == nil || proj == nil || target == nil {
 		return
 	}
 	if proj.Skill != nil && proj.Caster != nil {
-		g.applySkillWithFeedback(proj.Caster, proj.Skill, []interface{}{target})
+		g.applySkillWithFeedback(proj.Caster, proj.Skill, []core.Combatant{target})
+		g.applySkillKnockback(proj.Skill, proj.X-proj.VX, proj.Y-proj.VY, []core.Combatant{target})
 		return
 	}
 
@@ -389,8 +433,7 @@ func (s *projectilesSystem) applyProjectileHit(g *Game, proj *Projectile, target
 		Target:             target,
 		BaseDamage:         proj.Damage,
 		DamageType:         proj.DamageType,
-		CritMultiplier:     1.5,
-		ApplyOnHitHooks:    proj.Caster != nil,
+		ApplyOnHitHooks:    proj.Caster != nil && !proj.FromItemProc,
 		UseSourceModifiers: false,
 	})
 }
@@ -412,7 +455,7 @@ func (s *projectilesSystem) updateDelayedSkillEffects(g *Game, dt float32) {
 
 			targetsHit := s.applyDelayedSkill(g, delayed)
 			if targetsHit > 0 {
-				g.spawnSkillImpactVisual(delayed.Skill, delayed.LastAppliedX, delayed.LastAppliedY)
+				g.publish(CombatEvent{Type: EventSkillImpact, Source: casterCombatant(delayed.Caster), Skill: delayed.Skill, X: delayed.LastAppliedX, Y: delayed.LastAppliedY})
 			}
 
 			if delayed.ActiveTime <= 0 || delayed.TickRate <= 0 {
@@ -434,7 +477,7 @@ func (s *projectilesSystem) updateDelayedSkillEffects(g *Game, dt float32) {
 			delayed.TickTimer -= delayed.TickRate
 			targetsHit := s.applyDelayedSkill(g, delayed)
 			if targetsHit > 0 {
-				g.spawnSkillImpactVisual(delayed.Skill, delayed.LastAppliedX, delayed.LastAppliedY)
+				g.publish(CombatEvent{Type: EventSkillImpact, Source: casterCombatant(delayed.Caster), Skill: delayed.Skill, X: delayed.LastAppliedX, Y: delayed.LastAppliedY})
 			}
 		}
 
@@ -446,20 +489,27 @@ func (s *projectilesSystem) updateDelayedSkillEffects(g *Game, dt float32) {
 }
 
 func (s *projectilesSystem) applyDelayedSkill(g *Game, delayed *DelayedSkillEffect) int {
-	if g == nil || delayed == nil || delayed.Skill == nil || delayed.Caster == nil {
+	if g == nil || delayed == nil || delayed.Skill == nil {
+		return 0
+	}
+	if delayed.EnemyCaster != nil {
+		return g.applyEnemyDelayedSkill(delayed)
+	}
+	if delayed.Caster == nil {
 		return 0
 	}
 	targets := s.resolveDelayedTargets(g, delayed)
 	g.applySkillWithFeedback(delayed.Caster, delayed.Skill, targets)
+	g.applySkillKnockback(delayed.Skill, delayed.X, delayed.Y, targets)
 	g.applySkillPostCast(delayed.Skill, len(targets))
 	delayed.LastAppliedX = delayed.X
 	delayed.LastAppliedY = delayed.Y
 	return len(targets)
 }
 
-func (s *projectilesSystem) resolveDelayedTargets(g *Game, delayed *DelayedSkillEffect) []interface{} {
+func (s *projectilesSystem) resolveDelayedTargets(g *Game, delayed *DelayedSkillEffect) []core.Combatant {
 	if delayed.Skill.Targeting.Type != gamedata.TargetArea {
-		return systems.ResolveTargets(delayed.Caster, delayed.Intent, delayed.Skill.Targeting, g.Enemies, g.Boss)
+		return g.resolveSkillTargets(delayed.Caster, delayed.Skill, delayed.Intent)
 	}
 
 	radius := delayed.Skill.Targeting.Radius
@@ -471,7 +521,7 @@ func (s *projectilesSystem) resolveDelayedTargets(g *Game, delayed *DelayedSkill
 	}
 
 	type candidate struct {
-		target    interface{}
+		target    core.Combatant
 		distance2 float32
 		order     int
 	}
@@ -480,35 +530,18 @@ func (s *projectilesSystem) resolveDelayedTargets(g *Game, delayed *DelayedSkill
 	centerY := delayed.Y
 	radius2 := radius * radius
 	candidates := make([]candidate, 0, len(g.Enemies)+1)
-	order := 0
 
-	for _, enemy := range g.Enemies {
-		if enemy == nil || !enemy.IsAlive() {
-			order++
+	for order, combatant := range g.combatSpace().CombatantsInCircle(centerX, centerY, radius) {
+		if !combatant.IsAlive() {
 			continue
 		}
-		targetX, targetY := enemy.Center()
+		targetX, targetY := combatant.Center()
 		dx := targetX - centerX
 		dy := targetY - centerY
 		distance2 := dx*dx + dy*dy
-		if distance2 <= radius2 {
+		if distance2 <= radius2 && !g.terrainOccludes(delayed.Skill, centerX, centerY, combatant) {
 			candidates = append(candidates, candidate{
-				target:    enemy,
-				distance2: distance2,
-				order:     order,
-			})
-		}
-		order++
-	}
-
-	if g.Boss != nil && g.Boss.IsAlive() {
-		targetX, targetY := g.Boss.Center()
-		dx := targetX - centerX
-		dy := targetY - centerY
-		distance2 := dx*dx + dy*dy
-		if distance2 <= radius2 {
-			candidates = append(candidates, candidate{
-				target:    g.Boss,
+				target:    combatant,
 				distance2: distance2,
 				order:     order,
 			})
@@ -528,21 +561,21 @@ func (s *projectilesSystem) resolveDelayedTargets(g *Game, delayed *DelayedSkill
 		limit = maxTargets
 	}
 
-	targets := make([]interface{}, 0, limit)
+	targets := make([]core.Combatant, 0, limit)
 	for i := 0; i < limit; i++ {
 		targets = append(targets, candidates[i].target)
 	}
 	return targets
 }
 
-func markProjectileTargetHit(proj *Projectile, target interface{}) {
+func markProjectileTargetHit(proj *Projectile, target core.Combatant) {
 	if proj.HitTargets == nil {
-		proj.HitTargets = map[interface{}]struct{}{}
+		proj.HitTargets = map[core.Combatant]struct{}{}
 	}
 	proj.HitTargets[target] = struct{}{}
 }
 
-func wasTargetHitByProjectile(proj *Projectile, target interface{}) bool {
+func wasTargetHitByProjectile(proj *Projectile, target core.Combatant) bool {
 	if proj.HitTargets == nil {
 		return false
 	}
@@ -579,8 +612,13 @@ func (s *movementSystem) Update(ctx *RuntimeContext, dt float32) {
 			if distance < PlayerMoveTargetSlowRadius {
 				moveSpeed *= distance / PlayerMoveTargetSlowRadius
 			}
-			desiredVelX = (dx / distance) * moveSpeed
-			desiredVelY = (dy / distance) * moveSpeed
+			waypointX, waypointY := g.navWaypoint(g.Player, g.PlayerMoveTargetX, g.PlayerMoveTargetY, dt)
+			steerX := waypointX - playerCenterX
+			steerY := waypointY - playerCenterY
+			if steerDistance := systems.GetDistance(0, 0, steerX, steerY); steerDistance > 0.0001 {
+				desiredVelX = (steerX / steerDistance) * moveSpeed
+				desiredVelY = (steerY / steerDistance) * moveSpeed
+			}
 		} else {
 			g.HasPlayerMoveTarget = false
 		}
@@ -606,7 +644,7 @@ func (s *movementSystem) Update(ctx *RuntimeContext, dt float32) {
 
 	startX := g.Player.PosX
 	startY := g.Player.PosY
-	newX, newY := systems.ResolvePlayerMovement(
+	newX, newY := systems.ResolvePlayerMovementInSpace(
 		startX,
 		startY,
 		g.Player.Hitbox.Width,
@@ -614,6 +652,7 @@ func (s *movementSystem) Update(ctx *RuntimeContext, dt float32) {
 		moveDeltaX,
 		moveDeltaY,
 		g.CurrentRoom,
+		g.combatSpace(),
 	)
 
 	appliedDeltaX := newX - startX
@@ -626,6 +665,7 @@ func (s *movementSystem) Update(ctx *RuntimeContext, dt float32) {
 
 	s.updateEnemies(g, dt)
 	s.updateBoss(g, dt)
+	g.invalidateCombatSpace()
 }
 
 func (s *movementSystem) updatePlayerFacing(g *Game, horizontalVelocity float32) {
@@ -655,54 +695,49 @@ func (s *movementSystem) updatePlayerFacing(g *Game, horizontalVelocity float32)
 }
 
 func (s *movementSystem) updateEnemies(g *Game, dt float32) {
-	for _, enemy := range g.Enemies {
+	space := g.combatSpace()
+	separation := g.enemySeparation(space)
+	for i, enemy := range g.Enemies {
 		if enemy == nil || !enemy.IsAlive() {
 			continue
 		}
-		if !gamedata.CanAct(&enemy.Effects) {
-			continue
-		}
 
-		speed := enemy.MoveSpeed * gamedata.MoveSpeedMultiplier(&enemy.Effects)
-		if speed <= 0 {
-			continue
+		enemy.KnockbackVelX = decayAxisVelocity(enemy.KnockbackVelX, EnemyKnockbackDecayPerSecond, dt)
+		enemy.KnockbackVelY = decayAxisVelocity(enemy.KnockbackVelY, EnemyKnockbackDecayPerSecond, dt)
+		velX := enemy.KnockbackVelX
+		velY := enemy.KnockbackVelY
+		if gamedata.CanAct(&enemy.Effects) {
+			if speed := enemy.MoveSpeed * gamedata.MoveSpeedMultiplier(&enemy.Effects); speed > 0 {
+				velX += enemy.IntentMoveX*speed + separation[i].X
+				velY += enemy.IntentMoveY*speed + separation[i].Y
+			}
 		}
 
-		moveDeltaX := enemy.IntentMoveX * speed * dt
-		moveDeltaY := enemy.IntentMoveY * speed * dt
+		moveDeltaX := velX * dt
+		moveDeltaY := velY * dt
 		if moveDeltaX == 0 && moveDeltaY == 0 {
 			continue
 		}
-
-		nextX := enemy.PosX + moveDeltaX
-		nextY := enemy.PosY + moveDeltaY
-		if g.CurrentRoom != nil {
-			minX := g.CurrentRoom.X
-			minY := g.CurrentRoom.Y
-			maxX := g.CurrentRoom.X + g.CurrentRoom.Width - enemy.Hitbox.Width
-			maxY := g.CurrentRoom.Y + g.CurrentRoom.Height - enemy.Hitbox.Height
-			if nextX < minX {
-				nextX = minX
-			}
-			if nextX > maxX {
-				nextX = maxX
-			}
-			if nextY < minY {
-				nextY = minY
-			}
-			if nextY > maxY {
-				nextY = maxY
-			}
-
-			candidate := world.AABB{X: nextX, Y: nextY, Width: enemy.Hitbox.Width, Height: enemy.Hitbox.Height}
-			if overlapsRoomObstacle(candidate, g.CurrentRoom.Obstacles) {
-				nextX = enemy.PosX
-				nextY = enemy.PosY
-			}
+		if g.CurrentRoom == nil {
+			enemy.PosX += moveDeltaX
+			enemy.PosY += moveDeltaY
+			continue
 		}
 
-		enemy.PosX = nextX
-		enemy.PosY = nextY
+		newX, newY := systems.ResolveEnemyMovementInSpace(
+			enemy.PosX,
+			enemy.PosY,
+			enemy.Hitbox.Width,
+			enemy.Hitbox.Height,
+			moveDeltaX,
+			moveDeltaY,
+			g.CurrentRoom,
+			space,
+		)
+		_, enemy.KnockbackVelX = dampBlockedAxis(0, enemy.KnockbackVelX, moveDeltaX, newX-enemy.PosX)
+		_, enemy.KnockbackVelY = dampBlockedAxis(0, enemy.KnockbackVelY, moveDeltaY, newY-enemy.PosY)
+		enemy.PosX = newX
+		enemy.PosY = newY
 	}
 }
 
@@ -749,7 +784,8 @@ func (s *movementSystem) updateBoss(g *Game, dt float32) {
 		candidate := world.AABB{X: nextX, Y: nextY, Width: g.Boss.Hitbox.Width, Height: g.Boss.Hitbox.Height}
 		// If the boss is already intersecting an obstacle, allow movement so it can
 		// escape instead of being permanently pinned.
-		if overlapsRoomObstacle(candidate, g.CurrentRoom.Obstacles) && !overlapsRoomObstacle(current, g.CurrentRoom.Obstacles) {
+		space := g.combatSpace()
+		if space.OverlapsObstacle(candidate) && !space.OverlapsObstacle(current) {
 			nextX = g.Boss.PosX
 			nextY = g.Boss.PosY
 		}
@@ -759,15 +795,6 @@ func (s *movementSystem) updateBoss(g *Game, dt float32) {
 	g.Boss.PosY = nextY
 }
 
-func overlapsRoomObstacle(candidate world.AABB, obstacles []world.AABB) bool {
-	for _, obstacle := range obstacles {
-		if systems.AABBOverlap(candidate, obstacle) {
-			return true
-		}
-	}
-	return false
-}
-
 type combatResolveSystem struct{}
 
 func (s *combatResolveSystem) Name() string { return "Combat Resolve" }
@@ -783,6 +810,17 @@ func (s *combatResolveSystem) Update(ctx *RuntimeContext, _ float32) {
 		if enemy == nil {
 			continue
 		}
+		if enemy.PendingSkill != nil {
+			g.castEnemySkill(enemy, enemy.PendingSkill)
+			continue
+		}
+		if g.enemyTargetsTotem(enemy) {
+			totem := g.RoomEvent.Totem
+			if hit, payload := enemy.Attack(totem.X, totem.Y); hit {
+				g.damageEventTotem(payload.Damage)
+			}
+			continue
+		}
 		hit, payload := enemy.Attack(playerX, playerY)
 		if !hit {
 			continue
@@ -820,9 +858,15 @@ func (s *combatResolveSystem) Update(ctx *RuntimeContext, _ float32) {
 				DamageType: payload.DamageType,
 				Effects:    payload.OnHitEffects,
 			})
-			g.playSound(sfxEnemyCast)
+			g.publish(CombatEvent{Type: EventEnemyCast, Source: enemy, X: payload.SourceX, Y: payload.SourceY})
 			continue
 		}
+		if payload.AttackMode == gamedata.EnemyAttackCasterAOE {
+			if impactX, impactY, blocked := g.sweepTerrain(payload.SourceX, payload.SourceY, playerX, playerY, 0); blocked {
+				g.spawnTerrainImpact(impactX, impactY, nil)
+				continue
+			}
+		}
 
 		g.ApplyPlayerCombatHit(payload.Damage, payload.DamageType, payload.SourceX, payload.SourceY, payload.OnHitEffects)
 	}
@@ -840,6 +884,7 @@ func (s *combatResolveSystem) Update(ctx *RuntimeContext, _ float32) {
 			}
 			g.ApplyPlayerCombatHit(event.Damage, event.DamageType, event.X, event.Y, event.Effects)
 		}
+		g.spawnBossAddWaves(g.Boss.ConsumeAddWaves())
 	}
 }
 
@@ -871,6 +916,9 @@ func (s *effectsSystem) Update(ctx *RuntimeContext, dt float32) {
 
 	g.RunElapsed += dt
 	g.Player.Update(dt)
+	systems.TriggerPeriodicItemEffects(g.Player, g.RunElapsed, dt, g.itemProcContext())
+	g.updateRoomHazards(dt)
+	g.updateProps(dt)
 }
 
 type dungeonRunSystem struct{}
@@ -900,14 +948,17 @@ func (s *dungeonRunSystem) Update(ctx *RuntimeContext, dt float32) {
 	}
 
 	if g.CurrentRoom != nil {
-		if g.CurrentRoom.Type == world.RoomTypeEvent && !g.CurrentRoom.Completed {
-			g.CurrentRoom.EventTimeLeft -= dt
-			if g.CurrentRoom.EventTimeLeft < 0 {
-				g.CurrentRoom.EventTimeLeft = 0
-			}
+		input := ctx.Input
+		if ctx.IsMenuOpen {
+			input = nil
 		}
+		g.updateRoomEvent(input, dt)
 
+		wasCompleted := g.CurrentRoom.Completed
 		roomCleared := g.CheckRoomCompletion()
+		if roomCleared && !wasCompleted {
+			g.publish(CombatEvent{Type: EventRoomCleared, RoomIndex: g.currentRoomIndex()})
+		}
 		if g.CurrentRoom.IsBoss() && roomCleared {
 			g.EnterBossReward()
 			return
@@ -921,7 +972,7 @@ func (s *dungeonRunSystem) Update(ctx *RuntimeContext, dt float32) {
 			hadLockedDoor := roomHasLockedDoor(g.CurrentRoom)
 			g.CurrentRoom.SetDoorsLocked(!roomCleared)
 			if roomCleared && hadLockedDoor && roomHasUnlockedDoor(g.CurrentRoom) {
-				g.playSound(sfxDoorOpen)
+				g.publish(CombatEvent{Type: EventDoorOpened, RoomIndex: g.currentRoomIndex()})
 			}
 		}
 
@@ -992,23 +1043,10 @@ func (s *uiRenderPrepSystem) Update(ctx *RuntimeContext, _ float32) {
 	}
 
 	if g.LevelUpMenu {
-		if rl.IsKeyPressed(rl.KeyOne) {
-			g.Player.AddStatPoint(gamedata.StatTypeSTR)
-		}
-		if rl.IsKeyPressed(rl.KeyTwo) {
-			g.Player.AddStatPoint(gamedata.StatTypeAGI)
-		}
-		if rl.IsKeyPressed(rl.KeyThree) {
-			g.Player.AddStatPoint(gamedata.StatTypeVIT)
-		}
-		if rl.IsKeyPressed(rl.KeyFour) {
-			g.Player.AddStatPoint(gamedata.StatTypeINT)
-		}
-		if rl.IsKeyPressed(rl.KeyFive) {
-			g.Player.AddStatPoint(gamedata.StatTypeDEX)
-		}
-		if rl.IsKeyPressed(rl.KeySix) {
-			g.Player.AddStatPoint(gamedata.StatTypeLUK)
+		if ctx.Input != nil {
+			for _, stat := range ctx.Input.StatAllocations {
+				g.Player.AddStatPoint(stat)
+			}
 		}
 		if g.Player.StatPoints == 0 {
 			g.LevelUpMenu = false
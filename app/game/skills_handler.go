@@ -1,6 +1,7 @@
 package game
 
 import (
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/systems"
 )
@@ -14,12 +15,18 @@ func (g *Game) TryCastSkill(skill *gamedata.Skill, input *systems.Input) {
 	}
 
 	intent := g.buildCastIntent(input)
+	if g.skillTargetsHiddenByTerrain(skill, intent) {
+		x, y := g.Player.Center()
+		g.addCombatTextEvent(x, y-g.Player.Hitbox.Height, "No line of sight", CombatTextStatus, combatStatusColor, CombatFeedbackStatusDuration, CombatFeedbackBaseScale, false)
+		return
+	}
 	if !g.executeSkillDelivery(skill, intent) {
 		return
 	}
-	g.spawnSkillCastVisual(skill, intent)
-	g.playSkillCastSFX(skill)
+	g.publish(CombatEvent{Type: EventSkillCast, Source: g.Player, Skill: skill, Intent: intent})
+	systems.TriggerItemEffects(g.Player, gamedata.ItemTriggerOnSkillCast, g.itemProcContext())
 	skill.Use()
+	g.playerCastTimer = EnemyCastReactionWindow
 }
 
 func (g *Game) buildCastIntent(input *systems.Input) systems.CastIntent {
@@ -48,12 +55,20 @@ func (g *Game) executeSkillDelivery(skill *gamedata.Skill, intent systems.CastIn
 
 func (g *Game) resolveAndApplySkill(skill *gamedata.Skill, intent systems.CastIntent) int {
 	g.applySkillPreCast(skill, intent)
-	targets := systems.ResolveTargets(g.Player, intent, skill.Targeting, g.Enemies, g.Boss)
+	if skill.Targeting.Type == gamedata.TargetArea && skill.Targeting.Range > 0 {
+		intent.CursorX, intent.CursorY = g.resolveAreaCenter(skill, intent)
+	}
+	targets := g.resolveSkillTargets(g.Player, skill, intent)
 	g.applySkillWithFeedback(g.Player, skill, targets)
+	knockbackX, knockbackY := g.Player.Center()
+	if skill.Targeting.Type == gamedata.TargetArea && skill.Targeting.Range > 0 {
+		knockbackX, knockbackY = intent.CursorX, intent.CursorY
+	}
+	g.applySkillKnockback(skill, knockbackX, knockbackY, targets)
 	g.applySkillPostCast(skill, len(targets))
 	if len(targets) > 0 {
 		impactX, impactY := resolveImpactCenter(intent, targets[0])
-		g.spawnSkillImpactVisual(skill, impactX, impactY)
+		g.publish(CombatEvent{Type: EventSkillImpact, Source: g.Player, Skill: skill, X: impactX, Y: impactY})
 	}
 	return len(targets)
 }
@@ -153,7 +168,7 @@ func (g *Game) spawnSkillProjectile(skill *gamedata.Skill, intent systems.CastIn
 		Radius:     radius,
 		Lifetime:   lifetime,
 		Pierce:     skill.Delivery.Pierce,
-		HitTargets: map[interface{}]struct{}{},
+		HitTargets: map[core.Combatant]struct{}{},
 		Alive:      true,
 		Skill:      skill,
 		Caster:     g.Player,
@@ -171,7 +186,7 @@ func (g *Game) queueDelayedSkill(skill *gamedata.Skill, intent systems.CastInten
 		delay = 0.1
 	}
 
-	centerX, centerY := g.resolveDelayedCenter(skill, intent)
+	centerX, centerY := g.resolveAreaCenter(skill, intent)
 	updatedIntent := intent
 	updatedIntent.CursorX = centerX
 	updatedIntent.CursorY = centerY
@@ -200,35 +215,30 @@ func (g *Game) queueDelayedSkill(skill *gamedata.Skill, intent systems.CastInten
 	return true
 }
 
-func (g *Game) resolveDelayedCenter(skill *gamedata.Skill, intent systems.CastIntent) (float32, float32) {
+// resolveAreaCenter clamps the cursor to the skill's range, then back to the first obstacle in the way unless the skill pierces terrain.
+func (g *Game) resolveAreaCenter(skill *gamedata.Skill, intent systems.CastIntent) (float32, float32) {
 	casterX, casterY := g.Player.Center()
 	centerX := intent.CursorX
 	centerY := intent.CursorY
 
 	if skill.Targeting.Type == gamedata.TargetArea && skill.Targeting.Range == 0 {
-		centerX = casterX
-		centerY = casterY
-	}
-	if skill.Targeting.Range <= 0 {
-		return centerX, centerY
+		return casterX, casterY
 	}
 
 	dx := centerX - casterX
 	dy := centerY - casterY
 	distance := systems.GetDistance(0, 0, dx, dy)
-	if distance <= skill.Targeting.Range || distance <= 0 {
-		return centerX, centerY
+	if skill.Targeting.Range > 0 && distance > skill.Targeting.Range && distance > 0 {
+		ratio := skill.Targeting.Range / distance
+		centerX = casterX + dx*ratio
+		centerY = casterY + dy*ratio
 	}
-
-	ratio := skill.Targeting.Range / distance
-	return casterX + dx*ratio, casterY + dy*ratio
+	return g.clampAreaCenterToTerrain(skill, centerX, centerY)
 }
 
-func resolveImpactCenter(intent systems.CastIntent, target interface{}) (float32, float32) {
-	switch t := target.(type) {
-	case interface{ Center() (float32, float32) }:
-		return t.Center()
-	default:
+func resolveImpactCenter(intent systems.CastIntent, target core.Combatant) (float32, float32) {
+	if target == nil {
 		return intent.CursorX, intent.CursorY
 	}
+	return target.Center()
 }
@@ -0,0 +1,60 @@
+package game
+
+import "fmt"
+
+type RunTelemetry struct {
+	DamageDealt    int
+	DamageTaken    int
+	CriticalHits   int
+	HealingDone    int
+	EffectsApplied int
+	Kills          int
+	LevelUps       int
+	SkillCasts     int
+	RoomsCleared   int
+	RewardsPicked  int
+}
+
+func (t *RunTelemetry) Record(event CombatEvent) {
+	if t == nil {
+		return
+	}
+
+	switch event.Type {
+	case EventDamageDealt:
+		if isPlayerTarget(event.Target) {
+			t.DamageTaken += event.Amount
+			return
+		}
+		t.DamageDealt += event.Amount
+		if event.IsCrit {
+			t.CriticalHits++
+		}
+	case EventHealed:
+		t.HealingDone += event.Amount
+	case EventEffectApplied:
+		t.EffectsApplied += event.Amount
+	case EventEntityKilled:
+		if !isPlayerTarget(event.Target) && !isPropTarget(event.Target) {
+			t.Kills++
+		}
+	case EventLevelUp:
+		t.LevelUps += event.Amount
+	case EventSkillCast:
+		t.SkillCasts++
+	case EventRoomCleared:
+		t.RoomsCleared++
+	case EventRewardPicked:
+		t.RewardsPicked++
+	}
+}
+
+func (t RunTelemetry) DebugLine() string {
+	return fmt.Sprintf("Telemetry: dmg %d/%d crits %d heal %d kills %d rooms %d", t.DamageDealt, t.DamageTaken, t.CriticalHits, t.HealingDone, t.Kills, t.RoomsCleared)
+}
+
+func (g *Game) subscribeTelemetry(bus *CombatEventBus) {
+	bus.SubscribeAll(func(event CombatEvent) {
+		g.Telemetry.Record(event)
+	})
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems"
+)
+
+const (
+	TerrainImpactVisualRadius = 12
+	TerrainImpactVisualLength = 0.2
+)
+
+func skillPiercesTerrain(skill *gamedata.Skill) bool {
+	return skill != nil && skill.Delivery.PierceTerrain
+}
+
+// sweepTerrain returns where a circle moving from (x0,y0) to (x1,y1) first touches a room obstacle, or the end point when nothing is in the way.
+func (g *Game) sweepTerrain(x0, y0, x1, y1, radius float32) (float32, float32, bool) {
+	fraction, hit := g.combatSpace().SweepTerrain(x0, y0, x1, y1, radius)
+	if !hit {
+		return x1, y1, false
+	}
+	return x0 + (x1-x0)*fraction, y0 + (y1-y0)*fraction, true
+}
+
+// clampAreaCenterToTerrain pulls a ground-targeted area back to the first obstacle between the caster and the chosen point.
+func (g *Game) clampAreaCenterToTerrain(skill *gamedata.Skill, centerX, centerY float32) (float32, float32) {
+	if skillPiercesTerrain(skill) || g.Player == nil {
+		return centerX, centerY
+	}
+	casterX, casterY := g.Player.Center()
+	x, y, _ := g.sweepTerrain(casterX, casterY, centerX, centerY, 0)
+	return x, y
+}
+
+// resolveSkillTargets resolves a player skill's targets, leaving out candidates that terrain hides from the skill's origin.
+func (g *Game) resolveSkillTargets(caster *gameobjects.Player, skill *gamedata.Skill, intent systems.CastIntent) []core.Combatant {
+	if caster == nil {
+		return nil
+	}
+	candidates := g.combatSpace().TargetCandidates(caster, intent, skill.Targeting)
+	if originX, originY, ok := skillTerrainOrigin(caster, skill, intent); ok && !skillPiercesTerrain(skill) {
+		visible := make([]core.Combatant, 0, len(candidates))
+		for _, candidate := range candidates {
+			if candidate == nil || g.terrainOccludes(skill, originX, originY, candidate) {
+				continue
+			}
+			visible = append(visible, candidate)
+		}
+		candidates = visible
+	}
+	return systems.ResolveCombatantTargets(caster, intent, skill.Targeting, candidates)
+}
+
+func (g *Game) hasLineOfSight(x0, y0, x1, y1 float32) bool {
+	return g.combatSpace().HasLineOfSight(x0, y0, x1, y1)
+}
+
+// updateEnemySight flags ranged and caster enemies that cannot see their target so they reposition instead of firing into cover.
+func (g *Game) updateEnemySight(enemy *gameobjects.Enemy, targetX, targetY float32) {
+	enemy.SightBlocked = false
+	if enemy.AttackMode != gamedata.EnemyAttackProjectile && enemy.AttackMode != gamedata.EnemyAttackCasterAOE {
+		return
+	}
+	enemyX, enemyY := enemy.Center()
+	enemy.SightBlocked = !g.hasLineOfSight(enemyX, enemyY, targetX, targetY)
+}
+
+// skillTargetsHiddenByTerrain reports a single-target skill with enemies in range that terrain hides from the caster.
+func (g *Game) skillTargetsHiddenByTerrain(skill *gamedata.Skill, intent systems.CastIntent) bool {
+	if skill.Targeting.Type != gamedata.TargetEnemy || skillPiercesTerrain(skill) {
+		return false
+	}
+	inRange := systems.ResolveCombatantTargets(g.Player, intent, skill.Targeting, g.combatSpace().TargetCandidates(g.Player, intent, skill.Targeting))
+	return len(inRange) > 0 && len(g.resolveSkillTargets(g.Player, skill, intent)) == 0
+}
+
+func (g *Game) terrainOccludes(skill *gamedata.Skill, originX, originY float32, target core.Combatant) bool {
+	if skillPiercesTerrain(skill) {
+		return false
+	}
+	targetX, targetY := target.Center()
+	return !g.hasLineOfSight(originX, originY, targetX, targetY)
+}
+
+// skillTerrainOrigin is the point terrain occlusion is measured from, and false for skills that ignore it.
+func skillTerrainOrigin(caster core.Combatant, skill *gamedata.Skill, intent systems.CastIntent) (float32, float32, bool) {
+	casterX, casterY := caster.Center()
+	switch skill.Targeting.Type {
+	case gamedata.TargetArea:
+		if skill.Targeting.Range == 0 {
+			return casterX, casterY, true
+		}
+		return intent.CursorX, intent.CursorY, true
+	case gamedata.TargetEnemy, gamedata.TargetDirection:
+		return casterX, casterY, true
+	default:
+		return 0, 0, false
+	}
+}
+
+func (g *Game) spawnTerrainImpact(x, y float32, skill *gamedata.Skill) {
+	g.SkillVisualEffects = append(g.SkillVisualEffects, &SkillVisualEffect{
+		X:        x,
+		Y:        y,
+		Radius:   TerrainImpactVisualRadius,
+		Duration: TerrainImpactVisualLength,
+		TimeLeft: TerrainImpactVisualLength,
+		Skill:    skill,
+	})
+	g.publish(CombatEvent{Type: EventProjectileBlocked, Skill: skill, X: x, Y: y, RoomIndex: g.currentRoomIndex()})
+}
@@ -0,0 +1,187 @@
+//go:build raylib
+
+package game
+
+import (
+	"testing"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/settings"
+	"singlefantasy/app/systems"
+	"singlefantasy/app/world"
+)
+
+func newTerrainTestGame() *Game {
+	g := NewGame(settings.Default())
+	g.State = StateRun
+	g.Player = gameobjects.NewPlayer(40, 240, gamedata.ClassTypeRanged)
+	g.CurrentRoom = &world.Room{
+		Width:     800,
+		Height:    600,
+		Obstacles: []world.AABB{{X: 300, Y: 160, Width: 96, Height: 200}},
+	}
+	g.invalidateCombatSpace()
+	g.Events.BeginFrame()
+	return g
+}
+
+func TestPlayerProjectileStopsAtObstacleWithImpact(t *testing.T) {
+	g := newTerrainTestGame()
+	behindWall := gameobjects.NewEnemyFromArchetype(450, 240, gamedata.EnemyArchetypeRaider, false, 0)
+	g.Enemies = []*gameobjects.Enemy{behindWall}
+	g.invalidateCombatSpace()
+	g.Projectiles = []*Projectile{{X: 200, Y: 260, VX: 1000, Radius: 6, Damage: 30, Lifetime: 2, Alive: true, HitTargets: map[core.Combatant]struct{}{}}}
+
+	(&projectilesSystem{}).updatePlayerProjectiles(g, 0.5)
+
+	if len(g.Projectiles) != 0 || behindWall.HP != behindWall.MaxHP {
+		t.Fatalf("expected projectile to die on the wall before reaching the enemy, got %d projectiles hp=%d", len(g.Projectiles), behindWall.HP)
+	}
+	if g.Events.CountFrameEvents(EventProjectileBlocked) != 1 || len(g.SkillVisualEffects) != 1 {
+		t.Fatalf("expected one terrain impact")
+	}
+	if impact := g.SkillVisualEffects[0]; impact.X != 294 || impact.Y != 260 {
+		t.Fatalf("expected impact at the wall face, got (%v,%v)", impact.X, impact.Y)
+	}
+}
+
+func TestTerrainPiercingProjectilePassesObstacles(t *testing.T) {
+	g := newTerrainTestGame()
+	skill := &gamedata.Skill{Delivery: gamedata.DeliverySpec{Type: gamedata.DeliveryProjectile, PierceTerrain: true}}
+	g.Projectiles = []*Projectile{{X: 200, Y: 260, VX: 1000, Radius: 6, Lifetime: 2, Alive: true, Skill: skill, HitTargets: map[core.Combatant]struct{}{}}}
+
+	(&projectilesSystem{}).updatePlayerProjectiles(g, 0.3)
+
+	if len(g.Projectiles) != 1 || g.Projectiles[0].X != 500 {
+		t.Fatalf("expected terrain-piercing projectile to fly through the wall")
+	}
+}
+
+func TestEnemyProjectileAndHexCastBlockedByCover(t *testing.T) {
+	g := newTerrainTestGame()
+	playerHP := g.Player.HP
+	g.EnemyProjectiles = []*EnemyProjectile{{X: 500, Y: 260, VX: -1000, Radius: 6, Damage: 20, Lifetime: 2, Alive: true}}
+
+	(&projectilesSystem{}).updateEnemyProjectiles(g, 0.5)
+	if len(g.EnemyProjectiles) != 0 || g.Player.HP != playerHP {
+		t.Fatalf("expected archer shot to break on cover, got hp=%d", g.Player.HP)
+	}
+
+	caster := gameobjects.NewEnemyFromArchetype(500, 240, gamedata.EnemyArchetypeHexCaller, false, 0)
+	caster.State = gameobjects.EnemyStateAttacking
+	caster.WantsAttack = true
+	caster.CurrentCooldown = 0
+	g.Enemies = []*gameobjects.Enemy{caster}
+	g.invalidateCombatSpace()
+
+	(&combatResolveSystem{}).Update(&RuntimeContext{Game: g}, 0.1)
+	if g.Player.HP != playerHP {
+		t.Fatalf("expected hex cast to be blocked by cover, got hp=%d", g.Player.HP)
+	}
+	if g.Events.CountFrameEvents(EventProjectileBlocked) != 2 {
+		t.Fatalf("expected impacts for both blocked attacks, got %d", g.Events.CountFrameEvents(EventProjectileBlocked))
+	}
+}
+
+func TestAreaSkillStopsAtWallAndIgnoresTargetsBehindIt(t *testing.T) {
+	g := newTerrainTestGame()
+	g.Player.Mana = g.Player.MaxMana
+	nearWall := gameobjects.NewEnemyFromArchetype(230, 240, gamedata.EnemyArchetypeRaider, false, 0)
+	behindWall := gameobjects.NewEnemyFromArchetype(420, 240, gamedata.EnemyArchetypeRaider, false, 0)
+	g.Enemies = []*gameobjects.Enemy{nearWall, behindWall}
+	g.invalidateCombatSpace()
+
+	skill := &gamedata.Skill{
+		Targeting:  gamedata.TargetingSpec{Type: gamedata.TargetArea, Range: 600, Radius: 200, MaxTargets: 10},
+		Delivery:   gamedata.DeliverySpec{Type: gamedata.DeliveryInstant},
+		DamageSpec: &gamedata.DamageSpec{Base: 10, DamageType: gamedata.DamageTrue},
+	}
+	playerX, playerY := g.Player.Center()
+	intent := systems.BuildCastIntent(g.Player, 480, playerY)
+
+	centerX, _ := g.resolveAreaCenter(skill, intent)
+	if centerX != 300 {
+		t.Fatalf("expected area center pulled back to the wall face, got %v (caster at %v)", centerX, playerX)
+	}
+
+	g.resolveAndApplySkill(skill, intent)
+	if nearWall.HP == nearWall.MaxHP || behindWall.HP != behindWall.MaxHP {
+		t.Fatalf("expected only the enemy on the caster's side to be hit, got near=%d behind=%d", nearWall.HP, behindWall.HP)
+	}
+
+	skill.Delivery.PierceTerrain = true
+	if centerX, _ := g.resolveAreaCenter(skill, intent); centerX != 480 {
+		t.Fatalf("expected terrain-piercing area to land at the cursor, got %v", centerX)
+	}
+}
+
+func TestArcherRepositionsAroundCoverBeforeFiring(t *testing.T) {
+	g := newTerrainTestGame()
+	movePlayerCenterTo(g.Player, 520, 260)
+	archer := gameobjects.NewEnemyFromArchetype(200, 245, gamedata.EnemyArchetypeArcher, false, 0)
+	archer.Provoked = true
+	g.Enemies = []*gameobjects.Enemy{archer}
+	g.invalidateCombatSpace()
+
+	ctx := &RuntimeContext{Game: g}
+	(&aiSystem{}).Update(ctx, 0.016)
+	if !archer.SightBlocked || archer.WantsAttack {
+		t.Fatalf("expected archer behind cover to hold fire, state %d", archer.State)
+	}
+
+	dt := float32(1.0 / 60.0)
+	for step := 0; step < 60*10 && !archer.WantsAttack; step++ {
+		(&aiSystem{}).Update(ctx, dt)
+		(&movementSystem{}).updateEnemies(g, dt)
+		g.invalidateCombatSpace()
+	}
+	archerX, archerY := archer.Center()
+	playerX, playerY := g.Player.Center()
+	if !archer.WantsAttack || !g.hasLineOfSight(archerX, archerY, playerX, playerY) {
+		t.Fatalf("expected archer to regain sight and fire, stuck at (%v,%v)", archerX, archerY)
+	}
+}
+
+func TestTargetEnemySkillRefusedWithoutLineOfSight(t *testing.T) {
+	g := newTerrainTestGame()
+	hidden := gameobjects.NewEnemyFromArchetype(450, 245, gamedata.EnemyArchetypeRaider, false, 0)
+	g.Enemies = []*gameobjects.Enemy{hidden}
+	g.invalidateCombatSpace()
+	g.Player.Mana = g.Player.MaxMana
+
+	skill := &gamedata.Skill{
+		Cooldown:   3,
+		ManaCost:   10,
+		Targeting:  gamedata.TargetingSpec{Type: gamedata.TargetEnemy, Range: 600, MaxTargets: 1},
+		Delivery:   gamedata.DeliverySpec{Type: gamedata.DeliveryInstant},
+		DamageSpec: &gamedata.DamageSpec{Base: 10, DamageType: gamedata.DamageTrue},
+	}
+	hiddenX, hiddenY := hidden.Center()
+	g.TryCastSkill(skill, &systems.Input{CursorWorldX: hiddenX, CursorWorldY: hiddenY})
+	if g.Player.Mana != g.Player.MaxMana || skill.CurrentCooldown != 0 || hidden.HP != hidden.MaxHP {
+		t.Fatalf("expected cast to be refused behind the wall, mana=%v cooldown=%v hp=%d", g.Player.Mana, skill.CurrentCooldown, hidden.HP)
+	}
+
+	movePlayerCenterTo(g.Player, 450, 420)
+	g.invalidateCombatSpace()
+	g.TryCastSkill(skill, &systems.Input{CursorWorldX: hiddenX, CursorWorldY: hiddenY})
+	if hidden.HP == hidden.MaxHP || skill.CurrentCooldown == 0 {
+		t.Fatalf("expected cast to land once the target is visible")
+	}
+}
+
+func TestAutoAttackPathsToTargetWithoutLineOfSight(t *testing.T) {
+	g := newTerrainTestGame()
+	target := gameobjects.NewEnemyFromArchetype(450, 245, gamedata.EnemyArchetypeRaider, false, 0)
+	g.Enemies = []*gameobjects.Enemy{target}
+	g.invalidateCombatSpace()
+	g.PlayerAttackTarget = target
+	movePlayerCenterTo(g.Player, 250, 260)
+
+	g.UpdateAutoAttack(0.016)
+	if g.Player.AttackState != gameobjects.PlayerAttackStateIdle || !g.HasPlayerMoveTarget {
+		t.Fatalf("expected ranged auto-attack to move toward a target behind cover instead of firing")
+	}
+}
@@ -3,7 +3,9 @@ package game
 import (
 	"fmt"
 	"math"
+	"strings"
 
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 	"singlefantasy/app/systems"
@@ -36,6 +38,9 @@ func (g *Game) drawRunHUD() {
 		statPointColor = rl.NewColor(26, 132, 56, 255)
 	}
 	rl.DrawText(fmt.Sprintf("Stat Points: %d", g.Player.StatPoints), 10, 118, 20, statPointColor)
+	if objective := g.roomEventObjective(); objective != "" {
+		rl.DrawText(objective, 10, 142, 20, rl.NewColor(40, 40, 40, 255))
+	}
 
 	g.drawMinimap()
 	g.drawPlayerEffectsTray()
@@ -118,10 +123,24 @@ func (g *Game) drawPlayerEffectsTray() {
 		rl.DrawRectangleRec(rect, rl.NewColor(20, 20, 20, 220))
 		systems.DrawIconCell(systems.GetEffectIconCell(effect.Type), rl.NewRectangle(x+2, startY+2, slotSize-4, slotSize-4), rl.White, rl.NewColor(90, 90, 90, 255))
 		rl.DrawRectangleLinesEx(rect, 1, effectBorderColor(effect.Type))
+		drawEffectStackCount(effect, rect, 14)
 		rl.DrawText(fmt.Sprintf("%.1f", effect.TimeLeft), int32(x), int32(startY+slotSize+1), 14, rl.RayWhite)
 	}
 }
 
+func drawEffectStackCount(effect gamedata.EffectInstance, rect rl.Rectangle, fontSize int32) {
+	stacks := effect.StackCount()
+	if stacks <= 1 {
+		return
+	}
+	label := fmt.Sprintf("x%d", stacks)
+	width := rl.MeasureText(label, fontSize)
+	x := int32(rect.X+rect.Width) - width - 2
+	y := int32(rect.Y+rect.Height) - fontSize - 1
+	rl.DrawText(label, x+1, y+1, fontSize, rl.Black)
+	rl.DrawText(label, x, y, fontSize, rl.RayWhite)
+}
+
 func effectBorderColor(effectType gamedata.EffectType) rl.Color {
 	switch effectType {
 	case gamedata.EffectSlow, gamedata.EffectStun, gamedata.EffectFreeze, gamedata.EffectSilence, gamedata.EffectBurn, gamedata.EffectPoison, gamedata.EffectMoveSpeedReduction:
@@ -137,39 +156,30 @@ func (g *Game) drawTargetFrame() {
 		return
 	}
 
+	if !target.IsAlive() {
+		return
+	}
+
 	name := "Enemy"
 	isElite := false
-	hp := 0
-	maxHP := 0
-	effects := []gamedata.EffectInstance{}
+	hp := target.GetHP()
+	maxHP := target.GetMaxHP()
+	effects := *target.GetEffects()
+	var resistances gamedata.ResistanceProfile
 
 	switch t := target.(type) {
-	case *gameobjects.Enemy:
-		if t == nil || !t.IsAlive() {
-			return
-		}
-		name = t.DisplayName()
-		if t.IsElite {
-			isElite = true
-		}
-		hp = t.HP
-		maxHP = t.MaxHP
-		effects = t.Effects
 	case *gameobjects.Boss:
-		if t == nil || !t.IsAlive() {
-			return
-		}
 		name = "Dungeon Boss"
-		hp = t.HP
-		maxHP = t.MaxHP
-		effects = t.Effects
-	default:
-		return
+		resistances = t.Resistances
+	case *gameobjects.Enemy:
+		name = t.DisplayName()
+		isElite = t.IsElite
+		resistances = t.Resistances
 	}
 
 	panelX := float32(WindowWidth - 290)
 	panelY := float32(160)
-	panelRect := rl.NewRectangle(panelX, panelY, 270, 118)
+	panelRect := rl.NewRectangle(panelX, panelY, 270, 140)
 	rl.DrawRectangleRec(panelRect, rl.NewColor(0, 0, 0, 175))
 	rl.DrawRectangleLinesEx(panelRect, 1, rl.NewColor(230, 230, 230, 220))
 
@@ -195,21 +205,50 @@ func (g *Game) drawTargetFrame() {
 		rl.DrawRectangleRec(rect, rl.NewColor(25, 25, 25, 220))
 		systems.DrawIconCell(systems.GetEffectIconCell(effect.Type), rl.NewRectangle(x+2, iconY+2, slotSize-4, slotSize-4), rl.White, rl.NewColor(80, 80, 80, 255))
 		rl.DrawRectangleLinesEx(rect, 1, rl.NewColor(190, 190, 190, 220))
+		drawEffectStackCount(effect, rect, 12)
+	}
+
+	if summary := resistanceSummary(resistances); summary != "" {
+		rl.DrawText(summary, int32(panelX+10), int32(panelY+112), 16, rl.NewColor(210, 210, 170, 255))
 	}
 }
 
-func (g *Game) getHoveredOrLockedTarget() interface{} {
-	if g.PlayerAttackTarget != nil {
-		switch t := g.PlayerAttackTarget.(type) {
-		case *gameobjects.Enemy:
-			if t != nil && t.IsAlive() {
-				return t
-			}
-		case *gameobjects.Boss:
-			if t != nil && t.IsAlive() {
-				return t
-			}
-		}
+// resistanceSummary lists a target's weaknesses and resistances, e.g. "Weak: Fire  Resists: Poison".
+func resistanceSummary(profile gamedata.ResistanceProfile) string {
+	parts := make([]string, 0, 2)
+	if weak := damageTypeNames(profile.Weaknesses()); weak != "" {
+		parts = append(parts, "Weak: "+weak)
+	}
+	if resists := damageTypeNames(profile.Resists()); resists != "" {
+		parts = append(parts, "Resists: "+resists)
+	}
+	return strings.Join(parts, "  ")
+}
+
+func damageTypeNames(types []gamedata.DamageType) string {
+	names := make([]string, len(types))
+	for i, damageType := range types {
+		names[i] = damageType.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// combatStatLines summarizes the derived combat stats shown beside stat allocation.
+func combatStatLines(derived gamedata.DerivedStats) []string {
+	percent := func(value float32) int { return int(value*100 + 0.5) }
+	return []string{
+		fmt.Sprintf("Hit: %d%%", percent(derived.Accuracy)),
+		fmt.Sprintf("Crit: %d%% x%.2f", percent(derived.CritChance), derived.CritMultiplier),
+		fmt.Sprintf("Dodge: %d%%", percent(derived.DodgeChance)),
+		fmt.Sprintf("Block: %d%% (%d%%)", percent(derived.BlockChance), percent(derived.BlockAmount)),
+		fmt.Sprintf("Armor Pen: %d%%", percent(derived.ArmorPenetration)),
+		fmt.Sprintf("Magic Pen: %d%%", percent(derived.MagicPenetration)),
+	}
+}
+
+func (g *Game) getHoveredOrLockedTarget() core.Combatant {
+	if g.PlayerAttackTarget != nil && g.PlayerAttackTarget.IsAlive() {
+		return g.PlayerAttackTarget
 	}
 
 	if g.Camera == nil {
@@ -219,19 +258,10 @@ func (g *Game) getHoveredOrLockedTarget() interface{} {
 	mouseX, mouseY := systems.GetMousePosition()
 	worldX, worldY := systems.ScreenToWorldIso(mouseX, mouseY, g.Camera)
 
-	if g.Boss != nil && g.Boss.IsAlive() && pointInRect(worldX, worldY, g.Boss.PosX, g.Boss.PosY, g.Boss.Hitbox.Width, g.Boss.Hitbox.Height) {
+	if g.Boss.IsAlive() && pointInRect(worldX, worldY, g.Boss.PosX, g.Boss.PosY, g.Boss.Hitbox.Width, g.Boss.Hitbox.Height) {
 		return g.Boss
 	}
-	for _, enemy := range g.Enemies {
-		if enemy == nil || !enemy.IsAlive() {
-			continue
-		}
-		if pointInRect(worldX, worldY, enemy.PosX, enemy.PosY, enemy.Hitbox.Width, enemy.Hitbox.Height) {
-			return enemy
-		}
-	}
-
-	return nil
+	return g.combatantAt(worldX, worldY)
 }
 
 func pointInRect(x, y, minX, minY, width, height float32) bool {
@@ -17,6 +17,11 @@ type BossEncounterConfig struct {
 	HeavyAttack         BossHeavyAttackConfig
 	AreaDenial          BossAreaDenialConfig
 	Enrage              BossEnrageConfig
+	CCResistance        BossCCResistanceConfig
+	Resistances         ResistanceProfile
+	Defense             DefenseStats
+	Volleys             []BossVolleyConfig
+	Phases              []BossPhaseConfig
 }
 
 type BossHeavyAttackConfig struct {
@@ -49,6 +54,75 @@ type BossEnrageConfig struct {
 	ZoneCountBonus          int
 }
 
+// BossCCResistanceConfig scales crowd-control durations on the boss and lists categories it ignores outright.
+type BossCCResistanceConfig struct {
+	DurationMultiplier float32
+	Immune             []CCCategory
+}
+
+type BossVolleyPattern int
+
+const (
+	BossVolleyRadial BossVolleyPattern = iota
+	BossVolleySpiral
+	BossVolleyAimedFan
+	BossVolleyPredictive
+	BossVolleyGappedRing
+)
+
+// BossVolleyConfig fires Waves bursts of Count projectiles, Cadence seconds apart. Arc is the spread of aimed
+// patterns or the gap of a ring, and Spin turns spirals and ring gaps between waves, both in degrees.
+type BossVolleyConfig struct {
+	ID         string
+	Pattern    BossVolleyPattern
+	Count      int
+	Waves      int
+	Cadence    float32
+	Cooldown   float32
+	Speed      float32
+	Radius     float32
+	Arc        float32
+	Spin       float32
+	Damage     int
+	DamageType DamageType
+	Effects    []EffectSpec
+}
+
+type BossAttackKind int
+
+const (
+	BossAttackHeavy BossAttackKind = iota
+	BossAttackArea
+	BossAttackVolley
+)
+
+// BossRotationStep is one attack in a phase rotation; Volley names the volley fired by BossAttackVolley steps.
+type BossRotationStep struct {
+	Attack BossAttackKind
+	Volley string
+}
+
+// BossPhaseConfig scripts one stage of a fight. The first phase opens the fight; each later phase starts once
+// HP falls to its threshold or the fight has lasted its time threshold, whichever comes first.
+type BossPhaseConfig struct {
+	Name                 string
+	HPThresholdPercent   float32
+	TimeThreshold        float32
+	Rotation             []BossRotationStep
+	RotationInterval     float32
+	AddWaves             []BossAddWaveConfig
+	InvulnerableDuration float32
+	DamageMultiplier     float32
+	MoveSpeedMultiplier  float32
+}
+
+// BossAddWaveConfig spawns Count adds drawn from Archetypes, Delay seconds after its phase starts.
+type BossAddWaveConfig struct {
+	Delay      float32
+	Count      int
+	Archetypes []EnemyArchetypeType
+}
+
 var defaultBossEncounter = BossEncounterConfig{
 	ID:                  "forest_warden_alpha",
 	Biome:               "forest",
@@ -94,10 +168,103 @@ var defaultBossEncounter = BossEncounterConfig{
 		AreaCooldownMultiplier:  0.7,
 		ZoneCountBonus:          1,
 	},
+	CCResistance: BossCCResistanceConfig{
+		DurationMultiplier: 0.5,
+	},
+	Resistances: ResistanceProfile{
+		DamagePhysical: 0.1,
+		DamageFire:     -0.3,
+		DamagePoison:   0.4,
+	},
+	Defense: DefenseStats{BlockChance: 0.1, BlockAmount: 0.35},
+	Volleys: []BossVolleyConfig{
+		{
+			ID:         "thorn_burst",
+			Pattern:    BossVolleyRadial,
+			Count:      12,
+			Waves:      2,
+			Cadence:    0.5,
+			Cooldown:   6.5,
+			Speed:      200,
+			Radius:     8,
+			Spin:       15,
+			Damage:     10,
+			DamageType: DamagePhysical,
+		},
+		{
+			ID:         "bramble_fan",
+			Pattern:    BossVolleyAimedFan,
+			Count:      5,
+			Waves:      3,
+			Cadence:    0.3,
+			Cooldown:   6.5,
+			Speed:      260,
+			Radius:     7,
+			Arc:        40,
+			Damage:     9,
+			DamageType: DamagePhysical,
+		},
+		{
+			ID:         "spore_ring",
+			Pattern:    BossVolleyGappedRing,
+			Count:      20,
+			Waves:      3,
+			Cadence:    0.7,
+			Cooldown:   7,
+			Speed:      150,
+			Radius:     9,
+			Arc:        60,
+			Spin:       25,
+			Damage:     8,
+			DamageType: DamageMagical,
+			Effects: []EffectSpec{
+				{
+					Type:      EffectSlow,
+					Duration:  1.2,
+					Magnitude: 0.25,
+				},
+			},
+		},
+	},
+	Phases: []BossPhaseConfig{
+		{
+			Name: "Rooted",
+			Rotation: []BossRotationStep{
+				{Attack: BossAttackHeavy},
+				{Attack: BossAttackVolley, Volley: "thorn_burst"},
+				{Attack: BossAttackArea},
+				{Attack: BossAttackVolley, Volley: "bramble_fan"},
+			},
+			RotationInterval:    3.2,
+			DamageMultiplier:    1,
+			MoveSpeedMultiplier: 1,
+		},
+		{
+			Name:               "Overgrown",
+			HPThresholdPercent: 0.5,
+			Rotation: []BossRotationStep{
+				{Attack: BossAttackHeavy},
+				{Attack: BossAttackVolley, Volley: "spore_ring"},
+				{Attack: BossAttackArea},
+				{Attack: BossAttackVolley, Volley: "bramble_fan"},
+			},
+			RotationInterval: 2.4,
+			AddWaves: []BossAddWaveConfig{
+				{Delay: 0.5, Count: 3, Archetypes: []EnemyArchetypeType{EnemyArchetypeSwarmling, EnemyArchetypeSwarmling, EnemyArchetypeRaider}},
+			},
+			InvulnerableDuration: 1.5,
+			DamageMultiplier:     1.22,
+			MoveSpeedMultiplier:  1.18,
+		},
+	},
 }
 
-var bossEncountersByBiome = map[string]BossEncounterConfig{
-	"forest": defaultBossEncounter,
+var bossEncountersByBiome = builtinBossEncounters()
+
+func builtinBossEncounters() map[string]BossEncounterConfig {
+	return map[string]BossEncounterConfig{
+		"forest": defaultBossEncounter,
+	}
 }
 
 func GetBossEncounterConfig(biome string) BossEncounterConfig {
@@ -159,7 +326,7 @@ func sanitizeBossEncounterConfig(cfg BossEncounterConfig) BossEncounterConfig {
 	if cfg.HeavyAttack.Damage <= 0 {
 		cfg.HeavyAttack.Damage = defaultBossEncounter.HeavyAttack.Damage
 	}
-	if cfg.HeavyAttack.DamageType != DamagePhysical && cfg.HeavyAttack.DamageType != DamageMagical {
+	if !isBossAttackDamageType(cfg.HeavyAttack.DamageType) {
 		cfg.HeavyAttack.DamageType = defaultBossEncounter.HeavyAttack.DamageType
 	}
 	if cfg.AreaDenial.Cooldown <= 0 {
@@ -180,7 +347,7 @@ func sanitizeBossEncounterConfig(cfg BossEncounterConfig) BossEncounterConfig {
 	if cfg.AreaDenial.Damage <= 0 {
 		cfg.AreaDenial.Damage = defaultBossEncounter.AreaDenial.Damage
 	}
-	if cfg.AreaDenial.DamageType != DamagePhysical && cfg.AreaDenial.DamageType != DamageMagical {
+	if !isBossAttackDamageType(cfg.AreaDenial.DamageType) {
 		cfg.AreaDenial.DamageType = defaultBossEncounter.AreaDenial.DamageType
 	}
 	if cfg.AreaDenial.ZoneCount <= 0 {
@@ -208,5 +375,95 @@ func sanitizeBossEncounterConfig(cfg BossEncounterConfig) BossEncounterConfig {
 		cfg.Enrage.ZoneCountBonus = defaultBossEncounter.Enrage.ZoneCountBonus
 	}
 	cfg.AreaDenial.Effects = append([]EffectSpec(nil), cfg.AreaDenial.Effects...)
+	if cfg.CCResistance.DurationMultiplier <= 0 {
+		cfg.CCResistance.DurationMultiplier = 1
+	}
+	cfg.CCResistance.Immune = append([]CCCategory(nil), cfg.CCResistance.Immune...)
+	cfg.Resistances = cfg.Resistances.Clone()
+	cfg.Defense = DefenseStats{
+		DodgeChance: clampFloat32(cfg.Defense.DodgeChance, 0, 1),
+		BlockChance: clampFloat32(cfg.Defense.BlockChance, 0, 1),
+		BlockAmount: clampFloat32(cfg.Defense.BlockAmount, 0, 1),
+	}
+	cfg.Volleys = sanitizeBossVolleys(cfg.Volleys)
+	cfg.Phases = sanitizeBossPhases(cfg.Phases)
 	return cfg
 }
+
+// isBossAttackDamageType rejects true damage and unknown values so boss attacks always meet player resistances.
+func isBossAttackDamageType(damageType DamageType) bool {
+	return damageType == DamagePhysical || damageType == DamageMagical || IsElementalDamage(damageType)
+}
+
+func sanitizeBossVolleys(volleys []BossVolleyConfig) []BossVolleyConfig {
+	if len(volleys) == 0 {
+		return nil
+	}
+	out := make([]BossVolleyConfig, 0, len(volleys))
+	for _, volley := range volleys {
+		if volley.Count <= 0 || volley.Speed <= 0 || volley.Damage <= 0 {
+			continue
+		}
+		if volley.Waves <= 0 {
+			volley.Waves = 1
+		}
+		if volley.Cadence < 0 {
+			volley.Cadence = 0
+		}
+		if volley.Cooldown <= 0 {
+			volley.Cooldown = 6
+		}
+		if volley.Radius <= 0 {
+			volley.Radius = 8
+		}
+		if !isBossAttackDamageType(volley.DamageType) {
+			volley.DamageType = DamagePhysical
+		}
+		volley.Effects = append([]EffectSpec(nil), volley.Effects...)
+		out = append(out, volley)
+	}
+	return out
+}
+
+// Volley finds one of the encounter's volleys by ID.
+func (cfg BossEncounterConfig) Volley(id string) (BossVolleyConfig, bool) {
+	for _, volley := range cfg.Volleys {
+		if volley.ID == id {
+			return volley, true
+		}
+	}
+	return BossVolleyConfig{}, false
+}
+
+func sanitizeBossPhases(phases []BossPhaseConfig) []BossPhaseConfig {
+	if len(phases) == 0 {
+		return nil
+	}
+	out := make([]BossPhaseConfig, len(phases))
+	for i, phase := range phases {
+		if phase.RotationInterval <= 0 {
+			phase.RotationInterval = 2.5
+		}
+		if phase.DamageMultiplier <= 0 {
+			phase.DamageMultiplier = 1
+		}
+		if phase.MoveSpeedMultiplier <= 0 {
+			phase.MoveSpeedMultiplier = 1
+		}
+		if phase.InvulnerableDuration < 0 {
+			phase.InvulnerableDuration = 0
+		}
+		phase.Rotation = append([]BossRotationStep(nil), phase.Rotation...)
+		waves := make([]BossAddWaveConfig, 0, len(phase.AddWaves))
+		for _, wave := range phase.AddWaves {
+			if wave.Count <= 0 || len(wave.Archetypes) == 0 {
+				continue
+			}
+			wave.Archetypes = append([]EnemyArchetypeType(nil), wave.Archetypes...)
+			waves = append(waves, wave)
+		}
+		phase.AddWaves = waves
+		out[i] = phase
+	}
+	return out
+}
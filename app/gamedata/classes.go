@@ -34,7 +34,7 @@ type Class struct {
 	ManaToHealthRate float32
 }
 
-var classTable = map[ClassType]Class{
+var builtinClassTable = map[ClassType]Class{
 	ClassTypeMelee: {
 		Type:             ClassTypeMelee,
 		Name:             "Warrior",
@@ -68,6 +68,8 @@ var classTable = map[ClassType]Class{
 	},
 }
 
+var classTable = builtinClassTable
+
 func GetClass(classType ClassType) *Class {
 	class, ok := classTable[classType]
 	if !ok {
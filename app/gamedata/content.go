@@ -0,0 +1,138 @@
+package gamedata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const DefaultDataRoot = "assets/data"
+
+const (
+	ClassesFile = "classes.json"
+	SkillsFile  = "skills.json"
+	EnemiesFile = "enemies.json"
+	ItemsFile   = "items.json"
+	BossesFile  = "bosses.json"
+)
+
+// Content is a full set of gameplay definitions. The active set backs every Get* lookup in this package.
+type Content struct {
+	Classes         map[ClassType]Class
+	ClassSkills     map[ClassType][]SkillType
+	Skills          map[SkillType]Skill
+	EnemyArchetypes map[EnemyArchetypeType]EnemyArchetype
+	EliteModifiers  map[EliteModifierType]EliteModifier
+	ItemPools       map[string][]*Item
+	BossEncounters  map[string]BossEncounterConfig
+}
+
+func BuiltinContent() *Content {
+	return &Content{
+		Classes:         copyMap(builtinClassTable),
+		ClassSkills:     copyClassSkills(builtinClassSkills),
+		Skills:          builtinSkillTable(),
+		EnemyArchetypes: copyMap(builtinEnemyArchetypes),
+		EliteModifiers:  copyMap(builtinEliteModifiers),
+		ItemPools:       builtinBiomeItemPools(),
+		BossEncounters:  builtinBossEncounters(),
+	}
+}
+
+func ApplyContent(content *Content) {
+	if content == nil {
+		return
+	}
+	classTable = content.Classes
+	classSkillTable = content.ClassSkills
+	skillTable = content.Skills
+	enemyArchetypes = content.EnemyArchetypes
+	eliteModifiers = content.EliteModifiers
+	biomeItemPools = content.ItemPools
+	bossEncountersByBiome = content.BossEncounters
+}
+
+func ResetContent() {
+	ApplyContent(BuiltinContent())
+}
+
+// LoadContent reads the JSON definition files under root on top of the built-in tables.
+// Absent files keep the built-in definitions; entries in a file replace the built-in entry with the same id.
+func LoadContent(root string) (*Content, error) {
+	root = strings.TrimSpace(root)
+	if root == "" {
+		root = DefaultDataRoot
+	}
+	root = ResolveDataRoot(root)
+
+	content := BuiltinContent()
+	loaders := []struct {
+		name  string
+		apply func(data []byte, content *Content) error
+	}{
+		{name: ClassesFile, apply: applyClassesFile},
+		{name: SkillsFile, apply: applySkillsFile},
+		{name: EnemiesFile, apply: applyEnemiesFile},
+		{name: ItemsFile, apply: applyItemsFile},
+		{name: BossesFile, apply: applyBossesFile},
+	}
+	for _, loader := range loaders {
+		path := filepath.Join(root, loader.name)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read content file %q: %w", path, err)
+		}
+		if err := loader.apply(data, content); err != nil {
+			return nil, fmt.Errorf("content file %q: %w", path, err)
+		}
+	}
+	return content, nil
+}
+
+func decodeContentFile(data []byte, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+	return nil
+}
+
+func ResolveDataRoot(root string) string {
+	candidates := []string{
+		root,
+		filepath.Join("..", root),
+		filepath.Join("..", "..", root),
+		filepath.Join("..", "..", "..", root),
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+	}
+	return root
+}
+
+func copyMap[K comparable, V any](values map[K]V) map[K]V {
+	out := make(map[K]V, len(values))
+	for key, value := range values {
+		out[key] = value
+	}
+	return out
+}
+
+func copyClassSkills(values map[ClassType][]SkillType) map[ClassType][]SkillType {
+	out := make(map[ClassType][]SkillType, len(values))
+	for classType, skillTypes := range values {
+		out[classType] = append([]SkillType(nil), skillTypes...)
+	}
+	return out
+}
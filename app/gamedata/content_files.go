@@ -0,0 +1,1446 @@
+package gamedata
+
+import (
+	"fmt"
+	"strings"
+)
+
+type classesFile struct {
+	Classes []classDefinition `json:"classes"`
+}
+
+type classDefinition struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	PrimaryStat      string         `json:"primary_stat"`
+	BaselineStats    map[string]int `json:"baseline_stats"`
+	GrowthBias       string         `json:"growth_bias"`
+	AttackRange      float32        `json:"attack_range"`
+	LifestealPercent float32        `json:"lifesteal_percent"`
+	KillHealAmount   int            `json:"kill_heal_amount"`
+	ManaCost         int            `json:"mana_cost"`
+	ManaRegenPerSec  float32        `json:"mana_regen_per_sec"`
+	ManaToHealthRate float32        `json:"mana_to_health_rate"`
+	Skills           []string       `json:"skills"`
+}
+
+type skillsFile struct {
+	Skills []skillDefinition `json:"skills"`
+}
+
+type skillDefinition struct {
+	ID           string                  `json:"id"`
+	Name         string                  `json:"name"`
+	Cooldown     float32                 `json:"cooldown"`
+	ManaCost     int                     `json:"mana_cost"`
+	Targeting    targetingDefinition     `json:"targeting"`
+	Delivery     deliveryDefinition      `json:"delivery"`
+	Damage       *damageDefinition       `json:"damage,omitempty"`
+	Effects      []EffectDefinition      `json:"effects,omitempty"`
+	SelfMovement *selfMovementDefinition `json:"self_movement,omitempty"`
+	ManaShield   *manaShieldDefinition   `json:"mana_shield,omitempty"`
+	ResourceGain *resourceGainDefinition `json:"resource_gain,omitempty"`
+	Knockback    *knockbackDefinition    `json:"knockback,omitempty"`
+	Heal         *healDefinition         `json:"heal,omitempty"`
+	Summon       *summonDefinition       `json:"summon,omitempty"`
+}
+
+type targetingDefinition struct {
+	Type       string  `json:"type"`
+	Range      float32 `json:"range,omitempty"`
+	Radius     float32 `json:"radius,omitempty"`
+	MaxTargets int     `json:"max_targets,omitempty"`
+	ArcDegrees float32 `json:"arc_degrees,omitempty"`
+	LineWidth  float32 `json:"line_width,omitempty"`
+}
+
+type deliveryDefinition struct {
+	Type             string  `json:"type"`
+	Speed            float32 `json:"speed,omitempty"`
+	Delay            float32 `json:"delay,omitempty"`
+	Lifetime         float32 `json:"lifetime,omitempty"`
+	Pierce           int     `json:"pierce,omitempty"`
+	PierceTerrain    bool    `json:"pierce_terrain,omitempty"`
+	ProjectileRadius float32 `json:"projectile_radius,omitempty"`
+	ZoneDuration     float32 `json:"zone_duration,omitempty"`
+	ZoneTickRate     float32 `json:"zone_tick_rate,omitempty"`
+}
+
+type damageDefinition struct {
+	Base       float32            `json:"base"`
+	Scaling    map[string]float32 `json:"scaling,omitempty"`
+	Type       string             `json:"type"`
+	CritChance float32            `json:"crit_chance,omitempty"`
+	CritMult   float32            `json:"crit_mult,omitempty"`
+}
+
+type EffectDefinition struct {
+	Type                string  `json:"type"`
+	Duration            float32 `json:"duration"`
+	Magnitude           float32 `json:"magnitude,omitempty"`
+	TickRate            float32 `json:"tick_rate,omitempty"`
+	PercentMaxHPPerTick float32 `json:"percent_max_hp_per_tick,omitempty"`
+	MinTickDamage       int     `json:"min_tick_damage,omitempty"`
+	MaxTickDamage       int     `json:"max_tick_damage,omitempty"`
+}
+
+type selfMovementDefinition struct {
+	Mode     string  `json:"mode"`
+	Distance float32 `json:"distance"`
+}
+
+type manaShieldDefinition struct {
+	AbsorbFromCurrentManaRatio float32 `json:"absorb_from_current_mana_ratio"`
+	Duration                   float32 `json:"duration"`
+}
+
+type resourceGainDefinition struct {
+	ManaPerTarget int `json:"mana_per_target"`
+}
+
+type knockbackDefinition struct {
+	Impulse float32 `json:"impulse"`
+}
+
+type healDefinition struct {
+	Amount       int     `json:"amount,omitempty"`
+	PercentMaxHP float32 `json:"percent_max_hp,omitempty"`
+}
+
+type summonDefinition struct {
+	Archetype string `json:"archetype"`
+	Count     int    `json:"count"`
+}
+
+type enemiesFile struct {
+	Archetypes     []enemyArchetypeDefinition `json:"archetypes"`
+	EliteModifiers []eliteModifierDefinition  `json:"elite_modifiers"`
+}
+
+type enemyArchetypeDefinition struct {
+	ID                 string                 `json:"id"`
+	Name               string                 `json:"name"`
+	Role               string                 `json:"role"`
+	MaxHP              int                    `json:"max_hp"`
+	Damage             int                    `json:"damage"`
+	MoveSpeed          float32                `json:"move_speed"`
+	AttackCooldown     float32                `json:"attack_cooldown"`
+	AttackRange        float32                `json:"attack_range"`
+	AggroRange         float32                `json:"aggro_range"`
+	PreferredRange     float32                `json:"preferred_range"`
+	RetreatRange       float32                `json:"retreat_range,omitempty"`
+	Width              float32                `json:"width"`
+	Height             float32                `json:"height"`
+	AttackMode         string                 `json:"attack_mode"`
+	ProjectileSpeed    float32                `json:"projectile_speed,omitempty"`
+	ProjectileRadius   float32                `json:"projectile_radius,omitempty"`
+	ProjectileLifetime float32                `json:"projectile_lifetime,omitempty"`
+	DamageType         string                 `json:"damage_type"`
+	OnHitEffects       []EffectDefinition     `json:"on_hit_effects,omitempty"`
+	Resistances        map[string]float32     `json:"resistances,omitempty"`
+	Defense            *defenseDefinition     `json:"defense,omitempty"`
+	XPReward           *int                   `json:"xp_reward,omitempty"`
+	ThreatValue        int                    `json:"threat_value"`
+	Skills             []enemySkillDefinition `json:"skills,omitempty"`
+}
+
+type enemySkillDefinition struct {
+	skillDefinition
+	Use enemySkillUseDefinition `json:"use"`
+}
+
+type enemySkillUseDefinition struct {
+	MinRange      float32 `json:"min_range,omitempty"`
+	MaxRange      float32 `json:"max_range,omitempty"`
+	SelfHPBelow   float32 `json:"self_hp_below,omitempty"`
+	AllyHPBelow   float32 `json:"ally_hp_below,omitempty"`
+	MinAllies     int     `json:"min_allies,omitempty"`
+	MaxAllies     int     `json:"max_allies,omitempty"`
+	PlayerCasting bool    `json:"player_casting,omitempty"`
+}
+
+type eliteModifierDefinition struct {
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	HPMultiplier  float32            `json:"hp_multiplier"`
+	DmgMultiplier float32            `json:"damage_multiplier"`
+	OnHitEffects  []EffectDefinition `json:"on_hit_effects,omitempty"`
+}
+
+type itemsFile struct {
+	Items []itemDefinition `json:"items"`
+}
+
+type itemDefinition struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Slot        string                 `json:"slot"`
+	Stats       map[string]int         `json:"stats"`
+	Class       string                 `json:"class"`
+	FlavorTags  []string               `json:"flavor_tags,omitempty"`
+	Biome       string                 `json:"biome"`
+	Weight      int                    `json:"weight"`
+	Effects     []itemEffectDefinition `json:"effects,omitempty"`
+}
+
+type itemEffectDefinition struct {
+	Trigger    string                    `json:"trigger"`
+	Interval   float32                   `json:"interval,omitempty"`
+	Conditions []itemConditionDefinition `json:"conditions,omitempty"`
+	Action     itemActionDefinition      `json:"action"`
+}
+
+type itemConditionDefinition struct {
+	Type    string   `json:"type"`
+	Value   float32  `json:"value,omitempty"`
+	Effects []string `json:"effects,omitempty"`
+}
+
+type itemActionDefinition struct {
+	Type            string            `json:"type"`
+	Amount          float32           `json:"amount,omitempty"`
+	PercentOfDamage float32           `json:"percent_of_damage,omitempty"`
+	Effect          *EffectDefinition `json:"effect,omitempty"`
+	OnSelf          bool              `json:"on_self,omitempty"`
+	Radius          float32           `json:"radius,omitempty"`
+	Speed           float32           `json:"speed,omitempty"`
+	DamageType      string            `json:"damage_type,omitempty"`
+}
+
+type bossesFile struct {
+	Bosses []bossDefinition `json:"bosses"`
+}
+
+type bossDefinition struct {
+	ID                  string                      `json:"id"`
+	Biome               string                      `json:"biome"`
+	MaxHP               int                         `json:"max_hp"`
+	Damage              int                         `json:"damage"`
+	MoveSpeed           float32                     `json:"move_speed"`
+	AttackCooldown      float32                     `json:"attack_cooldown"`
+	AttackRange         float32                     `json:"attack_range"`
+	AggroRange          float32                     `json:"aggro_range"`
+	Width               float32                     `json:"width"`
+	Height              float32                     `json:"height"`
+	TargetFightDuration float32                     `json:"target_fight_duration"`
+	HeavyAttack         bossHeavyAttackDefinition   `json:"heavy_attack"`
+	AreaDenial          bossAreaDenialDefinition    `json:"area_denial"`
+	Enrage              bossEnrageDefinition        `json:"enrage"`
+	CCResistance        *bossCCResistanceDefinition `json:"cc_resistance,omitempty"`
+	Resistances         map[string]float32          `json:"resistances,omitempty"`
+	Defense             *defenseDefinition          `json:"defense,omitempty"`
+	Volleys             []bossVolleyDefinition      `json:"volleys,omitempty"`
+	Phases              []bossPhaseDefinition       `json:"phases,omitempty"`
+}
+
+type defenseDefinition struct {
+	DodgeChance float32 `json:"dodge_chance,omitempty"`
+	BlockChance float32 `json:"block_chance,omitempty"`
+	BlockAmount float32 `json:"block_amount,omitempty"`
+}
+
+type bossCCResistanceDefinition struct {
+	DurationMultiplier float32  `json:"duration_multiplier,omitempty"`
+	Immune             []string `json:"immune,omitempty"`
+}
+
+type bossVolleyDefinition struct {
+	ID         string             `json:"id"`
+	Pattern    string             `json:"pattern"`
+	Count      int                `json:"count"`
+	Waves      int                `json:"waves,omitempty"`
+	Cadence    float32            `json:"cadence,omitempty"`
+	Cooldown   float32            `json:"cooldown"`
+	Speed      float32            `json:"speed"`
+	Radius     float32            `json:"radius,omitempty"`
+	Arc        float32            `json:"arc,omitempty"`
+	Spin       float32            `json:"spin,omitempty"`
+	Damage     int                `json:"damage"`
+	DamageType string             `json:"damage_type"`
+	Effects    []EffectDefinition `json:"effects,omitempty"`
+}
+
+type bossPhaseDefinition struct {
+	Name                 string                  `json:"name"`
+	HPThresholdPercent   float32                 `json:"hp_threshold_percent,omitempty"`
+	TimeThreshold        float32                 `json:"time_threshold,omitempty"`
+	Rotation             []string                `json:"rotation,omitempty"`
+	RotationInterval     float32                 `json:"rotation_interval,omitempty"`
+	AddWaves             []bossAddWaveDefinition `json:"add_waves,omitempty"`
+	InvulnerableDuration float32                 `json:"invulnerable_duration,omitempty"`
+	DamageMultiplier     float32                 `json:"damage_multiplier,omitempty"`
+	MoveSpeedMultiplier  float32                 `json:"move_speed_multiplier,omitempty"`
+}
+
+type bossAddWaveDefinition struct {
+	Delay      float32  `json:"delay,omitempty"`
+	Count      int      `json:"count"`
+	Archetypes []string `json:"archetypes"`
+}
+
+type bossHeavyAttackDefinition struct {
+	TelegraphDuration float32 `json:"telegraph_duration"`
+	Cooldown          float32 `json:"cooldown"`
+	Radius            float32 `json:"radius"`
+	Damage            int     `json:"damage"`
+	DamageType        string  `json:"damage_type"`
+}
+
+type bossAreaDenialDefinition struct {
+	Cooldown        float32            `json:"cooldown"`
+	WarningDuration float32            `json:"warning_duration"`
+	ActiveDuration  float32            `json:"active_duration"`
+	TickRate        float32            `json:"tick_rate"`
+	Radius          float32            `json:"radius"`
+	Damage          int                `json:"damage"`
+	DamageType      string             `json:"damage_type"`
+	Effects         []EffectDefinition `json:"effects,omitempty"`
+	ZoneCount       int                `json:"zone_count"`
+	SpawnDistance   float32            `json:"spawn_distance"`
+}
+
+type bossEnrageDefinition struct {
+	ThresholdHPPercent      float32 `json:"threshold_hp_percent"`
+	MoveSpeedMultiplier     float32 `json:"move_speed_multiplier"`
+	DamageMultiplier        float32 `json:"damage_multiplier"`
+	HeavyCooldownMultiplier float32 `json:"heavy_cooldown_multiplier"`
+	AreaCooldownMultiplier  float32 `json:"area_cooldown_multiplier"`
+	ZoneCountBonus          int     `json:"zone_count_bonus"`
+}
+
+var classTypeNames = map[string]ClassType{
+	"melee":  ClassTypeMelee,
+	"ranged": ClassTypeRanged,
+	"caster": ClassTypeCaster,
+	"any":    ClassTypeAny,
+}
+
+var statTypeNames = map[string]StatType{
+	"str": StatTypeSTR,
+	"agi": StatTypeAGI,
+	"vit": StatTypeVIT,
+	"int": StatTypeINT,
+	"dex": StatTypeDEX,
+	"luk": StatTypeLUK,
+}
+
+var skillTypeNames = map[string]SkillType{
+	"power_strike":   SkillTypePowerStrike,
+	"guard_stance":   SkillTypeGuardStance,
+	"blood_oath":     SkillTypeBloodOath,
+	"shockwave_slam": SkillTypeShockwaveSlam,
+	"quick_shot":     SkillTypeQuickShot,
+	"retreat_roll":   SkillTypeRetreatRoll,
+	"focused_aim":    SkillTypeFocusedAim,
+	"poison_tip":     SkillTypePoisonTip,
+	"arcane_bolt":    SkillTypeArcaneBolt,
+	"mana_shield":    SkillTypeManaShield,
+	"frost_field":    SkillTypeFrostField,
+	"arcane_drain":   SkillTypeArcaneDrain,
+}
+
+var targetTypeNames = map[string]TargetType{
+	"self":      TargetSelf,
+	"enemy":     TargetEnemy,
+	"area":      TargetArea,
+	"direction": TargetDirection,
+	"ally":      TargetAlly,
+}
+
+var deliveryTypeNames = map[string]DeliveryType{
+	"instant":    DeliveryInstant,
+	"projectile": DeliveryProjectile,
+	"delayed":    DeliveryDelayed,
+}
+
+var damageTypeNames = map[string]DamageType{
+	"physical":  DamagePhysical,
+	"magical":   DamageMagical,
+	"true":      DamageTrue,
+	"fire":      DamageFire,
+	"frost":     DamageFrost,
+	"poison":    DamagePoison,
+	"lightning": DamageLightning,
+}
+
+var effectTypeNames = map[string]EffectType{
+	"slow":                 EffectSlow,
+	"stun":                 EffectStun,
+	"freeze":               EffectFreeze,
+	"silence":              EffectSilence,
+	"burn":                 EffectBurn,
+	"poison":               EffectPoison,
+	"damage_reduction":     EffectDamageReduction,
+	"move_speed_reduction": EffectMoveSpeedReduction,
+	"lifesteal":            EffectLifesteal,
+	"damage_boost":         EffectDamageBoost,
+	"move_speed_boost":     EffectMoveSpeedBoost,
+}
+
+var selfMovementModeNames = map[string]SelfMovementMode{
+	"none":                 SelfMovementNone,
+	"backward_from_cursor": SelfMovementBackwardFromCursor,
+	"toward_target":        SelfMovementTowardTarget,
+}
+
+var enemyArchetypeNames = map[string]EnemyArchetypeType{
+	"raider":     EnemyArchetypeRaider,
+	"pikeman":    EnemyArchetypePikeman,
+	"archer":     EnemyArchetypeArcher,
+	"hex_caller": EnemyArchetypeHexCaller,
+	"brute":      EnemyArchetypeBrute,
+	"swarmling":  EnemyArchetypeSwarmling,
+}
+
+var enemyAttackModeNames = map[string]EnemyAttackMode{
+	"melee":      EnemyAttackMelee,
+	"projectile": EnemyAttackProjectile,
+	"caster_aoe": EnemyAttackCasterAOE,
+}
+
+var bossAttackKindNames = map[string]BossAttackKind{
+	"heavy": BossAttackHeavy,
+	"area":  BossAttackArea,
+}
+
+var ccCategoryNames = map[string]CCCategory{
+	"stun":    CCCategoryStun,
+	"freeze":  CCCategoryFreeze,
+	"silence": CCCategorySilence,
+	"slow":    CCCategorySlow,
+}
+
+var bossVolleyPatternNames = map[string]BossVolleyPattern{
+	"radial":      BossVolleyRadial,
+	"spiral":      BossVolleySpiral,
+	"aimed_fan":   BossVolleyAimedFan,
+	"predictive":  BossVolleyPredictive,
+	"gapped_ring": BossVolleyGappedRing,
+}
+
+var eliteModifierNames = map[string]EliteModifierType{
+	"scorching": EliteModifierScorching,
+	"crippling": EliteModifierCrippling,
+}
+
+var itemSlotNames = map[string]ItemSlot{
+	"weapon": ItemSlotWeapon,
+	"head":   ItemSlotHead,
+	"chest":  ItemSlotChest,
+	"lower":  ItemSlotLower,
+}
+
+var itemTriggerNames = map[string]ItemTrigger{
+	"on_hit":        ItemTriggerOnHit,
+	"on_crit":       ItemTriggerOnCrit,
+	"on_kill":       ItemTriggerOnKill,
+	"on_damaged":    ItemTriggerOnDamaged,
+	"on_skill_cast": ItemTriggerOnSkillCast,
+	"on_room_enter": ItemTriggerOnRoomEnter,
+	"periodic":      ItemTriggerPeriodic,
+	"on_attack":     ItemTriggerOnAttack,
+}
+
+var itemConditionNames = map[string]ItemConditionType{
+	"chance":            ItemConditionChance,
+	"target_has_effect": ItemConditionTargetHasEffect,
+	"target_hp_below":   ItemConditionTargetHPBelow,
+	"self_hp_below":     ItemConditionSelfHPBelow,
+}
+
+var itemActionNames = map[string]ItemActionType{
+	"apply_effect":     ItemActionApplyEffect,
+	"area_damage":      ItemActionAreaDamage,
+	"heal":             ItemActionHeal,
+	"restore_mana":     ItemActionRestoreMana,
+	"reduce_cooldowns": ItemActionReduceCooldowns,
+	"spawn_projectile": ItemActionSpawnProjectile,
+	"crit_chance":      ItemActionCritChance,
+}
+
+func parseContentName[T any](kind, value string, names map[string]T) (T, error) {
+	parsed, ok := names[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("unsupported %s %q", kind, value)
+	}
+	return parsed, nil
+}
+
+func ParseDamageType(value string) (DamageType, error) {
+	return parseContentName("damage type", value, damageTypeNames)
+}
+
+func applyClassesFile(data []byte, content *Content) error {
+	var file classesFile
+	if err := decodeContentFile(data, &file); err != nil {
+		return err
+	}
+	seen := map[ClassType]bool{}
+	for _, definition := range file.Classes {
+		classType, err := parseContentName("class", definition.ID, classTypeNames)
+		if err != nil {
+			return err
+		}
+		if classType == ClassTypeAny {
+			return fmt.Errorf("class %q cannot be defined", definition.ID)
+		}
+		if seen[classType] {
+			return fmt.Errorf("duplicate class %q", definition.ID)
+		}
+		seen[classType] = true
+
+		class, skills, err := buildClass(classType, definition)
+		if err != nil {
+			return fmt.Errorf("class %q: %w", definition.ID, err)
+		}
+		content.Classes[classType] = class
+		content.ClassSkills[classType] = skills
+	}
+	return nil
+}
+
+func buildClass(classType ClassType, definition classDefinition) (Class, []SkillType, error) {
+	if strings.TrimSpace(definition.Name) == "" {
+		return Class{}, nil, fmt.Errorf("missing required field name")
+	}
+	primary, err := parseContentName("stat", definition.PrimaryStat, statTypeNames)
+	if err != nil {
+		return Class{}, nil, err
+	}
+	growth, err := parseContentName("stat", definition.GrowthBias, statTypeNames)
+	if err != nil {
+		return Class{}, nil, err
+	}
+	baseline, err := parseStatValues(definition.BaselineStats)
+	if err != nil {
+		return Class{}, nil, err
+	}
+	stats := Stats{
+		STR: baseline[StatTypeSTR],
+		AGI: baseline[StatTypeAGI],
+		VIT: baseline[StatTypeVIT],
+		INT: baseline[StatTypeINT],
+		DEX: baseline[StatTypeDEX],
+		LUK: baseline[StatTypeLUK],
+	}
+	if definition.AttackRange <= 0 {
+		return Class{}, nil, fmt.Errorf("attack_range must be > 0")
+	}
+	if definition.LifestealPercent < 0 || definition.KillHealAmount < 0 || definition.ManaCost < 0 || definition.ManaRegenPerSec < 0 || definition.ManaToHealthRate < 0 {
+		return Class{}, nil, fmt.Errorf("lifesteal, kill heal, mana cost, mana regen and mana to health rate must be >= 0")
+	}
+	if len(definition.Skills) == 0 {
+		return Class{}, nil, fmt.Errorf("missing required field skills")
+	}
+	skills := make([]SkillType, 0, len(definition.Skills))
+	for _, name := range definition.Skills {
+		skillType, err := parseContentName("skill", name, skillTypeNames)
+		if err != nil {
+			return Class{}, nil, err
+		}
+		skills = append(skills, skillType)
+	}
+
+	return Class{
+		Type:             classType,
+		Name:             definition.Name,
+		PrimaryStat:      primary,
+		BaselineStats:    stats,
+		GrowthBias:       growth,
+		AttackRange:      definition.AttackRange,
+		LifestealPercent: definition.LifestealPercent,
+		KillHealAmount:   definition.KillHealAmount,
+		ManaCost:         definition.ManaCost,
+		ManaRegenPerSec:  definition.ManaRegenPerSec,
+		ManaToHealthRate: definition.ManaToHealthRate,
+	}, skills, nil
+}
+
+func applySkillsFile(data []byte, content *Content) error {
+	var file skillsFile
+	if err := decodeContentFile(data, &file); err != nil {
+		return err
+	}
+	seen := map[SkillType]bool{}
+	for _, definition := range file.Skills {
+		skillType, err := parseContentName("skill", definition.ID, skillTypeNames)
+		if err != nil {
+			return err
+		}
+		if seen[skillType] {
+			return fmt.Errorf("duplicate skill %q", definition.ID)
+		}
+		seen[skillType] = true
+
+		skill, err := buildSkill(skillType, definition)
+		if err != nil {
+			return fmt.Errorf("skill %q: %w", definition.ID, err)
+		}
+		if skill.Summon.Count > 0 {
+			return fmt.Errorf("skill %q: summon is only supported on enemy skills", definition.ID)
+		}
+		content.Skills[skillType] = skill
+	}
+	return nil
+}
+
+func buildSkill(skillType SkillType, definition skillDefinition) (Skill, error) {
+	if strings.TrimSpace(definition.Name) == "" {
+		return Skill{}, fmt.Errorf("missing required field name")
+	}
+	if definition.Cooldown < 0 || definition.ManaCost < 0 {
+		return Skill{}, fmt.Errorf("cooldown and mana_cost must be >= 0")
+	}
+
+	targetType, err := parseContentName("targeting type", definition.Targeting.Type, targetTypeNames)
+	if err != nil {
+		return Skill{}, err
+	}
+	if definition.Targeting.Radius < 0 || definition.Targeting.MaxTargets < 0 || definition.Targeting.ArcDegrees < 0 || definition.Targeting.LineWidth < 0 {
+		return Skill{}, fmt.Errorf("targeting radius, max_targets, arc_degrees and line_width must be >= 0")
+	}
+
+	deliveryType, err := parseContentName("delivery type", definition.Delivery.Type, deliveryTypeNames)
+	if err != nil {
+		return Skill{}, err
+	}
+	delivery := definition.Delivery
+	if deliveryType == DeliveryProjectile && (delivery.Speed <= 0 || delivery.Lifetime <= 0) {
+		return Skill{}, fmt.Errorf("projectile delivery requires speed and lifetime > 0")
+	}
+	if delivery.Delay < 0 || delivery.Pierce < 0 || delivery.ProjectileRadius < 0 || delivery.ZoneDuration < 0 || delivery.ZoneTickRate < 0 {
+		return Skill{}, fmt.Errorf("delivery delay, pierce, projectile_radius and zone timings must be >= 0")
+	}
+
+	skill := Skill{
+		Type:     skillType,
+		Name:     definition.Name,
+		Cooldown: definition.Cooldown,
+		ManaCost: definition.ManaCost,
+		Targeting: TargetingSpec{
+			Type:                  targetType,
+			Range:                 definition.Targeting.Range,
+			Radius:                definition.Targeting.Radius,
+			MaxTargets:            definition.Targeting.MaxTargets,
+			DirectionalArcDegrees: definition.Targeting.ArcDegrees,
+			DirectionalLineWidth:  definition.Targeting.LineWidth,
+		},
+		Delivery: DeliverySpec{
+			Type:             deliveryType,
+			Speed:            delivery.Speed,
+			Delay:            delivery.Delay,
+			Lifetime:         delivery.Lifetime,
+			Pierce:           delivery.Pierce,
+			PierceTerrain:    delivery.PierceTerrain,
+			ProjectileRadius: delivery.ProjectileRadius,
+			ZoneDuration:     delivery.ZoneDuration,
+			ZoneTickRate:     delivery.ZoneTickRate,
+		},
+	}
+
+	if definition.Damage != nil {
+		damage, err := buildDamageSpec(*definition.Damage)
+		if err != nil {
+			return Skill{}, err
+		}
+		skill.DamageSpec = &damage
+	}
+	skill.Effects, err = BuildEffectSpecs(definition.Effects)
+	if err != nil {
+		return Skill{}, err
+	}
+	if definition.SelfMovement != nil {
+		mode, err := parseContentName("self movement mode", definition.SelfMovement.Mode, selfMovementModeNames)
+		if err != nil {
+			return Skill{}, err
+		}
+		if definition.SelfMovement.Distance < 0 {
+			return Skill{}, fmt.Errorf("self_movement distance must be >= 0")
+		}
+		skill.SelfMovement = SelfMovementSpec{Mode: mode, Distance: definition.SelfMovement.Distance}
+	}
+	if definition.ManaShield != nil {
+		if definition.ManaShield.AbsorbFromCurrentManaRatio < 0 || definition.ManaShield.Duration < 0 {
+			return Skill{}, fmt.Errorf("mana_shield values must be >= 0")
+		}
+		skill.ManaShield = ManaShieldSpec{
+			AbsorbFromCurrentManaRatio: definition.ManaShield.AbsorbFromCurrentManaRatio,
+			Duration:                   definition.ManaShield.Duration,
+		}
+	}
+	if definition.ResourceGain != nil {
+		if definition.ResourceGain.ManaPerTarget < 0 {
+			return Skill{}, fmt.Errorf("resource_gain mana_per_target must be >= 0")
+		}
+		skill.ResourceGain = ResourceGainSpec{ManaPerTarget: definition.ResourceGain.ManaPerTarget}
+	}
+	if definition.Knockback != nil {
+		if definition.Knockback.Impulse < 0 {
+			return Skill{}, fmt.Errorf("knockback impulse must be >= 0")
+		}
+		skill.Knockback = KnockbackSpec{Impulse: definition.Knockback.Impulse}
+	}
+	if definition.Heal != nil {
+		if definition.Heal.Amount < 0 || definition.Heal.PercentMaxHP < 0 {
+			return Skill{}, fmt.Errorf("heal values must be >= 0")
+		}
+		skill.Heal = HealSpec{Amount: definition.Heal.Amount, PercentMaxHP: definition.Heal.PercentMaxHP}
+	}
+	if definition.Summon != nil {
+		archetype, err := parseContentName("enemy archetype", definition.Summon.Archetype, enemyArchetypeNames)
+		if err != nil {
+			return Skill{}, err
+		}
+		if definition.Summon.Count <= 0 {
+			return Skill{}, fmt.Errorf("summon count must be > 0")
+		}
+		skill.Summon = SummonSpec{Archetype: archetype, Count: definition.Summon.Count}
+	}
+	return skill, nil
+}
+
+func buildDamageSpec(definition damageDefinition) (DamageSpec, error) {
+	damageType, err := parseContentName("damage type", definition.Type, damageTypeNames)
+	if err != nil {
+		return DamageSpec{}, err
+	}
+	if definition.Base < 0 {
+		return DamageSpec{}, fmt.Errorf("damage base must be >= 0")
+	}
+	if definition.CritChance < 0 || definition.CritChance > 1 || definition.CritMult < 0 {
+		return DamageSpec{}, fmt.Errorf("damage crit_chance must be within [0,1] and crit_mult >= 0")
+	}
+	scaling := make(map[StatType]float32, len(definition.Scaling))
+	for name, value := range definition.Scaling {
+		statType, err := parseContentName("stat", name, statTypeNames)
+		if err != nil {
+			return DamageSpec{}, err
+		}
+		scaling[statType] = value
+	}
+	return DamageSpec{
+		Base:       definition.Base,
+		Scaling:    scaling,
+		DamageType: damageType,
+		CritChance: definition.CritChance,
+		CritMult:   definition.CritMult,
+	}, nil
+}
+
+func BuildEffectSpecs(definitions []EffectDefinition) ([]EffectSpec, error) {
+	if len(definitions) == 0 {
+		return nil, nil
+	}
+	effects := make([]EffectSpec, 0, len(definitions))
+	for _, definition := range definitions {
+		effectType, err := parseContentName("effect", definition.Type, effectTypeNames)
+		if err != nil {
+			return nil, err
+		}
+		if definition.Duration <= 0 {
+			return nil, fmt.Errorf("effect %q duration must be > 0", definition.Type)
+		}
+		if definition.TickRate < 0 || definition.PercentMaxHPPerTick < 0 || definition.MinTickDamage < 0 || definition.MaxTickDamage < 0 {
+			return nil, fmt.Errorf("effect %q tick values must be >= 0", definition.Type)
+		}
+		if definition.MaxTickDamage > 0 && definition.MaxTickDamage < definition.MinTickDamage {
+			return nil, fmt.Errorf("effect %q max_tick_damage must be >= min_tick_damage", definition.Type)
+		}
+		effects = append(effects, EffectSpec{
+			Type:                effectType,
+			Duration:            definition.Duration,
+			Magnitude:           definition.Magnitude,
+			TickRate:            definition.TickRate,
+			PercentMaxHPPerTick: definition.PercentMaxHPPerTick,
+			MinTickDamage:       definition.MinTickDamage,
+			MaxTickDamage:       definition.MaxTickDamage,
+		})
+	}
+	return effects, nil
+}
+
+func applyEnemiesFile(data []byte, content *Content) error {
+	var file enemiesFile
+	if err := decodeContentFile(data, &file); err != nil {
+		return err
+	}
+	seenArchetypes := map[EnemyArchetypeType]bool{}
+	for _, definition := range file.Archetypes {
+		archetypeType, err := parseContentName("enemy archetype", definition.ID, enemyArchetypeNames)
+		if err != nil {
+			return err
+		}
+		if seenArchetypes[archetypeType] {
+			return fmt.Errorf("duplicate enemy archetype %q", definition.ID)
+		}
+		seenArchetypes[archetypeType] = true
+
+		archetype, err := buildEnemyArchetype(archetypeType, definition)
+		if err != nil {
+			return fmt.Errorf("enemy archetype %q: %w", definition.ID, err)
+		}
+		content.EnemyArchetypes[archetypeType] = archetype
+	}
+
+	seenModifiers := map[EliteModifierType]bool{}
+	for _, definition := range file.EliteModifiers {
+		modifierType, err := parseContentName("elite modifier", definition.ID, eliteModifierNames)
+		if err != nil {
+			return err
+		}
+		if seenModifiers[modifierType] {
+			return fmt.Errorf("duplicate elite modifier %q", definition.ID)
+		}
+		seenModifiers[modifierType] = true
+
+		if strings.TrimSpace(definition.Name) == "" {
+			return fmt.Errorf("elite modifier %q: missing required field name", definition.ID)
+		}
+		if definition.HPMultiplier <= 0 || definition.DmgMultiplier <= 0 {
+			return fmt.Errorf("elite modifier %q: hp_multiplier and damage_multiplier must be > 0", definition.ID)
+		}
+		effects, err := BuildEffectSpecs(definition.OnHitEffects)
+		if err != nil {
+			return fmt.Errorf("elite modifier %q: %w", definition.ID, err)
+		}
+		content.EliteModifiers[modifierType] = EliteModifier{
+			Type:          modifierType,
+			Name:          definition.Name,
+			HPMultiplier:  definition.HPMultiplier,
+			DmgMultiplier: definition.DmgMultiplier,
+			OnHitEffects:  effects,
+		}
+	}
+	return nil
+}
+
+func buildEnemyArchetype(archetypeType EnemyArchetypeType, definition enemyArchetypeDefinition) (EnemyArchetype, error) {
+	if strings.TrimSpace(definition.Name) == "" {
+		return EnemyArchetype{}, fmt.Errorf("missing required field name")
+	}
+	if definition.MaxHP <= 0 || definition.MoveSpeed <= 0 || definition.AttackCooldown <= 0 || definition.AttackRange <= 0 || definition.Width <= 0 || definition.Height <= 0 {
+		return EnemyArchetype{}, fmt.Errorf("max_hp, move_speed, attack_cooldown, attack_range, width and height must be > 0")
+	}
+	if definition.Damage < 0 || definition.AggroRange < 0 || definition.PreferredRange < 0 || definition.RetreatRange < 0 || (definition.XPReward != nil && *definition.XPReward < 0) || definition.ThreatValue < 0 {
+		return EnemyArchetype{}, fmt.Errorf("damage, ranges, xp_reward and threat_value must be >= 0")
+	}
+	attackMode, err := parseContentName("attack mode", definition.AttackMode, enemyAttackModeNames)
+	if err != nil {
+		return EnemyArchetype{}, err
+	}
+	if attackMode == EnemyAttackProjectile && (definition.ProjectileSpeed <= 0 || definition.ProjectileLifetime <= 0) {
+		return EnemyArchetype{}, fmt.Errorf("projectile attack mode requires projectile_speed and projectile_lifetime > 0")
+	}
+	damageType, err := parseContentName("damage type", definition.DamageType, damageTypeNames)
+	if err != nil {
+		return EnemyArchetype{}, err
+	}
+	effects, err := BuildEffectSpecs(definition.OnHitEffects)
+	if err != nil {
+		return EnemyArchetype{}, err
+	}
+	skills, err := buildEnemySkills(definition.Skills)
+	if err != nil {
+		return EnemyArchetype{}, err
+	}
+	resistances, err := buildResistanceProfile(definition.Resistances)
+	if err != nil {
+		return EnemyArchetype{}, err
+	}
+	defense, err := buildDefenseStats(definition.Defense)
+	if err != nil {
+		return EnemyArchetype{}, err
+	}
+	xpReward := DefaultEnemyXPReward
+	if definition.XPReward != nil {
+		xpReward = *definition.XPReward
+	}
+
+	return EnemyArchetype{
+		Type:               archetypeType,
+		Name:               definition.Name,
+		Role:               definition.Role,
+		MaxHP:              definition.MaxHP,
+		Damage:             definition.Damage,
+		MoveSpeed:          definition.MoveSpeed,
+		AttackCooldown:     definition.AttackCooldown,
+		AttackRange:        definition.AttackRange,
+		AggroRange:         definition.AggroRange,
+		PreferredRange:     definition.PreferredRange,
+		RetreatRange:       definition.RetreatRange,
+		Width:              definition.Width,
+		Height:             definition.Height,
+		AttackMode:         attackMode,
+		ProjectileSpeed:    definition.ProjectileSpeed,
+		ProjectileRadius:   definition.ProjectileRadius,
+		ProjectileLifetime: definition.ProjectileLifetime,
+		DamageType:         damageType,
+		OnHitEffects:       effects,
+		Resistances:        resistances,
+		Defense:            defense,
+		XPReward:           xpReward,
+		ThreatValue:        definition.ThreatValue,
+		Skills:             skills,
+	}, nil
+}
+
+func buildEnemySkills(definitions []enemySkillDefinition) ([]EnemySkill, error) {
+	if len(definitions) == 0 {
+		return nil, nil
+	}
+	skills := make([]EnemySkill, 0, len(definitions))
+	seen := map[string]bool{}
+	for _, definition := range definitions {
+		id := strings.TrimSpace(definition.ID)
+		if id == "" {
+			return nil, fmt.Errorf("skill %q: missing required field id", definition.Name)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate skill %q", id)
+		}
+		seen[id] = true
+
+		skill, err := buildSkill(SkillTypeEnemy, definition.skillDefinition)
+		if err != nil {
+			return nil, fmt.Errorf("skill %q: %w", id, err)
+		}
+		use, err := buildEnemySkillUse(definition.Use)
+		if err != nil {
+			return nil, fmt.Errorf("skill %q: %w", id, err)
+		}
+		skills = append(skills, EnemySkill{ID: id, Skill: skill, Use: use})
+	}
+	return skills, nil
+}
+
+func buildEnemySkillUse(definition enemySkillUseDefinition) (EnemySkillUse, error) {
+	if definition.MinRange < 0 || definition.MaxRange < 0 || definition.MinAllies < 0 || definition.MaxAllies < 0 {
+		return EnemySkillUse{}, fmt.Errorf("use ranges and ally counts must be >= 0")
+	}
+	if definition.SelfHPBelow < 0 || definition.SelfHPBelow > 1 || definition.AllyHPBelow < 0 || definition.AllyHPBelow > 1 {
+		return EnemySkillUse{}, fmt.Errorf("use self_hp_below and ally_hp_below must be within [0,1]")
+	}
+	if definition.MaxRange > 0 && definition.MaxRange < definition.MinRange {
+		return EnemySkillUse{}, fmt.Errorf("use max_range must be >= min_range")
+	}
+	if definition.MaxAllies > 0 && definition.MaxAllies < definition.MinAllies {
+		return EnemySkillUse{}, fmt.Errorf("use max_allies must be >= min_allies")
+	}
+	return EnemySkillUse{
+		MinRange:      definition.MinRange,
+		MaxRange:      definition.MaxRange,
+		SelfHPBelow:   definition.SelfHPBelow,
+		AllyHPBelow:   definition.AllyHPBelow,
+		MinAllies:     definition.MinAllies,
+		MaxAllies:     definition.MaxAllies,
+		PlayerCasting: definition.PlayerCasting,
+	}, nil
+}
+
+func applyItemsFile(data []byte, content *Content) error {
+	var file itemsFile
+	if err := decodeContentFile(data, &file); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, definition := range file.Items {
+		id := strings.TrimSpace(definition.ID)
+		if id == "" {
+			return fmt.Errorf("item %q: missing required field id", definition.Name)
+		}
+		if seen[id] {
+			return fmt.Errorf("duplicate item %q", id)
+		}
+		seen[id] = true
+
+		item, err := buildItem(definition)
+		if err != nil {
+			return fmt.Errorf("item %q: %w", id, err)
+		}
+		content.ItemPools[item.Biome] = upsertItem(content.ItemPools[item.Biome], item)
+	}
+	return nil
+}
+
+func buildItem(definition itemDefinition) (*Item, error) {
+	if strings.TrimSpace(definition.Name) == "" {
+		return nil, fmt.Errorf("missing required field name")
+	}
+	slot, err := parseContentName("item slot", definition.Slot, itemSlotNames)
+	if err != nil {
+		return nil, err
+	}
+	classRestriction, err := parseContentName("class", definition.Class, classTypeNames)
+	if err != nil {
+		return nil, err
+	}
+	if definition.Weight < 0 {
+		return nil, fmt.Errorf("weight must be >= 0")
+	}
+	bonuses, err := parseStatValues(definition.Stats)
+	if err != nil {
+		return nil, err
+	}
+	flavorTags := make([]ClassType, 0, len(definition.FlavorTags))
+	for _, name := range definition.FlavorTags {
+		classType, err := parseContentName("class", name, classTypeNames)
+		if err != nil {
+			return nil, err
+		}
+		flavorTags = append(flavorTags, classType)
+	}
+	effects, err := buildItemEffects(definition.Effects)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCuratedItem(definition.ID, definition.Name, definition.Description, slot, bonuses, classRestriction, ItemMetadata{
+		Biome:      definition.Biome,
+		Weight:     definition.Weight,
+		FlavorTags: flavorTags,
+		Effects:    effects,
+	}), nil
+}
+
+func buildItemEffects(definitions []itemEffectDefinition) ([]ItemEffect, error) {
+	effects := make([]ItemEffect, 0, len(definitions))
+	for _, definition := range definitions {
+		trigger, err := parseContentName("item trigger", definition.Trigger, itemTriggerNames)
+		if err != nil {
+			return nil, err
+		}
+		if definition.Interval < 0 || (trigger == ItemTriggerPeriodic && definition.Interval == 0) {
+			return nil, fmt.Errorf("item trigger %q interval must be > 0", definition.Trigger)
+		}
+		conditions, err := buildItemConditions(definition.Conditions)
+		if err != nil {
+			return nil, err
+		}
+		action, err := buildItemAction(definition.Action)
+		if err != nil {
+			return nil, err
+		}
+		if action.Type == ItemActionCritChance && trigger != ItemTriggerOnAttack {
+			return nil, fmt.Errorf("item action %q requires trigger on_attack", definition.Action.Type)
+		}
+		effects = append(effects, ItemEffect{
+			Trigger:    trigger,
+			Interval:   definition.Interval,
+			Conditions: conditions,
+			Action:     action,
+		})
+	}
+	return effects, nil
+}
+
+func buildItemConditions(definitions []itemConditionDefinition) ([]ItemCondition, error) {
+	if len(definitions) == 0 {
+		return nil, nil
+	}
+	conditions := make([]ItemCondition, 0, len(definitions))
+	for _, definition := range definitions {
+		conditionType, err := parseContentName("item condition", definition.Type, itemConditionNames)
+		if err != nil {
+			return nil, err
+		}
+		condition := ItemCondition{Type: conditionType, Value: definition.Value}
+		if conditionType == ItemConditionTargetHasEffect {
+			if len(definition.Effects) == 0 {
+				return nil, fmt.Errorf("item condition %q requires effects", definition.Type)
+			}
+			for _, name := range definition.Effects {
+				effectType, err := parseContentName("effect", name, effectTypeNames)
+				if err != nil {
+					return nil, err
+				}
+				condition.Effects = append(condition.Effects, effectType)
+			}
+		} else if definition.Value <= 0 || definition.Value > 1 {
+			return nil, fmt.Errorf("item condition %q value must be within (0,1]", definition.Type)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+func buildItemAction(definition itemActionDefinition) (ItemAction, error) {
+	actionType, err := parseContentName("item action", definition.Type, itemActionNames)
+	if err != nil {
+		return ItemAction{}, err
+	}
+	if definition.Amount < 0 || definition.PercentOfDamage < 0 || definition.Radius < 0 || definition.Speed < 0 {
+		return ItemAction{}, fmt.Errorf("item action %q values must be >= 0", definition.Type)
+	}
+	action := ItemAction{
+		Type:            actionType,
+		Amount:          definition.Amount,
+		PercentOfDamage: definition.PercentOfDamage,
+		OnSelf:          definition.OnSelf,
+		Radius:          definition.Radius,
+		Speed:           definition.Speed,
+	}
+	if definition.DamageType != "" {
+		action.DamageType, err = parseContentName("damage type", definition.DamageType, damageTypeNames)
+		if err != nil {
+			return ItemAction{}, err
+		}
+	}
+
+	switch actionType {
+	case ItemActionApplyEffect:
+		if definition.Effect == nil {
+			return ItemAction{}, fmt.Errorf("item action %q requires effect", definition.Type)
+		}
+		specs, err := BuildEffectSpecs([]EffectDefinition{*definition.Effect})
+		if err != nil {
+			return ItemAction{}, err
+		}
+		action.Effect = specs[0]
+	case ItemActionAreaDamage:
+		if action.Radius <= 0 {
+			return ItemAction{}, fmt.Errorf("item action %q radius must be > 0", definition.Type)
+		}
+	case ItemActionSpawnProjectile:
+		if action.Speed <= 0 {
+			return ItemAction{}, fmt.Errorf("item action %q speed must be > 0", definition.Type)
+		}
+	}
+	if action.Amount == 0 && action.PercentOfDamage == 0 && actionType != ItemActionApplyEffect {
+		return ItemAction{}, fmt.Errorf("item action %q requires amount or percent_of_damage", definition.Type)
+	}
+	return action, nil
+}
+
+func upsertItem(pool []*Item, item *Item) []*Item {
+	for i, existing := range pool {
+		if existing != nil && existing.ID == item.ID {
+			pool[i] = item
+			return pool
+		}
+	}
+	return append(pool, item)
+}
+
+func applyBossesFile(data []byte, content *Content) error {
+	var file bossesFile
+	if err := decodeContentFile(data, &file); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, definition := range file.Bosses {
+		biome := normalizeBiome(definition.Biome)
+		if seen[biome] {
+			return fmt.Errorf("duplicate boss for biome %q", biome)
+		}
+		seen[biome] = true
+
+		cfg, err := buildBossEncounter(definition)
+		if err != nil {
+			return fmt.Errorf("boss %q: %w", definition.ID, err)
+		}
+		content.BossEncounters[cfg.Biome] = cfg
+	}
+	return nil
+}
+
+func buildBossEncounter(definition bossDefinition) (BossEncounterConfig, error) {
+	if strings.TrimSpace(definition.ID) == "" {
+		return BossEncounterConfig{}, fmt.Errorf("missing required field id")
+	}
+	if definition.MaxHP <= 1 || definition.Damage <= 0 || definition.MoveSpeed <= 0 || definition.AttackCooldown <= 0 || definition.AttackRange <= 0 || definition.Width <= 0 || definition.Height <= 0 {
+		return BossEncounterConfig{}, fmt.Errorf("max_hp must be > 1 and damage, move_speed, attack_cooldown, attack_range, width and height must be > 0")
+	}
+	heavy := definition.HeavyAttack
+	if heavy.TelegraphDuration <= 0 || heavy.Cooldown <= 0 || heavy.Radius <= 0 || heavy.Damage <= 0 {
+		return BossEncounterConfig{}, fmt.Errorf("heavy_attack values must be > 0")
+	}
+	heavyDamageType, err := parseContentName("damage type", heavy.DamageType, damageTypeNames)
+	if err != nil {
+		return BossEncounterConfig{}, fmt.Errorf("heavy_attack: %w", err)
+	}
+	area := definition.AreaDenial
+	if area.Cooldown <= 0 || area.WarningDuration <= 0 || area.ActiveDuration <= 0 || area.TickRate <= 0 || area.Radius <= 0 || area.Damage <= 0 || area.ZoneCount <= 0 || area.SpawnDistance < 0 {
+		return BossEncounterConfig{}, fmt.Errorf("area_denial values must be > 0 and spawn_distance >= 0")
+	}
+	areaDamageType, err := parseContentName("damage type", area.DamageType, damageTypeNames)
+	if err != nil {
+		return BossEncounterConfig{}, fmt.Errorf("area_denial: %w", err)
+	}
+	areaEffects, err := BuildEffectSpecs(area.Effects)
+	if err != nil {
+		return BossEncounterConfig{}, fmt.Errorf("area_denial: %w", err)
+	}
+	enrage := definition.Enrage
+	if enrage.ThresholdHPPercent <= 0 || enrage.ThresholdHPPercent >= 1 {
+		return BossEncounterConfig{}, fmt.Errorf("enrage threshold_hp_percent must be within (0,1)")
+	}
+	if enrage.MoveSpeedMultiplier <= 0 || enrage.DamageMultiplier <= 0 || enrage.HeavyCooldownMultiplier <= 0 || enrage.AreaCooldownMultiplier <= 0 || enrage.ZoneCountBonus < 0 {
+		return BossEncounterConfig{}, fmt.Errorf("enrage multipliers must be > 0 and zone_count_bonus >= 0")
+	}
+	ccResistance, err := buildBossCCResistance(definition.CCResistance)
+	if err != nil {
+		return BossEncounterConfig{}, err
+	}
+	resistances, err := buildResistanceProfile(definition.Resistances)
+	if err != nil {
+		return BossEncounterConfig{}, err
+	}
+	defense, err := buildDefenseStats(definition.Defense)
+	if err != nil {
+		return BossEncounterConfig{}, err
+	}
+	volleys, err := buildBossVolleys(definition.Volleys)
+	if err != nil {
+		return BossEncounterConfig{}, err
+	}
+	phases, err := buildBossPhases(definition.Phases, volleys)
+	if err != nil {
+		return BossEncounterConfig{}, err
+	}
+
+	return BossEncounterConfig{
+		ID:                  strings.TrimSpace(definition.ID),
+		Biome:               normalizeBiome(definition.Biome),
+		MaxHP:               definition.MaxHP,
+		Damage:              definition.Damage,
+		MoveSpeed:           definition.MoveSpeed,
+		AttackCooldown:      definition.AttackCooldown,
+		AttackRange:         definition.AttackRange,
+		AggroRange:          definition.AggroRange,
+		Width:               definition.Width,
+		Height:              definition.Height,
+		TargetFightDuration: definition.TargetFightDuration,
+		HeavyAttack: BossHeavyAttackConfig{
+			TelegraphDuration: heavy.TelegraphDuration,
+			Cooldown:          heavy.Cooldown,
+			Radius:            heavy.Radius,
+			Damage:            heavy.Damage,
+			DamageType:        heavyDamageType,
+		},
+		AreaDenial: BossAreaDenialConfig{
+			Cooldown:        area.Cooldown,
+			WarningDuration: area.WarningDuration,
+			ActiveDuration:  area.ActiveDuration,
+			TickRate:        area.TickRate,
+			Radius:          area.Radius,
+			Damage:          area.Damage,
+			DamageType:      areaDamageType,
+			Effects:         areaEffects,
+			ZoneCount:       area.ZoneCount,
+			SpawnDistance:   area.SpawnDistance,
+		},
+		Enrage: BossEnrageConfig{
+			ThresholdHPPercent:      enrage.ThresholdHPPercent,
+			MoveSpeedMultiplier:     enrage.MoveSpeedMultiplier,
+			DamageMultiplier:        enrage.DamageMultiplier,
+			HeavyCooldownMultiplier: enrage.HeavyCooldownMultiplier,
+			AreaCooldownMultiplier:  enrage.AreaCooldownMultiplier,
+			ZoneCountBonus:          enrage.ZoneCountBonus,
+		},
+		CCResistance: ccResistance,
+		Resistances:  resistances,
+		Defense:      defense,
+		Volleys:      volleys,
+		Phases:       phases,
+	}, nil
+}
+
+// buildDefenseStats reads the dodge and block a content entry brings to incoming hits; all three are fractions.
+func buildDefenseStats(definition *defenseDefinition) (DefenseStats, error) {
+	if definition == nil {
+		return DefenseStats{}, nil
+	}
+	for _, value := range []float32{definition.DodgeChance, definition.BlockChance, definition.BlockAmount} {
+		if value < 0 || value > 1 {
+			return DefenseStats{}, fmt.Errorf("defense dodge_chance, block_chance and block_amount must be within [0,1]")
+		}
+	}
+	return DefenseStats{
+		DodgeChance: definition.DodgeChance,
+		BlockChance: definition.BlockChance,
+		BlockAmount: definition.BlockAmount,
+	}, nil
+}
+
+// buildResistanceProfile reads damage type names to resistance fractions; negative values are weaknesses.
+func buildResistanceProfile(values map[string]float32) (ResistanceProfile, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	profile := make(ResistanceProfile, len(values))
+	for name, value := range values {
+		damageType, err := parseContentName("damage type", name, damageTypeNames)
+		if err != nil {
+			return nil, fmt.Errorf("resistances: %w", err)
+		}
+		if damageType == DamageTrue {
+			return nil, fmt.Errorf("resistances: true damage cannot be resisted")
+		}
+		if value < -MaxDamageWeakness || value > MaxDamageResistance {
+			return nil, fmt.Errorf("resistances: %s must be within [%v,%v]", name, -MaxDamageWeakness, MaxDamageResistance)
+		}
+		profile[damageType] = value
+	}
+	return profile, nil
+}
+
+func buildBossCCResistance(definition *bossCCResistanceDefinition) (BossCCResistanceConfig, error) {
+	if definition == nil {
+		return BossCCResistanceConfig{DurationMultiplier: 1}, nil
+	}
+	if definition.DurationMultiplier < 0 {
+		return BossCCResistanceConfig{}, fmt.Errorf("cc_resistance duration_multiplier must be >= 0")
+	}
+	resistance := BossCCResistanceConfig{DurationMultiplier: definition.DurationMultiplier}
+	if resistance.DurationMultiplier == 0 {
+		resistance.DurationMultiplier = 1
+	}
+	for _, name := range definition.Immune {
+		category, err := parseContentName("cc category", name, ccCategoryNames)
+		if err != nil {
+			return BossCCResistanceConfig{}, fmt.Errorf("cc_resistance: %w", err)
+		}
+		resistance.Immune = append(resistance.Immune, category)
+	}
+	return resistance, nil
+}
+
+func buildBossVolleys(definitions []bossVolleyDefinition) ([]BossVolleyConfig, error) {
+	if len(definitions) == 0 {
+		return nil, nil
+	}
+	volleys := make([]BossVolleyConfig, 0, len(definitions))
+	seen := map[string]bool{}
+	for _, definition := range definitions {
+		id := strings.TrimSpace(definition.ID)
+		if id == "" {
+			return nil, fmt.Errorf("volley: missing required field id")
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate volley %q", id)
+		}
+		seen[id] = true
+		if definition.Count <= 0 || definition.Speed <= 0 || definition.Damage <= 0 || definition.Cooldown <= 0 {
+			return nil, fmt.Errorf("volley %q: count, speed, damage and cooldown must be > 0", id)
+		}
+		if definition.Waves < 0 || definition.Cadence < 0 || definition.Radius < 0 || definition.Arc < 0 || definition.Arc > 360 {
+			return nil, fmt.Errorf("volley %q: waves, cadence and radius must be >= 0 and arc within [0,360]", id)
+		}
+		pattern, err := parseContentName("volley pattern", definition.Pattern, bossVolleyPatternNames)
+		if err != nil {
+			return nil, fmt.Errorf("volley %q: %w", id, err)
+		}
+		damageType, err := parseContentName("damage type", definition.DamageType, damageTypeNames)
+		if err != nil {
+			return nil, fmt.Errorf("volley %q: %w", id, err)
+		}
+		effects, err := BuildEffectSpecs(definition.Effects)
+		if err != nil {
+			return nil, fmt.Errorf("volley %q: %w", id, err)
+		}
+		volleys = append(volleys, BossVolleyConfig{
+			ID:         id,
+			Pattern:    pattern,
+			Count:      definition.Count,
+			Waves:      max(definition.Waves, 1),
+			Cadence:    definition.Cadence,
+			Cooldown:   definition.Cooldown,
+			Speed:      definition.Speed,
+			Radius:     definition.Radius,
+			Arc:        definition.Arc,
+			Spin:       definition.Spin,
+			Damage:     definition.Damage,
+			DamageType: damageType,
+			Effects:    effects,
+		})
+	}
+	return volleys, nil
+}
+
+// parseBossRotationStep reads a rotation entry: "heavy", "area" or "volley:<id>" naming one of the boss volleys.
+func parseBossRotationStep(value string, volleys []BossVolleyConfig) (BossRotationStep, error) {
+	if id, ok := strings.CutPrefix(strings.TrimSpace(value), "volley:"); ok {
+		for _, volley := range volleys {
+			if volley.ID == id {
+				return BossRotationStep{Attack: BossAttackVolley, Volley: id}, nil
+			}
+		}
+		return BossRotationStep{}, fmt.Errorf("unknown volley %q", id)
+	}
+	kind, err := parseContentName("boss attack", value, bossAttackKindNames)
+	if err != nil {
+		return BossRotationStep{}, err
+	}
+	return BossRotationStep{Attack: kind}, nil
+}
+
+func buildBossPhases(definitions []bossPhaseDefinition, volleys []BossVolleyConfig) ([]BossPhaseConfig, error) {
+	if len(definitions) == 0 {
+		return nil, nil
+	}
+	phases := make([]BossPhaseConfig, 0, len(definitions))
+	for i, definition := range definitions {
+		name := strings.TrimSpace(definition.Name)
+		if name == "" {
+			return nil, fmt.Errorf("phase %d: missing required field name", i)
+		}
+		if definition.HPThresholdPercent < 0 || definition.HPThresholdPercent >= 1 || definition.TimeThreshold < 0 {
+			return nil, fmt.Errorf("phase %q: hp_threshold_percent must be within [0,1) and time_threshold >= 0", name)
+		}
+		if i > 0 && definition.HPThresholdPercent == 0 && definition.TimeThreshold == 0 {
+			return nil, fmt.Errorf("phase %q: needs hp_threshold_percent or time_threshold", name)
+		}
+		if definition.RotationInterval < 0 || definition.InvulnerableDuration < 0 || definition.DamageMultiplier < 0 || definition.MoveSpeedMultiplier < 0 {
+			return nil, fmt.Errorf("phase %q: rotation_interval, invulnerable_duration and multipliers must be >= 0", name)
+		}
+		var rotation []BossRotationStep
+		for _, attack := range definition.Rotation {
+			step, err := parseBossRotationStep(attack, volleys)
+			if err != nil {
+				return nil, fmt.Errorf("phase %q: %w", name, err)
+			}
+			rotation = append(rotation, step)
+		}
+		var waves []BossAddWaveConfig
+		for _, wave := range definition.AddWaves {
+			if wave.Delay < 0 || wave.Count <= 0 || len(wave.Archetypes) == 0 {
+				return nil, fmt.Errorf("phase %q: add waves need delay >= 0, count > 0 and archetypes", name)
+			}
+			archetypes := make([]EnemyArchetypeType, 0, len(wave.Archetypes))
+			for _, id := range wave.Archetypes {
+				archetype, err := parseContentName("enemy archetype", id, enemyArchetypeNames)
+				if err != nil {
+					return nil, fmt.Errorf("phase %q: %w", name, err)
+				}
+				archetypes = append(archetypes, archetype)
+			}
+			waves = append(waves, BossAddWaveConfig{Delay: wave.Delay, Count: wave.Count, Archetypes: archetypes})
+		}
+		phases = append(phases, BossPhaseConfig{
+			Name:                 name,
+			HPThresholdPercent:   definition.HPThresholdPercent,
+			TimeThreshold:        definition.TimeThreshold,
+			Rotation:             rotation,
+			RotationInterval:     definition.RotationInterval,
+			AddWaves:             waves,
+			InvulnerableDuration: definition.InvulnerableDuration,
+			DamageMultiplier:     definition.DamageMultiplier,
+			MoveSpeedMultiplier:  definition.MoveSpeedMultiplier,
+		})
+	}
+	return phases, nil
+}
+
+func parseStatValues(values map[string]int) (map[StatType]int, error) {
+	out := make(map[StatType]int, len(values))
+	for name, value := range values {
+		statType, err := parseContentName("stat", name, statTypeNames)
+		if err != nil {
+			return nil, err
+		}
+		out[statType] = value
+	}
+	return out, nil
+}
@@ -0,0 +1,285 @@
+package gamedata
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShippedContentMatchesBuiltinTables(t *testing.T) {
+	content, err := LoadContent(DefaultDataRoot)
+	if err != nil {
+		t.Fatalf("expected shipped content to load, got %v", err)
+	}
+	if !reflect.DeepEqual(content, BuiltinContent()) {
+		t.Fatalf("expected assets/data to mirror the built-in tables")
+	}
+}
+
+func TestLoadContentOverridesAndExtendsBuiltins(t *testing.T) {
+	root := t.TempDir()
+	writeContentFile(t, root, SkillsFile, `{"skills": [{
+		"id": "power_strike", "name": "Heavy Strike", "cooldown": 5, "mana_cost": 0,
+		"targeting": {"type": "enemy", "range": 70, "max_targets": 1},
+		"delivery": {"type": "instant"},
+		"damage": {"base": 42, "scaling": {"str": 2}, "type": "physical"},
+		"knockback": {"impulse": 90}
+	}]}`)
+	writeContentFile(t, root, ItemsFile, `{"items": [{
+		"id": "shared_moss_charm", "name": "Moss Charm", "description": "Smells of rain.",
+		"slot": "head", "stats": {"luk": 3}, "class": "any", "biome": "forest", "weight": 6,
+		"effects": [{"trigger": "on_damaged", "conditions": [{"type": "chance", "value": 0.5}, {"type": "self_hp_below", "value": 0.4}],
+			"action": {"type": "apply_effect", "on_self": true, "effect": {"type": "damage_reduction", "duration": 2, "magnitude": 0.25}}}]
+	}]}`)
+
+	content, err := LoadContent(root)
+	if err != nil {
+		t.Fatalf("expected content to load, got %v", err)
+	}
+	ApplyContent(content)
+	t.Cleanup(ResetContent)
+
+	skill := NewSkill(SkillTypePowerStrike)
+	if skill.Name != "Heavy Strike" || skill.Cooldown != 5 || skill.DamageSpec.Base != 42 || skill.Knockback.Impulse != 90 {
+		t.Fatalf("expected power strike override, got %+v", skill)
+	}
+	if NewSkill(SkillTypeQuickShot).Name != "Quick Shot" {
+		t.Fatalf("expected skills missing from the file to keep built-in values")
+	}
+	item := GetItemByID("shared_moss_charm")
+	if item == nil || item.StatBonuses[StatTypeLUK] != 3 || len(item.Effects) != 1 {
+		t.Fatalf("expected new item in forest pool, got %+v", item)
+	}
+	effect := item.Effects[0]
+	if effect.Trigger != ItemTriggerOnDamaged || len(effect.Conditions) != 2 || effect.Chance() != 0.5 || !effect.Action.OnSelf || effect.Action.Effect.Type != EffectDamageReduction {
+		t.Fatalf("expected item effect trigger, conditions and action to parse, got %+v", effect)
+	}
+	if CountBiomeItems("forest") != len(buildForestItemPool())+1 {
+		t.Fatalf("expected new item appended to built-in pool")
+	}
+	if GetClass(ClassTypeMelee).Name != "Warrior" {
+		t.Fatalf("expected classes to keep built-in values without classes.json")
+	}
+}
+
+func TestLoadContentParsesEnemyArchetypeSkills(t *testing.T) {
+	root := t.TempDir()
+	writeContentFile(t, root, EnemiesFile, `{"archetypes": [{
+		"id": "hex_caller", "name": "Mender", "role": "Support", "max_hp": 60, "damage": 6, "move_speed": 90,
+		"attack_cooldown": 1.5, "attack_range": 180, "aggro_range": 320, "preferred_range": 160,
+		"width": 28, "height": 28, "attack_mode": "caster_aoe", "damage_type": "magical", "xp_reward": 30, "threat_value": 14,
+		"skills": [
+			{"id": "mend", "name": "Mend", "cooldown": 6, "targeting": {"type": "ally", "range": 220, "max_targets": 1},
+			 "delivery": {"type": "instant"}, "heal": {"amount": 20, "percent_max_hp": 0.1}, "use": {"ally_hp_below": 0.5}},
+			{"id": "call_swarm", "name": "Call Swarm", "cooldown": 12, "targeting": {"type": "self"},
+			 "delivery": {"type": "instant"}, "summon": {"archetype": "swarmling", "count": 2}, "use": {"max_allies": 4, "player_casting": true}}
+		]
+	}]}`)
+
+	content, err := LoadContent(root)
+	if err != nil {
+		t.Fatalf("expected content to load, got %v", err)
+	}
+	ApplyContent(content)
+	t.Cleanup(ResetContent)
+
+	skills := GetEnemyArchetype(EnemyArchetypeHexCaller).Skills
+	if len(skills) != 2 {
+		t.Fatalf("expected two archetype skills, got %d", len(skills))
+	}
+	mend, call := skills[0], skills[1]
+	if mend.ID != "mend" || mend.Skill.Type != SkillTypeEnemy || mend.Skill.Targeting.Type != TargetAlly || mend.Skill.Heal.Amount != 20 || mend.Use.AllyHPBelow != 0.5 {
+		t.Fatalf("expected mend skill, got %+v", mend)
+	}
+	if call.Skill.Summon != (SummonSpec{Archetype: EnemyArchetypeSwarmling, Count: 2}) || call.Use.MaxAllies != 4 || !call.Use.PlayerCasting {
+		t.Fatalf("expected summon skill, got %+v", call)
+	}
+
+	cloned := CloneEnemySkills(skills)
+	cloned[0].Skill.Use()
+	if skills[0].Skill.CurrentCooldown != 0 {
+		t.Fatalf("expected cloned enemy skills to keep separate cooldowns")
+	}
+}
+
+func TestLoadContentDefaultsOnlyOmittedEnemyXPReward(t *testing.T) {
+	root := t.TempDir()
+	writeContentFile(t, root, EnemiesFile, `{"archetypes": [
+		{"id": "raider", "name": "Raider", "max_hp": 60, "damage": 8, "move_speed": 110, "attack_cooldown": 1.2,
+		 "attack_range": 40, "width": 30, "height": 30, "attack_mode": "melee", "damage_type": "physical", "threat_value": 10},
+		{"id": "swarmling", "name": "Swarmling", "max_hp": 20, "damage": 4, "move_speed": 150, "attack_cooldown": 0.8,
+		 "attack_range": 30, "width": 20, "height": 20, "attack_mode": "melee", "damage_type": "physical", "xp_reward": 0, "threat_value": 4}
+	]}`)
+
+	content, err := LoadContent(root)
+	if err != nil {
+		t.Fatalf("expected content to load, got %v", err)
+	}
+	ApplyContent(content)
+	t.Cleanup(ResetContent)
+
+	if reward := GetEnemyArchetype(EnemyArchetypeRaider).XPReward; reward != DefaultEnemyXPReward {
+		t.Fatalf("expected omitted xp_reward to default to %d, got %d", DefaultEnemyXPReward, reward)
+	}
+	if reward := GetEnemyArchetype(EnemyArchetypeSwarmling).XPReward; reward != 0 {
+		t.Fatalf("expected explicit zero xp_reward to be kept, got %d", reward)
+	}
+}
+
+func TestLoadContentParsesBossPhasesAndVolleys(t *testing.T) {
+	root := t.TempDir()
+	writeContentFile(t, root, BossesFile, bossesWithPhases(`[
+		{"name": "opening", "rotation": ["heavy", "volley:spore_ring", "area"], "rotation_interval": 3},
+		{"name": "swarm", "hp_threshold_percent": 0.6, "invulnerable_duration": 1.5, "damage_multiplier": 1.2,
+		 "add_waves": [{"delay": 0.5, "count": 3, "archetypes": ["swarmling", "raider"]}]}
+	]`))
+
+	content, err := LoadContent(root)
+	if err != nil {
+		t.Fatalf("expected content to load, got %v", err)
+	}
+	ApplyContent(content)
+	t.Cleanup(ResetContent)
+
+	phases := GetBossEncounterConfig("forest").Phases
+	if len(phases) != 2 {
+		t.Fatalf("expected two boss phases, got %d", len(phases))
+	}
+	opening, swarm := phases[0], phases[1]
+	if !reflect.DeepEqual(opening.Rotation, []BossRotationStep{{Attack: BossAttackHeavy}, {Attack: BossAttackVolley, Volley: "spore_ring"}, {Attack: BossAttackArea}}) || opening.RotationInterval != 3 || opening.DamageMultiplier != 1 {
+		t.Fatalf("expected opening rotation with default multipliers, got %+v", opening)
+	}
+	if swarm.HPThresholdPercent != 0.6 || swarm.InvulnerableDuration != 1.5 || len(swarm.AddWaves) != 1 {
+		t.Fatalf("expected swarm phase, got %+v", swarm)
+	}
+	resistance := GetBossEncounterConfig("forest").CCResistance
+	if resistance.DurationMultiplier != 0.4 || !reflect.DeepEqual(resistance.Immune, []CCCategory{CCCategoryStun, CCCategoryFreeze}) {
+		t.Fatalf("expected boss cc resistance, got %+v", resistance)
+	}
+	if resistances := GetBossEncounterConfig("forest").Resistances; !reflect.DeepEqual(resistances, ResistanceProfile{DamageFire: -0.5, DamagePoison: 0.3}) {
+		t.Fatalf("expected boss resistance profile, got %+v", resistances)
+	}
+	volley, ok := GetBossEncounterConfig("forest").Volley("spore_ring")
+	if !ok || volley.Pattern != BossVolleyGappedRing || volley.Count != 18 || volley.Radius != 8 || volley.DamageType != DamageMagical || len(volley.Effects) != 1 {
+		t.Fatalf("expected spore ring volley with default radius, got %+v", volley)
+	}
+	if wave := swarm.AddWaves[0]; wave.Count != 3 || !reflect.DeepEqual(wave.Archetypes, []EnemyArchetypeType{EnemyArchetypeSwarmling, EnemyArchetypeRaider}) {
+		t.Fatalf("expected swarm add wave, got %+v", wave)
+	}
+}
+
+func bossesWithPhases(phases string) string {
+	return `{"bosses": [{
+		"id": "forest_warden_alpha", "biome": "forest", "max_hp": 700, "damage": 18, "move_speed": 78,
+		"attack_cooldown": 1.25, "attack_range": 92, "aggro_range": 1000, "width": 72, "height": 72,
+		"heavy_attack": {"telegraph_duration": 1.2, "cooldown": 5.8, "radius": 110, "damage": 34, "damage_type": "physical"},
+		"area_denial": {"cooldown": 7.2, "warning_duration": 1.1, "active_duration": 4.2, "tick_rate": 0.8, "radius": 88,
+			"damage": 8, "damage_type": "magical", "zone_count": 2, "spawn_distance": 130},
+		"enrage": {"threshold_hp_percent": 0.5, "move_speed_multiplier": 1.18, "damage_multiplier": 1.22,
+			"heavy_cooldown_multiplier": 0.72, "area_cooldown_multiplier": 0.7, "zone_count_bonus": 1},
+		"cc_resistance": {"duration_multiplier": 0.4, "immune": ["stun", "freeze"]},
+		"resistances": {"fire": -0.5, "poison": 0.3},
+		"volleys": [{"id": "spore_ring", "pattern": "gapped_ring", "count": 18, "waves": 3, "cadence": 0.6, "cooldown": 7,
+			"speed": 150, "arc": 60, "spin": 25, "damage": 8, "damage_type": "magical", "effects": [{"type": "slow", "duration": 1, "magnitude": 0.2}]}],
+		"phases": ` + phases + `
+	}]}`
+}
+
+func TestLoadContentRejectsInvalidDefinitions(t *testing.T) {
+	cases := map[string]struct {
+		file string
+		body string
+		want string
+	}{
+		"unknown effect": {
+			file: EnemiesFile,
+			body: `{"elite_modifiers": [{"id": "scorching", "name": "Scorching", "hp_multiplier": 1.2, "damage_multiplier": 1.1, "on_hit_effects": [{"type": "ignite", "duration": 2}]}]}`,
+			want: `unsupported effect "ignite"`,
+		},
+		"enemy dodge over one": {
+			file: EnemiesFile,
+			body: `{"archetypes": [{"id": "swarmling", "name": "Swarmling", "max_hp": 20, "damage": 4, "move_speed": 150, "attack_cooldown": 0.8,
+				"attack_range": 30, "width": 20, "height": 20, "attack_mode": "melee", "damage_type": "physical", "threat_value": 4, "defense": {"dodge_chance": 1.5}}]}`,
+			want: "defense dodge_chance, block_chance and block_amount must be within [0,1]",
+		},
+		"unknown field": {
+			file: ClassesFile,
+			body: `{"classes": [], "clases": []}`,
+			want: "unknown field",
+		},
+		"duplicate item": {
+			file: ItemsFile,
+			body: `{"items": [{"id": "a", "name": "A", "slot": "head", "class": "any"}, {"id": "a", "name": "A", "slot": "head", "class": "any"}]}`,
+			want: `duplicate item "a"`,
+		},
+		"periodic item effect without interval": {
+			file: ItemsFile,
+			body: `{"items": [{"id": "a", "name": "A", "slot": "head", "class": "any", "effects": [{"trigger": "periodic", "action": {"type": "restore_mana", "amount": 2}}]}]}`,
+			want: `item trigger "periodic" interval must be > 0`,
+		},
+		"crit chance item effect outside on_attack": {
+			file: ItemsFile,
+			body: `{"items": [{"id": "a", "name": "A", "slot": "head", "class": "any", "effects": [{"trigger": "on_hit", "action": {"type": "crit_chance", "amount": 0.1}}]}]}`,
+			want: `item action "crit_chance" requires trigger on_attack`,
+		},
+		"bad projectile": {
+			file: SkillsFile,
+			body: `{"skills": [{"id": "quick_shot", "name": "Quick Shot", "cooldown": 6, "targeting": {"type": "enemy"}, "delivery": {"type": "projectile"}}]}`,
+			want: "projectile delivery requires speed",
+		},
+		"player summon": {
+			file: SkillsFile,
+			body: `{"skills": [{"id": "quick_shot", "name": "Quick Shot", "cooldown": 6, "targeting": {"type": "self"}, "delivery": {"type": "instant"}, "summon": {"archetype": "raider", "count": 1}}]}`,
+			want: "summon is only supported on enemy skills",
+		},
+		"bad enemy skill use": {
+			file: EnemiesFile,
+			body: `{"archetypes": [{"id": "raider", "name": "Raider", "max_hp": 50, "move_speed": 100, "attack_cooldown": 1, "attack_range": 50, "width": 30, "height": 30, "attack_mode": "melee", "damage_type": "physical",
+				"skills": [{"id": "rally", "name": "Rally", "targeting": {"type": "ally"}, "delivery": {"type": "instant"}, "use": {"self_hp_below": 2}}]}]}`,
+			want: "self_hp_below and ally_hp_below must be within [0,1]",
+		},
+		"resistance out of range": {
+			file: EnemiesFile,
+			body: `{"archetypes": [{"id": "raider", "name": "Raider", "max_hp": 50, "move_speed": 100, "attack_cooldown": 1, "attack_range": 50, "width": 30, "height": 30, "attack_mode": "melee", "damage_type": "physical",
+				"resistances": {"frost": 1.5}}]}`,
+			want: "resistances: frost must be within [-1,0.9]",
+		},
+		"boss phase without threshold": {
+			file: BossesFile,
+			body: bossesWithPhases(`[{"name": "opening"}, {"name": "second", "rotation": ["area"]}]`),
+			want: `phase "second": needs hp_threshold_percent or time_threshold`,
+		},
+		"unknown boss volley": {
+			file: BossesFile,
+			body: bossesWithPhases(`[{"name": "opening", "rotation": ["volley:thorn_burst"]}]`),
+			want: `phase "opening": unknown volley "thorn_burst"`,
+		},
+	}
+	for name, tc := range cases {
+		root := t.TempDir()
+		writeContentFile(t, root, tc.file, tc.body)
+		_, err := LoadContent(root)
+		if err == nil || !strings.Contains(err.Error(), tc.want) || !strings.Contains(err.Error(), tc.file) {
+			t.Fatalf("%s: expected error mentioning %q and %q, got %v", name, tc.want, tc.file, err)
+		}
+	}
+}
+
+func TestLoadContentWithoutFilesUsesBuiltins(t *testing.T) {
+	content, err := LoadContent(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("expected missing data root to fall back to built-ins, got %v", err)
+	}
+	if !reflect.DeepEqual(content, BuiltinContent()) {
+		t.Fatalf("expected built-in content")
+	}
+}
+
+func writeContentFile(t *testing.T, root, name, body string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, name), []byte(body), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
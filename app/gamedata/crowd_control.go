@@ -0,0 +1,129 @@
+package gamedata
+
+type CCCategory int
+
+const (
+	CCCategoryNone CCCategory = iota
+	CCCategoryStun
+	CCCategoryFreeze
+	CCCategorySilence
+	CCCategorySlow
+	ccCategoryCount
+)
+
+func (c CCCategory) String() string {
+	switch c {
+	case CCCategoryStun:
+		return "stun"
+	case CCCategoryFreeze:
+		return "freeze"
+	case CCCategorySilence:
+		return "silence"
+	case CCCategorySlow:
+		return "slow"
+	default:
+		return "none"
+	}
+}
+
+// CCDiminishingSteps scales each hard crowd-control landing within the reset window; once exhausted the target
+// is immune. Slows use CCSlowDiminishingSteps instead so zones that keep reapplying them still work.
+var CCDiminishingSteps = []float32{1, 0.5, 0.25}
+
+// CCSlowDiminishingSteps scales slows on targets that diminish them. Past the last step slows keep landing at
+// that floor rather than turning into immunity.
+var CCSlowDiminishingSteps = []float32{1, 0.7, 0.5, 0.35}
+
+// CCDiminishingResetWindow is how long a category must go without landing before its diminishing returns reset.
+const CCDiminishingResetWindow float32 = 15
+
+func CCCategoryOf(effectType EffectType) CCCategory {
+	switch effectType {
+	case EffectStun:
+		return CCCategoryStun
+	case EffectFreeze:
+		return CCCategoryFreeze
+	case EffectSilence:
+		return CCCategorySilence
+	case EffectSlow, EffectMoveSpeedReduction:
+		return CCCategorySlow
+	default:
+		return CCCategoryNone
+	}
+}
+
+type ccDiminishingState struct {
+	Landed     int
+	ResetTimer float32
+}
+
+// CrowdControlTracker applies diminishing returns to crowd control landing on one target. DurationMultiplier
+// and Immune let tougher targets, like bosses, shrug off control on top of the shared diminishing steps, and
+// DiminishSlows stops them being kited under permanent slows.
+type CrowdControlTracker struct {
+	DurationMultiplier float32
+	Immune             []CCCategory
+	DiminishSlows      bool
+	states             [ccCategoryCount]ccDiminishingState
+}
+
+// Apply returns the duration an effect should land with, or false when the target is immune to it right now.
+// Effects outside any crowd-control category pass through unchanged.
+func (t *CrowdControlTracker) Apply(effectType EffectType, duration float32) (float32, bool) {
+	category := CCCategoryOf(effectType)
+	if t == nil || category == CCCategoryNone {
+		return duration, true
+	}
+	for _, immune := range t.Immune {
+		if immune == category {
+			return 0, false
+		}
+	}
+
+	scale := float32(1)
+	if t.DurationMultiplier > 0 {
+		scale = t.DurationMultiplier
+	}
+	state := &t.states[category]
+	if category == CCCategorySlow {
+		if !t.DiminishSlows {
+			return duration * scale, true
+		}
+		scale *= CCSlowDiminishingSteps[min(state.Landed, len(CCSlowDiminishingSteps)-1)]
+		state.Landed++
+		state.ResetTimer = CCDiminishingResetWindow
+		return duration * scale, true
+	}
+
+	if state.Landed >= len(CCDiminishingSteps) {
+		return 0, false
+	}
+	scale *= CCDiminishingSteps[state.Landed]
+	state.Landed++
+	state.ResetTimer = CCDiminishingResetWindow
+	return duration * scale, true
+}
+
+// Landed reports how many times a category has landed since its diminishing returns last reset.
+func (t *CrowdControlTracker) Landed(category CCCategory) int {
+	if t == nil || category <= CCCategoryNone || category >= ccCategoryCount {
+		return 0
+	}
+	return t.states[category].Landed
+}
+
+func (t *CrowdControlTracker) Update(deltaTime float32) {
+	if t == nil {
+		return
+	}
+	for i := range t.states {
+		state := &t.states[i]
+		if state.Landed == 0 {
+			continue
+		}
+		state.ResetTimer -= deltaTime
+		if state.ResetTimer <= 0 {
+			*state = ccDiminishingState{}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package gamedata
+
+import "testing"
+
+func TestCrowdControlDiminishesThenImmunesUntilReset(t *testing.T) {
+	tracker := CrowdControlTracker{}
+	for i, want := range []float32{2, 1, 0.5} {
+		duration, landed := tracker.Apply(EffectStun, 2)
+		if !landed || duration != want {
+			t.Fatalf("stun %d: expected %.2fs, got %.2fs landed=%v", i+1, want, duration, landed)
+		}
+	}
+	if _, landed := tracker.Apply(EffectStun, 2); landed {
+		t.Fatalf("expected immunity once diminishing steps run out")
+	}
+	if duration, landed := tracker.Apply(EffectSilence, 2); !landed || duration != 2 {
+		t.Fatalf("expected silence to diminish separately from stun")
+	}
+
+	tracker.Update(CCDiminishingResetWindow - 1)
+	if tracker.Landed(CCCategoryStun) != 3 {
+		t.Fatalf("expected stun diminishing returns to hold inside the reset window")
+	}
+	tracker.Update(1.1)
+	if duration, landed := tracker.Apply(EffectStun, 2); !landed || duration != 2 {
+		t.Fatalf("expected full stun after the reset window, got %.2fs landed=%v", duration, landed)
+	}
+}
+
+func TestCrowdControlResistanceScalesAndImmunes(t *testing.T) {
+	tracker := CrowdControlTracker{DurationMultiplier: 0.5, Immune: []CCCategory{CCCategoryFreeze}}
+	if duration, _ := tracker.Apply(EffectStun, 2); duration != 1 {
+		t.Fatalf("expected resisted stun of 1s, got %.2f", duration)
+	}
+	if _, landed := tracker.Apply(EffectFreeze, 2); landed {
+		t.Fatalf("expected freeze immunity")
+	}
+	for i := 0; i < 5; i++ {
+		if duration, landed := tracker.Apply(EffectSlow, 2); !landed || duration != 1 {
+			t.Fatalf("expected slows to be shortened but never diminished, got %.2fs landed=%v", duration, landed)
+		}
+	}
+	if duration, landed := tracker.Apply(EffectBurn, 4); !landed || duration != 4 {
+		t.Fatalf("expected non-control effects to pass through")
+	}
+}
+
+func TestCrowdControlDiminishesSlowsDownToAFloor(t *testing.T) {
+	tracker := CrowdControlTracker{DurationMultiplier: 0.5, DiminishSlows: true}
+	for i, want := range []float32{1, 0.7, 0.5, 0.35, 0.35} {
+		duration, landed := tracker.Apply(EffectSlow, 2)
+		if !landed || duration != want {
+			t.Fatalf("slow %d: expected %.2fs, got %.2fs landed=%v", i+1, want, duration, landed)
+		}
+	}
+
+	tracker.Update(CCDiminishingResetWindow + 0.1)
+	if duration, _ := tracker.Apply(EffectSlow, 2); duration != 1 {
+		t.Fatalf("expected full slow after the reset window, got %.2fs", duration)
+	}
+}
@@ -1,16 +1,53 @@
 package gamedata
 
+const (
+	BaseCritMultiplier float32 = 1.5
+	// EnemyBaseAccuracy is what enemy attacks roll against. Accuracy above 1 cancels that much of the
+	// defender's dodge and then block, so avoidance only shows up once a build invests in AGI and VIT.
+	EnemyBaseAccuracy float32 = 1.2
+)
+
 type DerivedStats struct {
 	AutoAttackDamage      int
 	AttackSpeedMultiplier float32
 	MoveSpeed             float32
 	CritChance            float32
+	CritMultiplier        float32
+	Accuracy              float32
+	DodgeChance           float32
+	BlockChance           float32
+	BlockAmount           float32
+	ArmorPenetration      float32
+	MagicPenetration      float32
 	PhysicalResist        float32
 	MagicalResist         float32
 	MaxHP                 int
 	MaxMana               int
 }
 
+// DefenseStats are the avoidance rolls a target brings to an incoming hit.
+type DefenseStats struct {
+	DodgeChance float32
+	BlockChance float32
+	BlockAmount float32
+}
+
+func (d DerivedStats) Defense() DefenseStats {
+	return DefenseStats{DodgeChance: d.DodgeChance, BlockChance: d.BlockChance, BlockAmount: d.BlockAmount}
+}
+
+// Penetration is the fraction of a target's resistance to the damage type that the attacker ignores.
+func (d DerivedStats) Penetration(damageType DamageType) float32 {
+	switch damageType {
+	case DamagePhysical:
+		return d.ArmorPenetration
+	case DamageMagical, DamageFire, DamageFrost, DamagePoison, DamageLightning:
+		return d.MagicPenetration
+	default:
+		return 0
+	}
+}
+
 func ComputeEffectiveStats(base *Stats, equipment map[ItemSlot]*Item) Stats {
 	if base == nil {
 		return Stats{}
@@ -34,6 +71,13 @@ func ComputeDerivedStats(classType ClassType, effective Stats) DerivedStats {
 		AttackSpeedMultiplier: effective.CalculateAttackSpeed(1.0),
 		MoveSpeed:             effective.CalculateMoveSpeed(BasePlayerMoveSpeed),
 		CritChance:            clampFloat32((float32(effective.DEX)+float32(effective.LUK))*0.005, 0.0, 0.60),
+		CritMultiplier:        clampFloat32(BaseCritMultiplier+float32(effective.LUK)*0.02, BaseCritMultiplier, 3.0),
+		Accuracy:              clampFloat32(1.0+float32(effective.DEX)*0.01+float32(effective.LUK)*0.003, 1.0, 2.0),
+		DodgeChance:           clampFloat32(float32(effective.AGI)*0.01+float32(effective.LUK)*0.003, 0.0, 0.50),
+		BlockChance:           clampFloat32(float32(effective.VIT)*0.008+float32(effective.STR)*0.002, 0.0, 0.40),
+		BlockAmount:           clampFloat32(0.3+float32(effective.VIT)*0.01, 0.3, 0.75),
+		ArmorPenetration:      clampFloat32(float32(effective.STR)*0.005+float32(effective.DEX)*0.002, 0.0, 0.50),
+		MagicPenetration:      clampFloat32(float32(effective.INT)*0.005, 0.0, 0.50),
 		PhysicalResist:        clampFloat32(float32(effective.VIT)*0.01, 0.0, 0.60),
 		MagicalResist:         clampFloat32(float32(effective.INT)*0.01, 0.0, 0.60),
 		MaxHP:                 effective.CalculateMaxHealth(BasePlayerHP),
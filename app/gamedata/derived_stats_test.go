@@ -64,6 +64,24 @@ func TestComputeDerivedStatsAppliesClassAttackIdentity(t *testing.T) {
 	}
 }
 
+func TestComputeDerivedStatsGivesEachStatACombatRole(t *testing.T) {
+	agile := ComputeDerivedStats(ClassTypeRanged, Stats{STR: 1, AGI: 30, VIT: 1, INT: 1, DEX: 20, LUK: 10})
+	sturdy := ComputeDerivedStats(ClassTypeMelee, Stats{STR: 20, AGI: 1, VIT: 30, INT: 1, DEX: 1, LUK: 1})
+
+	if agile.DodgeChance <= sturdy.DodgeChance || agile.Accuracy <= sturdy.Accuracy || agile.CritMultiplier <= sturdy.CritMultiplier {
+		t.Fatalf("expected AGI, DEX and LUK to drive dodge, accuracy and crit damage, got %+v vs %+v", agile, sturdy)
+	}
+	if sturdy.BlockChance <= agile.BlockChance || sturdy.BlockAmount <= agile.BlockAmount || sturdy.ArmorPenetration <= agile.ArmorPenetration {
+		t.Fatalf("expected VIT and STR to drive block and armor penetration, got %+v vs %+v", sturdy, agile)
+	}
+	if sturdy.Defense() != (DefenseStats{DodgeChance: sturdy.DodgeChance, BlockChance: sturdy.BlockChance, BlockAmount: sturdy.BlockAmount}) {
+		t.Fatalf("expected defense to mirror the derived avoidance stats")
+	}
+	if sturdy.Penetration(DamageFire) != sturdy.MagicPenetration || sturdy.Penetration(DamageTrue) != 0 {
+		t.Fatalf("expected elemental damage to use magic penetration")
+	}
+}
+
 func TestComputeDerivedStatsClampsCritAndResists(t *testing.T) {
 	effective := Stats{STR: 1, AGI: 1, VIT: 200, INT: 200, DEX: 200, LUK: 200}
 	derived := ComputeDerivedStats(ClassTypeMelee, effective)
@@ -77,4 +95,7 @@ func TestComputeDerivedStatsClampsCritAndResists(t *testing.T) {
 	if derived.MagicalResist != 0.60 {
 		t.Fatalf("expected magical resist cap 0.60, got %.2f", derived.MagicalResist)
 	}
+	if derived.CritMultiplier != 3 || derived.Accuracy != 2 || derived.BlockChance != 0.40 || derived.BlockAmount != 0.75 {
+		t.Fatalf("expected crit multiplier, accuracy and block caps, got %+v", derived)
+	}
 }
@@ -9,7 +9,7 @@ const (
 
 var DefaultEffectStackPolicy = EffectStackPolicyRefreshDurationKeepStrongestMagnitude
 
-func UpdateEffects(effects *[]EffectInstance, dt float32, takeDamage func(int)) {
+func UpdateEffects(effects *[]EffectInstance, dt float32, takeDamage func(int, DamageType)) {
 	if effects == nil {
 		return
 	}
@@ -23,19 +23,48 @@ func UpdateEffects(effects *[]EffectInstance, dt float32, takeDamage func(int))
 			eff.TickTimer += dt
 			for eff.TickTimer >= eff.TickRate {
 				if takeDamage != nil {
-					takeDamage(int(eff.Magnitude))
+					takeDamage(int(eff.StackedMagnitude()), EffectDamageType(eff.Type))
 				}
 				eff.TickTimer -= eff.TickRate
 			}
 		}
 
-		if eff.TimeLeft <= 0 {
+		if !expireStacks(eff, dt) {
 			RemoveEffect(effects, i)
 			i--
 		}
 	}
 }
 
+// expireStacks drops stacks whose time ran out and reports whether any remain.
+func expireStacks(eff *EffectInstance, dt float32) bool {
+	if len(eff.StackTimers) > 0 {
+		remaining := eff.StackTimers[:0]
+		longest := float32(0)
+		for _, timeLeft := range eff.StackTimers {
+			timeLeft -= dt
+			if timeLeft > 0 {
+				remaining = append(remaining, timeLeft)
+				longest = max(longest, timeLeft)
+			}
+		}
+		eff.StackTimers = remaining
+		eff.Stacks = len(remaining)
+		eff.TimeLeft = longest
+		return eff.Stacks > 0
+	}
+
+	if eff.TimeLeft > 0 {
+		return true
+	}
+	if eff.Stacks > 1 && GetEffectStacking(eff.Type).OnExpire == EffectExpireOneStack {
+		eff.Stacks--
+		eff.TimeLeft += eff.Duration
+		return eff.TimeLeft > 0
+	}
+	return false
+}
+
 func ApplyEffect(effects *[]EffectInstance, newEffect Effect) {
 	ApplyEffectWithPolicy(effects, newEffect, DefaultEffectStackPolicy)
 }
@@ -45,20 +74,59 @@ func ApplyEffectWithPolicy(effects *[]EffectInstance, newEffect Effect, policy E
 		return
 	}
 
+	stacking := GetEffectStacking(newEffect.Type)
 	for i := range *effects {
-		if (*effects)[i].Type == newEffect.Type {
-			(*effects)[i].TimeLeft = newEffect.Duration
-			if policy == EffectStackPolicyRefreshDurationKeepStrongestMagnitude && newEffect.Magnitude > (*effects)[i].Magnitude {
-				(*effects)[i].Magnitude = newEffect.Magnitude
+		existing := &(*effects)[i]
+		if existing.Type == newEffect.Type {
+			if stacking.IndependentTimers {
+				existing.TimeLeft = max(existing.TimeLeft, newEffect.Duration)
+			} else {
+				existing.TimeLeft = newEffect.Duration
+			}
+			if policy == EffectStackPolicyRefreshDurationKeepStrongestMagnitude && newEffect.Magnitude > existing.Magnitude {
+				existing.Magnitude = newEffect.Magnitude
+			}
+			if stacking.MaxStacks > 1 {
+				addStack(existing, newEffect, stacking)
 			}
 			return
 		}
 	}
 
-	*effects = append(*effects, EffectInstance{
+	instance := EffectInstance{
 		Effect:   newEffect,
 		TimeLeft: newEffect.Duration,
-	})
+		Stacks:   1,
+	}
+	if stacking.MaxStacks > 1 && stacking.IndependentTimers {
+		instance.StackTimers = []float32{newEffect.Duration}
+	}
+	*effects = append(*effects, instance)
+}
+
+// addStack adds one stack up to the cap. At the cap, independent timers refresh their oldest stack instead.
+func addStack(existing *EffectInstance, newEffect Effect, stacking EffectStacking) {
+	existing.Duration = newEffect.Duration
+	if !stacking.IndependentTimers {
+		existing.Stacks = min(existing.StackCount()+1, stacking.MaxStacks)
+		return
+	}
+
+	if len(existing.StackTimers) == 0 {
+		existing.StackTimers = []float32{existing.TimeLeft}
+	}
+	if len(existing.StackTimers) < stacking.MaxStacks {
+		existing.StackTimers = append(existing.StackTimers, newEffect.Duration)
+	} else {
+		oldest := 0
+		for i, timeLeft := range existing.StackTimers {
+			if timeLeft < existing.StackTimers[oldest] {
+				oldest = i
+			}
+		}
+		existing.StackTimers[oldest] = newEffect.Duration
+	}
+	existing.Stacks = len(existing.StackTimers)
 }
 
 func RemoveEffect(effects *[]EffectInstance, index int) {
@@ -88,7 +156,21 @@ func GetEffectMagnitude(effects *[]EffectInstance, effectType EffectType) float3
 
 	for _, e := range *effects {
 		if e.Type == effectType {
-			return e.Magnitude
+			return e.StackedMagnitude()
+		}
+	}
+	return 0
+}
+
+// GetEffectStacks returns how many stacks of an effect are active, or 0 when it is absent.
+func GetEffectStacks(effects *[]EffectInstance, effectType EffectType) int {
+	if effects == nil {
+		return 0
+	}
+
+	for _, e := range *effects {
+		if e.Type == effectType {
+			return e.StackCount()
 		}
 	}
 	return 0
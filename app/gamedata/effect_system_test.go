@@ -53,7 +53,10 @@ func TestUpdateEffectsSupportsLargeDeltaAndDotOnlyTicks(t *testing.T) {
 	}
 
 	totalDamage := 0
-	takeDamage := func(amount int) {
+	takeDamage := func(amount int, damageType DamageType) {
+		if damageType != DamageFire {
+			t.Fatalf("expected burn ticks to deal fire damage, got %v", damageType)
+		}
 		totalDamage += amount
 	}
 
@@ -119,3 +122,65 @@ func TestEffectQueryHelpers(t *testing.T) {
 		t.Fatalf("expected freeze to force zero move speed multiplier")
 	}
 }
+
+func TestPoisonStacksWithIndependentTimers(t *testing.T) {
+	effects := []EffectInstance{}
+	poison := Effect{Type: EffectPoison, Duration: 3, Magnitude: 2, TickRate: 1}
+
+	ApplyEffect(&effects, poison)
+	UpdateEffects(&effects, 1.5, nil)
+	ApplyEffect(&effects, poison)
+	if len(effects) != 1 || GetEffectStacks(&effects, EffectPoison) != 2 {
+		t.Fatalf("expected two poison stacks on one instance, got %+v", effects)
+	}
+	if GetEffectMagnitude(&effects, EffectPoison) != 4 {
+		t.Fatalf("expected stacked magnitude 4, got %.2f", GetEffectMagnitude(&effects, EffectPoison))
+	}
+
+	totalDamage := 0
+	UpdateEffects(&effects, 1.6, func(amount int, _ DamageType) { totalDamage += amount })
+	if GetEffectStacks(&effects, EffectPoison) != 1 || math.Abs(float64(effects[0].TimeLeft-1.4)) > 0.0001 {
+		t.Fatalf("expected the first stack to expire on its own, got %+v", effects)
+	}
+	if totalDamage != 8 {
+		t.Fatalf("expected two ticks at two stacks, got %d", totalDamage)
+	}
+
+	for i := 0; i < 8; i++ {
+		ApplyEffect(&effects, poison)
+	}
+	if GetEffectStacks(&effects, EffectPoison) != GetEffectStacking(EffectPoison).MaxStacks {
+		t.Fatalf("expected poison to cap at %d stacks, got %d", GetEffectStacking(EffectPoison).MaxStacks, GetEffectStacks(&effects, EffectPoison))
+	}
+}
+
+func TestBurnSharesTimerAndDropsOneStackOnExpiry(t *testing.T) {
+	effects := []EffectInstance{}
+	burn := Effect{Type: EffectBurn, Duration: 2, Magnitude: 3, TickRate: 1}
+
+	for i := 0; i < 4; i++ {
+		ApplyEffect(&effects, burn)
+	}
+	if GetEffectStacks(&effects, EffectBurn) != 3 {
+		t.Fatalf("expected burn to cap at three stacks, got %d", GetEffectStacks(&effects, EffectBurn))
+	}
+
+	UpdateEffects(&effects, 2.1, nil)
+	if GetEffectStacks(&effects, EffectBurn) != 2 || effects[0].TimeLeft <= 1.8 {
+		t.Fatalf("expected one stack to fall off and the timer to restart, got %+v", effects)
+	}
+	UpdateEffects(&effects, 2, nil)
+	UpdateEffects(&effects, 2, nil)
+	if HasEffect(&effects, EffectBurn) {
+		t.Fatalf("expected burn to end once the last stack expires")
+	}
+}
+
+func TestNonStackingEffectStaysSingleStack(t *testing.T) {
+	effects := []EffectInstance{}
+	ApplyEffect(&effects, Effect{Type: EffectSlow, Duration: 2, Magnitude: 0.3})
+	ApplyEffect(&effects, Effect{Type: EffectSlow, Duration: 2, Magnitude: 0.3})
+	if GetEffectStacks(&effects, EffectSlow) != 1 || GetEffectMagnitude(&effects, EffectSlow) != 0.3 {
+		t.Fatalf("expected slow to refresh without stacking, got %+v", effects)
+	}
+}
@@ -25,25 +25,65 @@ type Effect struct {
 
 type EffectInstance struct {
 	Effect
-	TimeLeft  float32
-	TickTimer float32
+	TimeLeft    float32
+	TickTimer   float32
+	Stacks      int
+	StackTimers []float32
 }
 
-var effectDefinitions = map[EffectType]Effect{
-	EffectSlow:               {Type: EffectSlow, Duration: 2.0, Magnitude: 0.3},
-	EffectStun:               {Type: EffectStun, Duration: 1.0, Magnitude: 0},
-	EffectFreeze:             {Type: EffectFreeze, Duration: 1.0, Magnitude: 0},
-	EffectSilence:            {Type: EffectSilence, Duration: 2.0, Magnitude: 0},
-	EffectBurn:               {Type: EffectBurn, Duration: 4.0, Magnitude: 3.0, TickRate: 1.0},
-	EffectPoison:             {Type: EffectPoison, Duration: 5.0, Magnitude: 3.0, TickRate: 1.0},
-	EffectDamageReduction:    {Type: EffectDamageReduction, Duration: 4.0, Magnitude: 0.4},
-	EffectMoveSpeedReduction: {Type: EffectMoveSpeedReduction, Duration: 4.0, Magnitude: 0.3},
-	EffectLifesteal:          {Type: EffectLifesteal, Duration: 5.0, Magnitude: 0.3},
-	EffectDamageBoost:        {Type: EffectDamageBoost, Duration: 5.0, Magnitude: 0.5},
-	EffectMoveSpeedBoost:     {Type: EffectMoveSpeedBoost, Duration: 2.0, Magnitude: 0.5},
+type EffectStackExpiry int
+
+const (
+	EffectExpireAllStacks EffectStackExpiry = iota
+	EffectExpireOneStack
+)
+
+// EffectStacking describes how repeat applications build up on one target. Each stack beyond the first adds
+// MagnitudePerStack times the base magnitude. Independent timers let every stack run out on its own; with a
+// shared timer OnExpire decides whether the whole effect ends or one stack falls off and the timer restarts.
+type EffectStacking struct {
+	MaxStacks         int
+	MagnitudePerStack float32
+	IndependentTimers bool
+	OnExpire          EffectStackExpiry
 }
 
-func GetEffectDefinition(effectType EffectType) (Effect, bool) {
+// EffectTemplate is an effect's default payload plus how repeat applications stack.
+type EffectTemplate struct {
+	Effect
+	Stacking EffectStacking
+}
+
+var effectDefinitions = map[EffectType]EffectTemplate{
+	EffectSlow:               {Effect: Effect{Type: EffectSlow, Duration: 2.0, Magnitude: 0.3}},
+	EffectStun:               {Effect: Effect{Type: EffectStun, Duration: 1.0, Magnitude: 0}},
+	EffectFreeze:             {Effect: Effect{Type: EffectFreeze, Duration: 1.0, Magnitude: 0}},
+	EffectSilence:            {Effect: Effect{Type: EffectSilence, Duration: 2.0, Magnitude: 0}},
+	EffectBurn:               {Effect: Effect{Type: EffectBurn, Duration: 4.0, Magnitude: 3.0, TickRate: 1.0}, Stacking: EffectStacking{MaxStacks: 3, MagnitudePerStack: 1, OnExpire: EffectExpireOneStack}},
+	EffectPoison:             {Effect: Effect{Type: EffectPoison, Duration: 5.0, Magnitude: 3.0, TickRate: 1.0}, Stacking: EffectStacking{MaxStacks: 5, MagnitudePerStack: 1, IndependentTimers: true}},
+	EffectDamageReduction:    {Effect: Effect{Type: EffectDamageReduction, Duration: 4.0, Magnitude: 0.4}},
+	EffectMoveSpeedReduction: {Effect: Effect{Type: EffectMoveSpeedReduction, Duration: 4.0, Magnitude: 0.3}},
+	EffectLifesteal:          {Effect: Effect{Type: EffectLifesteal, Duration: 5.0, Magnitude: 0.3}},
+	EffectDamageBoost:        {Effect: Effect{Type: EffectDamageBoost, Duration: 5.0, Magnitude: 0.5}},
+	EffectMoveSpeedBoost:     {Effect: Effect{Type: EffectMoveSpeedBoost, Duration: 2.0, Magnitude: 0.5}},
+}
+
+func GetEffectDefinition(effectType EffectType) (EffectTemplate, bool) {
 	effect, ok := effectDefinitions[effectType]
 	return effect, ok
 }
+
+func GetEffectStacking(effectType EffectType) EffectStacking {
+	return effectDefinitions[effectType].Stacking
+}
+
+// StackCount reports the number of stacks, counting instances created before stacking existed as one.
+func (e EffectInstance) StackCount() int {
+	return max(e.Stacks, 1)
+}
+
+// StackedMagnitude is the magnitude the effect currently applies with all of its stacks.
+func (e EffectInstance) StackedMagnitude() float32 {
+	perStack := GetEffectStacking(e.Type).MagnitudePerStack
+	return e.Magnitude * (1 + perStack*float32(e.StackCount()-1))
+}
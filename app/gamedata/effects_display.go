@@ -18,3 +18,32 @@ func EffectPopupLabel(effectType EffectType) string {
 		return ""
 	}
 }
+
+func EffectDisplayName(effectType EffectType) string {
+	switch effectType {
+	case EffectSlow:
+		return "Slow"
+	case EffectStun:
+		return "Stun"
+	case EffectFreeze:
+		return "Freeze"
+	case EffectSilence:
+		return "Silence"
+	case EffectBurn:
+		return "Burn"
+	case EffectPoison:
+		return "Poison"
+	case EffectDamageReduction:
+		return "Damage Reduction"
+	case EffectMoveSpeedReduction:
+		return "Move Speed Reduction"
+	case EffectLifesteal:
+		return "Lifesteal"
+	case EffectDamageBoost:
+		return "Damage Boost"
+	case EffectMoveSpeedBoost:
+		return "Move Speed Boost"
+	default:
+		return "Unknown"
+	}
+}
@@ -38,6 +38,9 @@ const (
 	EnemyArchetypeSwarmling
 )
 
+// DefaultEnemyXPReward applies when an archetype's content entry leaves xp_reward out.
+const DefaultEnemyXPReward = 20
+
 type EnemyArchetype struct {
 	Type               EnemyArchetypeType
 	Name               string
@@ -58,8 +61,39 @@ type EnemyArchetype struct {
 	ProjectileLifetime float32
 	DamageType         DamageType
 	OnHitEffects       []EffectSpec
+	Resistances        ResistanceProfile
+	Defense            DefenseStats
 	XPReward           int
 	ThreatValue        int
+	Skills             []EnemySkill
+}
+
+// EnemySkillUse gates when the AI casts a skill. Zero values leave a condition unchecked.
+type EnemySkillUse struct {
+	MinRange      float32
+	MaxRange      float32
+	SelfHPBelow   float32
+	AllyHPBelow   float32
+	MinAllies     int
+	MaxAllies     int
+	PlayerCasting bool
+}
+
+type EnemySkill struct {
+	ID    string
+	Skill Skill
+	Use   EnemySkillUse
+}
+
+func CloneEnemySkills(skills []EnemySkill) []EnemySkill {
+	if len(skills) == 0 {
+		return nil
+	}
+	out := make([]EnemySkill, len(skills))
+	for i, skill := range skills {
+		out[i] = EnemySkill{ID: skill.ID, Skill: *cloneSkill(skill.Skill), Use: skill.Use}
+	}
+	return out
 }
 
 type EliteModifierType int
@@ -119,7 +153,7 @@ var enemyArchetypeOrder = []EnemyArchetypeType{
 	EnemyArchetypeSwarmling,
 }
 
-var enemyArchetypes = map[EnemyArchetypeType]EnemyArchetype{
+var builtinEnemyArchetypes = map[EnemyArchetypeType]EnemyArchetype{
 	EnemyArchetypeRaider: {
 		Type:           EnemyArchetypeRaider,
 		Name:           "Raider",
@@ -155,8 +189,28 @@ var enemyArchetypes = map[EnemyArchetypeType]EnemyArchetype{
 		Height:         34,
 		AttackMode:     EnemyAttackMelee,
 		DamageType:     DamagePhysical,
-		XPReward:       22,
-		ThreatValue:    14,
+		Resistances: ResistanceProfile{
+			DamagePhysical:  0.15,
+			DamageLightning: -0.25,
+		},
+		Defense:     DefenseStats{BlockChance: 0.15, BlockAmount: 0.4},
+		XPReward:    22,
+		ThreatValue: 14,
+		Skills: []EnemySkill{
+			{
+				ID: "lunge",
+				Skill: Skill{
+					Type:         SkillTypeEnemy,
+					Name:         "Lunge",
+					Cooldown:     6,
+					Targeting:    TargetingSpec{Type: TargetEnemy, Range: 70, MaxTargets: 1},
+					Delivery:     DeliverySpec{Type: DeliveryInstant},
+					DamageSpec:   &DamageSpec{Base: 14, Scaling: map[StatType]float32{}, DamageType: DamagePhysical},
+					SelfMovement: SelfMovementSpec{Mode: SelfMovementTowardTarget, Distance: 180},
+				},
+				Use: EnemySkillUse{MinRange: 110, MaxRange: 260},
+			},
+		},
 	},
 	EnemyArchetypeArcher: {
 		Type:               EnemyArchetypeArcher,
@@ -177,8 +231,12 @@ var enemyArchetypes = map[EnemyArchetypeType]EnemyArchetype{
 		ProjectileRadius:   6,
 		ProjectileLifetime: 2.2,
 		DamageType:         DamagePhysical,
-		XPReward:           24,
-		ThreatValue:        16,
+		Resistances: ResistanceProfile{
+			DamagePoison: -0.25,
+		},
+		Defense:     DefenseStats{DodgeChance: 0.1},
+		XPReward:    24,
+		ThreatValue: 16,
 	},
 	EnemyArchetypeHexCaller: {
 		Type:           EnemyArchetypeHexCaller,
@@ -203,6 +261,10 @@ var enemyArchetypes = map[EnemyArchetypeType]EnemyArchetype{
 				Magnitude: 0.25,
 			},
 		},
+		Resistances: ResistanceProfile{
+			DamageMagical: 0.25,
+			DamageFire:    -0.25,
+		},
 		XPReward:    26,
 		ThreatValue: 18,
 	},
@@ -222,8 +284,14 @@ var enemyArchetypes = map[EnemyArchetypeType]EnemyArchetype{
 		Height:         40,
 		AttackMode:     EnemyAttackMelee,
 		DamageType:     DamagePhysical,
-		XPReward:       32,
-		ThreatValue:    24,
+		Resistances: ResistanceProfile{
+			DamagePhysical: 0.25,
+			DamageFire:     -0.25,
+			DamageFrost:    0.2,
+		},
+		Defense:     DefenseStats{BlockChance: 0.2, BlockAmount: 0.5},
+		XPReward:    32,
+		ThreatValue: 24,
 	},
 	EnemyArchetypeSwarmling: {
 		Type:           EnemyArchetypeSwarmling,
@@ -241,8 +309,13 @@ var enemyArchetypes = map[EnemyArchetypeType]EnemyArchetype{
 		Height:         22,
 		AttackMode:     EnemyAttackMelee,
 		DamageType:     DamagePhysical,
-		XPReward:       10,
-		ThreatValue:    6,
+		Resistances: ResistanceProfile{
+			DamageFire:   -0.5,
+			DamagePoison: 0.3,
+		},
+		Defense:     DefenseStats{DodgeChance: 0.15},
+		XPReward:    10,
+		ThreatValue: 6,
 	},
 }
 
@@ -251,7 +324,7 @@ var eliteModifierOrder = []EliteModifierType{
 	EliteModifierCrippling,
 }
 
-var eliteModifiers = map[EliteModifierType]EliteModifier{
+var builtinEliteModifiers = map[EliteModifierType]EliteModifier{
 	EliteModifierScorching: {
 		Type:          EliteModifierScorching,
 		Name:          "Scorching",
@@ -281,6 +354,10 @@ var eliteModifiers = map[EliteModifierType]EliteModifier{
 	},
 }
 
+var enemyArchetypes = builtinEnemyArchetypes
+
+var eliteModifiers = builtinEliteModifiers
+
 func GetEnemyTemplate(templateType EnemyTemplateType) EnemyTemplate {
 	template, ok := enemyTemplates[templateType]
 	if !ok {
@@ -8,6 +8,7 @@ func TestEnemyArchetypeRegistryHasMinimumRoster(t *testing.T) {
 		t.Fatalf("expected at least 6 archetypes, got %d", len(pool))
 	}
 
+	skilled := 0
 	for _, archetypeType := range pool {
 		archetype := GetEnemyArchetype(archetypeType)
 		if archetype.Name == "" {
@@ -40,6 +41,12 @@ func TestEnemyArchetypeRegistryHasMinimumRoster(t *testing.T) {
 		if archetype.ThreatValue <= 0 {
 			t.Fatalf("expected archetype %s threat value > 0", archetype.Name)
 		}
+		if len(archetype.Skills) > 0 {
+			skilled++
+		}
+	}
+	if skilled == 0 {
+		t.Fatalf("expected at least one archetype to ship with skills")
 	}
 }
 
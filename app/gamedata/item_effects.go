@@ -0,0 +1,220 @@
+package gamedata
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ItemTrigger int
+
+const (
+	ItemTriggerOnHit ItemTrigger = iota
+	ItemTriggerOnCrit
+	ItemTriggerOnKill
+	ItemTriggerOnDamaged
+	ItemTriggerOnSkillCast
+	ItemTriggerOnRoomEnter
+	ItemTriggerPeriodic
+	ItemTriggerOnAttack
+)
+
+type ItemConditionType int
+
+const (
+	ItemConditionChance ItemConditionType = iota
+	ItemConditionTargetHasEffect
+	ItemConditionTargetHPBelow
+	ItemConditionSelfHPBelow
+)
+
+type ItemCondition struct {
+	Type    ItemConditionType
+	Value   float32
+	Effects []EffectType
+}
+
+type ItemActionType int
+
+const (
+	ItemActionApplyEffect ItemActionType = iota
+	ItemActionAreaDamage
+	ItemActionHeal
+	ItemActionRestoreMana
+	ItemActionReduceCooldowns
+	ItemActionSpawnProjectile
+	ItemActionCritChance
+)
+
+type ItemAction struct {
+	Type            ItemActionType
+	Amount          float32
+	PercentOfDamage float32
+	Effect          EffectSpec
+	OnSelf          bool
+	Radius          float32
+	Speed           float32
+	DamageType      DamageType
+}
+
+// ItemEffect runs Action when Trigger fires and every condition holds.
+type ItemEffect struct {
+	Trigger    ItemTrigger
+	Interval   float32
+	Conditions []ItemCondition
+	Action     ItemAction
+}
+
+func (e ItemEffect) Chance() float32 {
+	chance := float32(1)
+	for _, condition := range e.Conditions {
+		if condition.Type == ItemConditionChance {
+			chance *= condition.Value
+		}
+	}
+	return chance
+}
+
+func BurnOnHit(magnitude, chance, duration, tickRate float32) ItemEffect {
+	return ItemEffect{
+		Trigger:    ItemTriggerOnHit,
+		Conditions: []ItemCondition{{Type: ItemConditionChance, Value: chance}},
+		Action: ItemAction{
+			Type:   ItemActionApplyEffect,
+			Effect: EffectSpec{Type: EffectBurn, Duration: duration, Magnitude: magnitude, TickRate: tickRate},
+		},
+	}
+}
+
+func CritChanceVsSlowed(bonus float32) ItemEffect {
+	return ItemEffect{
+		Trigger:    ItemTriggerOnAttack,
+		Conditions: []ItemCondition{{Type: ItemConditionTargetHasEffect, Effects: []EffectType{EffectSlow, EffectFreeze, EffectMoveSpeedReduction}}},
+		Action:     ItemAction{Type: ItemActionCritChance, Amount: bonus},
+	}
+}
+
+func LifestealOnHit(fraction float32) ItemEffect {
+	return ItemEffect{Trigger: ItemTriggerOnHit, Action: ItemAction{Type: ItemActionHeal, PercentOfDamage: fraction}}
+}
+
+func ManaOnHit(amount float32) ItemEffect {
+	return ItemEffect{Trigger: ItemTriggerOnHit, Action: ItemAction{Type: ItemActionRestoreMana, Amount: amount}}
+}
+
+func DescribeItemEffect(effect ItemEffect) string {
+	action := describeItemAction(effect.Action)
+	if action == "" {
+		return ""
+	}
+
+	text := action
+	if trigger := describeItemTrigger(effect); trigger != "" {
+		text = trigger + ": " + action
+	}
+	if conditions := describeItemConditions(effect.Conditions); conditions != "" {
+		text += " " + conditions
+	}
+	return text
+}
+
+func describeItemTrigger(effect ItemEffect) string {
+	trigger := ""
+	switch effect.Trigger {
+	case ItemTriggerOnHit:
+		trigger = "on hit"
+	case ItemTriggerOnCrit:
+		trigger = "on crit"
+	case ItemTriggerOnKill:
+		trigger = "on kill"
+	case ItemTriggerOnDamaged:
+		trigger = "when hit"
+	case ItemTriggerOnSkillCast:
+		trigger = "on skill cast"
+	case ItemTriggerOnRoomEnter:
+		trigger = "on entering a room"
+	case ItemTriggerPeriodic:
+		trigger = fmt.Sprintf("every %gs", effect.Interval)
+	}
+
+	if chance := effect.Chance(); chance < 1 {
+		return strings.TrimSpace(fmt.Sprintf("%.0f%% %s", chance*100, trigger))
+	}
+	if trigger == "" {
+		return ""
+	}
+	return strings.ToUpper(trigger[:1]) + trigger[1:]
+}
+
+func describeItemAction(action ItemAction) string {
+	switch action.Type {
+	case ItemActionApplyEffect:
+		verb := "apply"
+		if action.OnSelf {
+			verb = "gain"
+		}
+		return verb + " " + describeEffectSpec(action.Effect)
+	case ItemActionAreaDamage:
+		return fmt.Sprintf("deal %s %s damage in a %.0f radius", describeItemAmount(action, "", "damage"), strings.ToLower(action.DamageType.String()), action.Radius)
+	case ItemActionHeal:
+		return "heal " + describeItemAmount(action, " HP", "damage dealt")
+	case ItemActionRestoreMana:
+		return "restore " + describeItemAmount(action, " mana", "damage dealt")
+	case ItemActionReduceCooldowns:
+		return fmt.Sprintf("reduce skill cooldowns by %.1fs", action.Amount)
+	case ItemActionSpawnProjectile:
+		return fmt.Sprintf("fire a bolt for %s %s damage", describeItemAmount(action, "", "damage"), strings.ToLower(action.DamageType.String()))
+	case ItemActionCritChance:
+		return fmt.Sprintf("+%.0f%% crit chance", action.Amount*100)
+	default:
+		return ""
+	}
+}
+
+func describeItemAmount(action ItemAction, unit, percentOf string) string {
+	parts := make([]string, 0, 2)
+	if action.Amount > 0 {
+		parts = append(parts, fmt.Sprintf("%g%s", action.Amount, unit))
+	}
+	if action.PercentOfDamage > 0 {
+		parts = append(parts, fmt.Sprintf("%.0f%% of %s", action.PercentOfDamage*100, percentOf))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func describeEffectSpec(spec EffectSpec) string {
+	name := EffectDisplayName(spec.Type)
+	switch {
+	case IsDamageOverTimeEffect(spec.Type):
+		tickRate := spec.TickRate
+		if tickRate <= 0 {
+			tickRate = 1
+		}
+		return fmt.Sprintf("%s (%.1f dmg/%.1fs for %.1fs)", name, spec.Magnitude, tickRate, spec.Duration)
+	case spec.Magnitude > 0:
+		return fmt.Sprintf("%s %.0f%% for %.1fs", name, spec.Magnitude*100, spec.Duration)
+	default:
+		return fmt.Sprintf("%s for %.1fs", name, spec.Duration)
+	}
+}
+
+func describeItemConditions(conditions []ItemCondition) string {
+	parts := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		switch condition.Type {
+		case ItemConditionTargetHasEffect:
+			names := make([]string, len(condition.Effects))
+			for i, effectType := range condition.Effects {
+				names[i] = EffectDisplayName(effectType)
+			}
+			parts = append(parts, "target has "+strings.Join(names, "/"))
+		case ItemConditionTargetHPBelow:
+			parts = append(parts, fmt.Sprintf("target below %.0f%% HP", condition.Value*100))
+		case ItemConditionSelfHPBelow:
+			parts = append(parts, fmt.Sprintf("below %.0f%% HP", condition.Value*100))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "if " + strings.Join(parts, " and ")
+}
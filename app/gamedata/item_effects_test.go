@@ -0,0 +1,54 @@
+package gamedata
+
+import "testing"
+
+func TestDescribeItemEffectCombinesTriggerActionAndConditions(t *testing.T) {
+	cases := map[string]struct {
+		effect ItemEffect
+		want   string
+	}{
+		"burn chance": {
+			effect: BurnOnHit(3, 0.35, 4, 1),
+			want:   "35% on hit: apply Burn (3.0 dmg/1.0s for 4.0s)",
+		},
+		"crit vs slowed": {
+			effect: CritChanceVsSlowed(0.1),
+			want:   "+10% crit chance if target has Slow/Freeze/Move Speed Reduction",
+		},
+		"lifesteal": {
+			effect: LifestealOnHit(0.05),
+			want:   "On hit: heal 5% of damage dealt",
+		},
+		"periodic mana": {
+			effect: ItemEffect{Trigger: ItemTriggerPeriodic, Interval: 5, Action: ItemAction{Type: ItemActionRestoreMana, Amount: 6}},
+			want:   "Every 5s: restore 6 mana",
+		},
+		"low hp self buff": {
+			effect: ItemEffect{
+				Trigger:    ItemTriggerOnDamaged,
+				Conditions: []ItemCondition{{Type: ItemConditionSelfHPBelow, Value: 0.35}},
+				Action:     ItemAction{Type: ItemActionApplyEffect, OnSelf: true, Effect: EffectSpec{Type: EffectDamageReduction, Duration: 3, Magnitude: 0.3}},
+			},
+			want: "When hit: gain Damage Reduction 30% for 3.0s if below 35% HP",
+		},
+		"area damage": {
+			effect: ItemEffect{Trigger: ItemTriggerOnKill, Action: ItemAction{Type: ItemActionAreaDamage, Amount: 12, Radius: 90, DamageType: DamageFire}},
+			want:   "On kill: deal 12 fire damage in a 90 radius",
+		},
+	}
+	for name, tc := range cases {
+		if got := DescribeItemEffect(tc.effect); got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", name, tc.want, got)
+		}
+	}
+}
+
+func TestCuratedItemCopiesEffectConditions(t *testing.T) {
+	effects := []ItemEffect{CritChanceVsSlowed(0.1)}
+	item := NewCuratedItem("probe", "Probe", "", ItemSlotHead, nil, ClassTypeAny, ItemMetadata{Effects: effects})
+
+	effects[0].Conditions[0].Effects[0] = EffectBurn
+	if item.Effects[0].Conditions[0].Effects[0] != EffectSlow {
+		t.Fatalf("expected item effects to be deep copied, got %+v", item.Effects[0].Conditions)
+	}
+}
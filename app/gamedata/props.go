@@ -0,0 +1,115 @@
+package gamedata
+
+type PropType int
+
+const (
+	PropBarrel PropType = iota
+	PropCrate
+	PropExplosiveUrn
+	PropBrazier
+)
+
+type PropDropType int
+
+const (
+	PropDropNone PropDropType = iota
+	PropDropHealthOrb
+	PropDropManaOrb
+)
+
+type PropSpec struct {
+	Type                PropType
+	Name                string
+	MaxHP               int
+	Width               float32
+	Height              float32
+	DropType            PropDropType
+	DropChance          float32
+	DropAmount          int
+	ExplosionRadius     float32
+	ExplosionDamage     int
+	ExplosionDamageType DamageType
+	ExplosionEffects    []EffectSpec
+}
+
+func (s PropSpec) Explodes() bool {
+	return s.ExplosionRadius > 0 && s.ExplosionDamage > 0
+}
+
+var propOrder = []PropType{
+	PropBarrel,
+	PropCrate,
+	PropExplosiveUrn,
+	PropBrazier,
+}
+
+var propTypeNames = map[string]PropType{
+	"barrel":        PropBarrel,
+	"crate":         PropCrate,
+	"explosive_urn": PropExplosiveUrn,
+	"brazier":       PropBrazier,
+}
+
+var propSpecs = map[PropType]PropSpec{
+	PropBarrel: {
+		Type:       PropBarrel,
+		Name:       "Barrel",
+		MaxHP:      30,
+		Width:      44,
+		Height:     44,
+		DropType:   PropDropHealthOrb,
+		DropChance: 0.5,
+		DropAmount: 20,
+	},
+	PropCrate: {
+		Type:       PropCrate,
+		Name:       "Crate",
+		MaxHP:      24,
+		Width:      48,
+		Height:     48,
+		DropType:   PropDropManaOrb,
+		DropChance: 0.5,
+		DropAmount: 20,
+	},
+	PropExplosiveUrn: {
+		Type:                PropExplosiveUrn,
+		Name:                "Explosive Urn",
+		MaxHP:               12,
+		Width:               36,
+		Height:              36,
+		ExplosionRadius:     140,
+		ExplosionDamage:     40,
+		ExplosionDamageType: DamageMagical,
+	},
+	PropBrazier: {
+		Type:                PropBrazier,
+		Name:                "Brazier",
+		MaxHP:               40,
+		Width:               40,
+		Height:              40,
+		ExplosionRadius:     96,
+		ExplosionDamage:     16,
+		ExplosionDamageType: DamageMagical,
+		ExplosionEffects: []EffectSpec{
+			{Type: EffectBurn, Duration: 3.0, Magnitude: 2.0, TickRate: 0.5, MinTickDamage: 1, MaxTickDamage: 8},
+		},
+	},
+}
+
+func GetPropSpec(propType PropType) PropSpec {
+	spec, ok := propSpecs[propType]
+	if !ok {
+		return propSpecs[PropCrate]
+	}
+	return spec
+}
+
+func PropTypes() []PropType {
+	out := make([]PropType, len(propOrder))
+	copy(out, propOrder)
+	return out
+}
+
+func ParsePropType(value string) (PropType, error) {
+	return parseContentName("prop type", value, propTypeNames)
+}
@@ -0,0 +1,103 @@
+package gamedata
+
+import "sort"
+
+const (
+	MaxDamageResistance float32 = 0.9
+	MaxDamageWeakness   float32 = 1
+)
+
+func (d DamageType) String() string {
+	switch d {
+	case DamagePhysical:
+		return "Physical"
+	case DamageMagical:
+		return "Magical"
+	case DamageTrue:
+		return "True"
+	case DamageFire:
+		return "Fire"
+	case DamageFrost:
+		return "Frost"
+	case DamagePoison:
+		return "Poison"
+	case DamageLightning:
+		return "Lightning"
+	default:
+		return "Unknown"
+	}
+}
+
+func IsElementalDamage(damageType DamageType) bool {
+	switch damageType {
+	case DamageFire, DamageFrost, DamagePoison, DamageLightning:
+		return true
+	default:
+		return false
+	}
+}
+
+// EffectDamageType is the damage type of an effect's ticks.
+func EffectDamageType(effectType EffectType) DamageType {
+	switch effectType {
+	case EffectBurn:
+		return DamageFire
+	case EffectPoison:
+		return DamagePoison
+	default:
+		return DamageTrue
+	}
+}
+
+// ResistanceProfile maps damage types to the fraction of damage a target shrugs off. Negative values are
+// weaknesses that add damage instead. True damage ignores the profile.
+type ResistanceProfile map[DamageType]float32
+
+func (p ResistanceProfile) Resistance(damageType DamageType) float32 {
+	if damageType == DamageTrue {
+		return 0
+	}
+	return clampFloat32(p[damageType], -MaxDamageWeakness, MaxDamageResistance)
+}
+
+func (p ResistanceProfile) Clone() ResistanceProfile {
+	if len(p) == 0 {
+		return nil
+	}
+	out := make(ResistanceProfile, len(p))
+	for damageType, value := range p {
+		out[damageType] = value
+	}
+	return out
+}
+
+func (p ResistanceProfile) Weaknesses() []DamageType {
+	return p.typesWhere(func(value float32) bool { return value < 0 })
+}
+
+func (p ResistanceProfile) Resists() []DamageType {
+	return p.typesWhere(func(value float32) bool { return value > 0 })
+}
+
+func (p ResistanceProfile) typesWhere(match func(float32) bool) []DamageType {
+	var types []DamageType
+	for damageType, value := range p {
+		if damageType != DamageTrue && match(value) {
+			types = append(types, damageType)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// MitigateDamage scales damage by a resistance. Hits that land always deal at least 1.
+func MitigateDamage(damage int, resistance float32) int {
+	if damage <= 0 {
+		return 0
+	}
+	mitigated := int(float32(damage) * (1 - resistance))
+	if mitigated < 1 {
+		return 1
+	}
+	return mitigated
+}
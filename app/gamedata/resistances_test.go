@@ -0,0 +1,33 @@
+package gamedata
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResistanceProfileClampsAndListsWeaknesses(t *testing.T) {
+	profile := ResistanceProfile{DamageFire: -0.5, DamagePoison: 2, DamagePhysical: 0.2, DamageTrue: 0.5}
+
+	if got := profile.Resistance(DamagePoison); got != MaxDamageResistance {
+		t.Fatalf("expected poison resistance clamped to %v, got %v", MaxDamageResistance, got)
+	}
+	if got := profile.Resistance(DamageTrue); got != 0 {
+		t.Fatalf("expected true damage to ignore resistances, got %v", got)
+	}
+	if got := profile.Weaknesses(); !reflect.DeepEqual(got, []DamageType{DamageFire}) {
+		t.Fatalf("expected fire weakness, got %v", got)
+	}
+	if got := profile.Resists(); !reflect.DeepEqual(got, []DamageType{DamagePhysical, DamagePoison}) {
+		t.Fatalf("expected physical and poison resists, got %v", got)
+	}
+
+	if got := MitigateDamage(20, profile.Resistance(DamageFire)); got != 30 {
+		t.Fatalf("expected weakness to raise 20 damage to 30, got %d", got)
+	}
+	if got := MitigateDamage(5, profile.Resistance(DamagePoison)); got != 1 {
+		t.Fatalf("expected mitigated hits to deal at least 1, got %d", got)
+	}
+	if got := MitigateDamage(0, profile.Resistance(DamageFire)); got != 0 {
+		t.Fatalf("expected zero damage to stay zero, got %d", got)
+	}
+}
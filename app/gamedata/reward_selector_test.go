@@ -34,10 +34,10 @@ func TestForestBiomeItemPoolMeetsCurationTargets(t *testing.T) {
 		seenIDs[item.ID] = struct{}{}
 
 		for _, effect := range item.Effects {
-			if effect.Type == ItemEffectBurnOnHit {
+			if effect.Action.Type == ItemActionApplyEffect && effect.Action.Effect.Type == EffectBurn {
 				hasBurn = true
 			}
-			if effect.Type == ItemEffectCritChanceVsSlowed {
+			if effect.Action.Type == ItemActionCritChance {
 				hasCritVsSlow = true
 			}
 		}
@@ -173,3 +173,23 @@ func TestWeightedSampleWithoutReplacementRespectsWeightBias(t *testing.T) {
 		t.Fatalf("expected weighted bias toward high weight item, high=%d low=%d", highPicks, lowPicks)
 	}
 }
+
+func TestGetItemByIDReturnsIndependentCopy(t *testing.T) {
+	item := GetItemByID("melee_bloodletter_axe")
+	if item == nil {
+		t.Fatalf("expected curated item lookup by id to succeed")
+	}
+	if item.Slot != ItemSlotWeapon || len(item.Effects) != 1 {
+		t.Fatalf("expected bloodletter axe weapon with one effect, got slot=%s effects=%d", item.Slot, len(item.Effects))
+	}
+
+	item.Effects[0].Action.PercentOfDamage = 99
+	again := GetItemByID("melee_bloodletter_axe")
+	if again.Effects[0].Action.PercentOfDamage == 99 {
+		t.Fatalf("expected item lookup to return a copy")
+	}
+
+	if GetItemByID("missing_item") != nil {
+		t.Fatalf("expected unknown item id to return nil")
+	}
+}
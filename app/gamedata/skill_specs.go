@@ -7,6 +7,7 @@ const (
 	TargetEnemy
 	TargetArea
 	TargetDirection
+	TargetAlly
 )
 
 type TargetingSpec struct {
@@ -32,6 +33,7 @@ type DeliverySpec struct {
 	Delay        float32
 	Lifetime     float32
 	Pierce       int
+	PierceTerrain bool
 	ProjectileRadius float32
 	ZoneDuration float32
 	ZoneTickRate float32
@@ -43,6 +45,10 @@ const (
 	DamagePhysical DamageType = iota
 	DamageMagical
 	DamageTrue
+	DamageFire
+	DamageFrost
+	DamagePoison
+	DamageLightning
 )
 
 type DamageSpec struct {
@@ -68,6 +74,7 @@ type SelfMovementMode int
 const (
 	SelfMovementNone SelfMovementMode = iota
 	SelfMovementBackwardFromCursor
+	SelfMovementTowardTarget
 )
 
 type SelfMovementSpec struct {
@@ -83,3 +90,17 @@ type ManaShieldSpec struct {
 type ResourceGainSpec struct {
 	ManaPerTarget int
 }
+
+type KnockbackSpec struct {
+	Impulse float32
+}
+
+type HealSpec struct {
+	Amount       int
+	PercentMaxHP float32
+}
+
+type SummonSpec struct {
+	Archetype EnemyArchetypeType
+	Count     int
+}
@@ -15,6 +15,7 @@ const (
 	SkillTypeManaShield
 	SkillTypeFrostField
 	SkillTypeArcaneDrain
+	SkillTypeEnemy // skills defined inline on enemy archetypes
 )
 
 type Skill struct {
@@ -30,9 +31,53 @@ type Skill struct {
 	SelfMovement    SelfMovementSpec
 	ManaShield      ManaShieldSpec
 	ResourceGain    ResourceGainSpec
+	Knockback       KnockbackSpec
+	Heal            HealSpec
+	Summon          SummonSpec
 }
 
+var skillTypeOrder = []SkillType{
+	SkillTypePowerStrike,
+	SkillTypeGuardStance,
+	SkillTypeBloodOath,
+	SkillTypeShockwaveSlam,
+	SkillTypeQuickShot,
+	SkillTypeRetreatRoll,
+	SkillTypeFocusedAim,
+	SkillTypePoisonTip,
+	SkillTypeArcaneBolt,
+	SkillTypeManaShield,
+	SkillTypeFrostField,
+	SkillTypeArcaneDrain,
+}
+
+var builtinClassSkills = map[ClassType][]SkillType{
+	ClassTypeMelee:  {SkillTypePowerStrike, SkillTypeGuardStance, SkillTypeBloodOath, SkillTypeShockwaveSlam},
+	ClassTypeRanged: {SkillTypeQuickShot, SkillTypeRetreatRoll, SkillTypeFocusedAim, SkillTypePoisonTip},
+	ClassTypeCaster: {SkillTypeArcaneBolt, SkillTypeManaShield, SkillTypeFrostField, SkillTypeArcaneDrain},
+}
+
+var skillTable = builtinSkillTable()
+
+var classSkillTable = builtinClassSkills
+
 func NewSkill(skillType SkillType) *Skill {
+	skill, ok := skillTable[skillType]
+	if !ok {
+		return nil
+	}
+	return cloneSkill(skill)
+}
+
+func builtinSkillTable() map[SkillType]Skill {
+	table := make(map[SkillType]Skill, len(skillTypeOrder))
+	for _, skillType := range skillTypeOrder {
+		table[skillType] = *builtinSkill(skillType)
+	}
+	return table
+}
+
+func builtinSkill(skillType SkillType) *Skill {
 	switch skillType {
 	case SkillTypePowerStrike:
 		return &Skill{
@@ -112,6 +157,7 @@ func NewSkill(skillType SkillType) *Skill {
 			Effects: []EffectSpec{
 				{Type: EffectSlow, Duration: 2.0, Magnitude: 0.3},
 			},
+			Knockback: KnockbackSpec{Impulse: 320},
 		}
 	case SkillTypeQuickShot:
 		return &Skill{
@@ -261,10 +307,16 @@ func NewSkill(skillType SkillType) *Skill {
 				MaxTargets: 10,
 			},
 			Delivery: DeliverySpec{
-				Type:         DeliveryDelayed,
-				Delay:        0.8,
-				ZoneDuration: 4.0,
-				ZoneTickRate: 1.0,
+				Type:          DeliveryDelayed,
+				Delay:         0.8,
+				PierceTerrain: true,
+				ZoneDuration:  4.0,
+				ZoneTickRate:  1.0,
+			},
+			DamageSpec: &DamageSpec{
+				Base:       6,
+				Scaling:    map[StatType]float32{StatTypeINT: 0.4},
+				DamageType: DamageFrost,
 			},
 			Effects: []EffectSpec{
 				{Type: EffectSlow, Duration: 1.2, Magnitude: 0.5},
@@ -299,31 +351,27 @@ func NewSkill(skillType SkillType) *Skill {
 }
 
 func GetClassSkills(classType ClassType) []*Skill {
-	switch classType {
-	case ClassTypeMelee:
-		return []*Skill{
-			NewSkill(SkillTypePowerStrike),
-			NewSkill(SkillTypeGuardStance),
-			NewSkill(SkillTypeBloodOath),
-			NewSkill(SkillTypeShockwaveSlam),
+	skillTypes := classSkillTable[classType]
+	skills := make([]*Skill, 0, len(skillTypes))
+	for _, skillType := range skillTypes {
+		if skill := NewSkill(skillType); skill != nil {
+			skills = append(skills, skill)
 		}
-	case ClassTypeRanged:
-		return []*Skill{
-			NewSkill(SkillTypeQuickShot),
-			NewSkill(SkillTypeRetreatRoll),
-			NewSkill(SkillTypeFocusedAim),
-			NewSkill(SkillTypePoisonTip),
-		}
-	case ClassTypeCaster:
-		return []*Skill{
-			NewSkill(SkillTypeArcaneBolt),
-			NewSkill(SkillTypeManaShield),
-			NewSkill(SkillTypeFrostField),
-			NewSkill(SkillTypeArcaneDrain),
+	}
+	return skills
+}
+
+func cloneSkill(skill Skill) *Skill {
+	if skill.DamageSpec != nil {
+		damage := *skill.DamageSpec
+		damage.Scaling = make(map[StatType]float32, len(skill.DamageSpec.Scaling))
+		for statType, value := range skill.DamageSpec.Scaling {
+			damage.Scaling[statType] = value
 		}
-	default:
-		return []*Skill{}
+		skill.DamageSpec = &damage
 	}
+	skill.Effects = append([]EffectSpec(nil), skill.Effects...)
+	return &skill
 }
 
 func (s *Skill) Update(deltaTime float32) {
@@ -96,17 +96,34 @@ type Boss struct {
 	BaseDamage             int
 	BaseMoveSpeed          float32
 	Projectiles            []*BossProjectile
+	FightTime              float32
+	PhaseIndex             int
+	RotationIndex          int
+	RotationCooldown       float32
+	scheduledAddWaves      []scheduledAddWave
+	pendingAddWaves        []gamedata.BossAddWaveConfig
+	VolleyCooldown         float32
+	volleyIndex            int
+	activeVolley           *bossVolleyRun
+	playerTracker          playerMotionTracker
+}
+
+type scheduledAddWave struct {
+	TimeLeft float32
+	Wave     gamedata.BossAddWaveConfig
 }
 
 type BossProjectile struct {
-	X      float32
-	Y      float32
-	VX     float32
-	VY     float32
-	Speed  float32
-	Damage int
-	Radius float32
-	Alive  bool
+	X          float32
+	Y          float32
+	VX         float32
+	VY         float32
+	Speed      float32
+	Damage     int
+	DamageType gamedata.DamageType
+	Effects    []gamedata.EffectSpec
+	Radius     float32
+	Alive      bool
 }
 
 func NewBoss(x, y float32, biome string) *Boss {
@@ -130,6 +147,9 @@ func NewBoss(x, y float32, biome string) *Boss {
 	enemy.RetreatRange = 0
 	enemy.XPReward = 120
 	enemy.Stats = nil
+	enemy.CC = bossCrowdControl(cfg.CCResistance)
+	enemy.Resistances = cfg.Resistances.Clone()
+	enemy.Defense = cfg.Defense
 
 	boss := &Boss{
 		Enemy:                  enemy,
@@ -152,9 +172,88 @@ func NewBoss(x, y float32, biome string) *Boss {
 	if boss.AreaCooldownRemaining < 0 {
 		boss.AreaCooldownRemaining = 0
 	}
+	if len(cfg.Volleys) > 0 {
+		boss.VolleyCooldown = cfg.Volleys[0].Cooldown * 0.55
+	}
+	if boss.scripted() {
+		boss.enterPhase(0)
+	}
 	return boss
 }
 
+func bossCrowdControl(resistance gamedata.BossCCResistanceConfig) gamedata.CrowdControlTracker {
+	return gamedata.CrowdControlTracker{
+		DurationMultiplier: resistance.DurationMultiplier,
+		Immune:             resistance.Immune,
+		DiminishSlows:      true,
+	}
+}
+
+func (b *Boss) IsAlive() bool {
+	return b != nil && b.Enemy != nil && b.Enemy.IsAlive()
+}
+
+// scripted reports a boss driven by its configured phase list instead of the single enrage threshold.
+func (b *Boss) scripted() bool {
+	return len(b.Config.Phases) > 0
+}
+
+func (b *Boss) currentPhase() gamedata.BossPhaseConfig {
+	if b.PhaseIndex < 0 || b.PhaseIndex >= len(b.Config.Phases) {
+		return gamedata.BossPhaseConfig{}
+	}
+	return b.Config.Phases[b.PhaseIndex]
+}
+
+func (b *Boss) PhaseName() string {
+	if b.scripted() {
+		return b.currentPhase().Name
+	}
+	return b.Phase.String()
+}
+
+func (b *Boss) applyEnrageStats() {
+	damage := int(float32(b.BaseDamage) * b.Config.Enrage.DamageMultiplier)
+	if damage < 1 {
+		damage = b.BaseDamage
+	}
+	b.Damage = damage
+
+	moveSpeed := b.BaseMoveSpeed * b.Config.Enrage.MoveSpeedMultiplier
+	if moveSpeed <= 0 {
+		moveSpeed = b.BaseMoveSpeed
+	}
+	b.MoveSpeed = moveSpeed
+}
+
+// RefreshConfig re-reads the encounter config for the boss biome, keeping position, HP ratio and phase.
+func (b *Boss) RefreshConfig() {
+	if b == nil || b.Enemy == nil {
+		return
+	}
+	cfg := gamedata.GetBossEncounterConfig(b.Config.Biome)
+	b.Config = cfg
+	b.BaseDamage = cfg.Damage
+	b.BaseMoveSpeed = cfg.MoveSpeed
+	b.Damage = cfg.Damage
+	b.MoveSpeed = cfg.MoveSpeed
+	if b.scripted() {
+		b.PhaseIndex = min(b.PhaseIndex, len(cfg.Phases)-1)
+		b.applyPhaseStats(b.currentPhase())
+	} else if b.EnrageTriggered {
+		b.applyEnrageStats()
+	}
+	b.AttackRange = cfg.AttackRange
+	b.AggroRange = cfg.AggroRange
+	b.AttackCooldown = cfg.AttackCooldown
+	b.PreferredRange = cfg.AttackRange * 0.8
+	b.CC.DurationMultiplier = cfg.CCResistance.DurationMultiplier
+	b.CC.Immune = cfg.CCResistance.Immune
+	b.Resistances = cfg.Resistances.Clone()
+	b.Defense = cfg.Defense
+	b.rescale(cfg.MaxHP, cfg.Width, cfg.Height)
+}
+
 func (b *Boss) Update(deltaTime float32, playerX, playerY float32) {
 	if b == nil || !b.Entity.IsAlive() {
 		return
@@ -166,12 +265,117 @@ func (b *Boss) Update(deltaTime float32, playerX, playerY float32) {
 
 	b.Enemy.Update(deltaTime)
 	ResolveEnemyIntent(b.Enemy, playerX, playerY)
-	b.updateEnrageState()
-	b.updateHeavyAttack(deltaTime, playerX, playerY)
-	b.updateAreaDenial(deltaTime, playerX, playerY)
+	b.playerTracker.Observe(deltaTime, playerX, playerY)
+	if b.scripted() {
+		b.updateScriptedPhases(deltaTime, playerX, playerY)
+	} else {
+		b.updateEnrageState()
+		b.updateHeavyAttack(deltaTime, playerX, playerY)
+		b.updateAreaDenial(deltaTime, playerX, playerY)
+		b.updateVolleyCooldown(deltaTime, playerX, playerY)
+	}
+	b.advanceVolley(deltaTime, playerX, playerY)
 	b.updateAreaZones(deltaTime)
 }
 
+func (b *Boss) updateScriptedPhases(deltaTime float32, playerX, playerY float32) {
+	b.FightTime += deltaTime
+	next := b.PhaseIndex
+	for next+1 < len(b.Config.Phases) && b.phaseReached(b.Config.Phases[next+1]) {
+		next++
+	}
+	if next != b.PhaseIndex {
+		b.enterPhase(next)
+	}
+
+	if b.InvulnerableTimer > 0 {
+		b.WantsAttack = false
+	}
+	b.advanceHeavyAttack(deltaTime)
+	b.updateRotation(deltaTime, playerX, playerY)
+	b.updateAddWaves(deltaTime)
+}
+
+func (b *Boss) phaseReached(phase gamedata.BossPhaseConfig) bool {
+	if phase.HPThresholdPercent > 0 && float32(b.HP) <= float32(b.MaxHP)*phase.HPThresholdPercent {
+		return true
+	}
+	return phase.TimeThreshold > 0 && b.FightTime >= phase.TimeThreshold
+}
+
+// enterPhase switches to a phase, restarting its rotation and add waves behind its invulnerability window.
+// Phases skipped on the way are not played.
+func (b *Boss) enterPhase(index int) {
+	b.PhaseIndex = index
+	phase := b.currentPhase()
+	b.applyPhaseStats(phase)
+	b.InvulnerableTimer = phase.InvulnerableDuration
+	b.RotationIndex = 0
+	b.RotationCooldown = phase.RotationInterval * 0.5
+	b.scheduledAddWaves = b.scheduledAddWaves[:0]
+	for _, wave := range phase.AddWaves {
+		b.scheduledAddWaves = append(b.scheduledAddWaves, scheduledAddWave{TimeLeft: wave.Delay, Wave: wave})
+	}
+}
+
+func (b *Boss) applyPhaseStats(phase gamedata.BossPhaseConfig) {
+	b.Damage = max(1, int(float32(b.BaseDamage)*phase.DamageMultiplier))
+	b.MoveSpeed = b.BaseMoveSpeed * phase.MoveSpeedMultiplier
+	if b.MoveSpeed <= 0 {
+		b.MoveSpeed = b.BaseMoveSpeed
+	}
+}
+
+// updateRotation performs the phase's special attacks in order, one every rotation interval.
+func (b *Boss) updateRotation(deltaTime float32, playerX, playerY float32) {
+	phase := b.currentPhase()
+	if len(phase.Rotation) == 0 || b.InvulnerableTimer > 0 {
+		return
+	}
+	if b.RotationCooldown > 0 {
+		b.RotationCooldown -= deltaTime
+		return
+	}
+	if b.HeavyState == BossHeavyAttackTelegraph || !b.canStartAttack(playerX, playerY) {
+		return
+	}
+
+	step := phase.Rotation[b.RotationIndex%len(phase.Rotation)]
+	switch step.Attack {
+	case gamedata.BossAttackHeavy:
+		b.startHeavyAttack(playerX, playerY)
+	case gamedata.BossAttackArea:
+		b.castAreaDenial(playerX, playerY)
+	case gamedata.BossAttackVolley:
+		if volley, ok := b.Config.Volley(step.Volley); ok {
+			b.startVolley(volley, playerX, playerY)
+		}
+	}
+	b.RotationIndex++
+	b.RotationCooldown = phase.RotationInterval
+}
+
+func (b *Boss) updateAddWaves(deltaTime float32) {
+	for i := len(b.scheduledAddWaves) - 1; i >= 0; i-- {
+		b.scheduledAddWaves[i].TimeLeft -= deltaTime
+		if b.scheduledAddWaves[i].TimeLeft > 0 {
+			continue
+		}
+		b.pendingAddWaves = append(b.pendingAddWaves, b.scheduledAddWaves[i].Wave)
+		b.scheduledAddWaves = append(b.scheduledAddWaves[:i], b.scheduledAddWaves[i+1:]...)
+	}
+}
+
+func (b *Boss) canStartAttack(playerX, playerY float32) bool {
+	if !gamedata.CanAct(&b.Effects) {
+		return false
+	}
+	centerX, centerY := b.Center()
+	dx := playerX - centerX
+	dy := playerY - centerY
+	return float32(math.Sqrt(float64(dx*dx+dy*dy))) <= b.AggroRange
+}
+
 func (b *Boss) updateEnrageState() {
 	if b.EnrageTriggered {
 		return
@@ -186,18 +390,7 @@ func (b *Boss) updateEnrageState() {
 
 	b.EnrageTriggered = true
 	b.Phase = BossPhaseEnraged
-
-	damage := int(float32(b.BaseDamage) * b.Config.Enrage.DamageMultiplier)
-	if damage < 1 {
-		damage = b.BaseDamage
-	}
-	b.Damage = damage
-
-	moveSpeed := b.BaseMoveSpeed * b.Config.Enrage.MoveSpeedMultiplier
-	if moveSpeed <= 0 {
-		moveSpeed = b.BaseMoveSpeed
-	}
-	b.MoveSpeed = moveSpeed
+	b.applyEnrageStats()
 
 	if b.HeavyState == BossHeavyAttackCooldown {
 		maxHeavyCooldown := b.currentHeavyCooldown()
@@ -213,6 +406,18 @@ func (b *Boss) updateEnrageState() {
 }
 
 func (b *Boss) updateHeavyAttack(deltaTime float32, playerX, playerY float32) {
+	b.advanceHeavyAttack(deltaTime)
+	if b.HeavyState != BossHeavyAttackIdle || b.HeavyCooldownRemaining > 0 {
+		return
+	}
+	if !b.canStartAttack(playerX, playerY) {
+		return
+	}
+	b.startHeavyAttack(playerX, playerY)
+}
+
+// advanceHeavyAttack counts down a running telegraph, resolving it into a damage event, and then its cooldown.
+func (b *Boss) advanceHeavyAttack(deltaTime float32) {
 	switch b.HeavyState {
 	case BossHeavyAttackTelegraph:
 		b.HeavyTelegraph.TimeLeft -= deltaTime
@@ -229,7 +434,6 @@ func (b *Boss) updateHeavyAttack(deltaTime float32, playerX, playerY float32) {
 			b.HeavyState = BossHeavyAttackCooldown
 			b.HeavyCooldownRemaining = b.currentHeavyCooldown()
 		}
-		return
 	case BossHeavyAttackCooldown:
 		b.HeavyCooldownRemaining -= deltaTime
 		if b.HeavyCooldownRemaining <= 0 {
@@ -237,22 +441,9 @@ func (b *Boss) updateHeavyAttack(deltaTime float32, playerX, playerY float32) {
 			b.HeavyState = BossHeavyAttackIdle
 		}
 	}
+}
 
-	if b.HeavyState != BossHeavyAttackIdle || b.HeavyCooldownRemaining > 0 {
-		return
-	}
-	if !gamedata.CanAct(&b.Effects) {
-		return
-	}
-
-	centerX, centerY := b.Center()
-	dx := playerX - centerX
-	dy := playerY - centerY
-	distance := float32(math.Sqrt(float64(dx*dx + dy*dy)))
-	if distance > b.AggroRange {
-		return
-	}
-
+func (b *Boss) startHeavyAttack(playerX, playerY float32) {
 	duration := b.Config.HeavyAttack.TelegraphDuration
 	if duration <= 0 {
 		duration = 0.2
@@ -280,18 +471,14 @@ func (b *Boss) updateAreaDenial(deltaTime float32, playerX, playerY float32) {
 	if b.AreaCooldownRemaining > 0 {
 		return
 	}
-	if !gamedata.CanAct(&b.Effects) {
-		return
-	}
-
-	centerX, centerY := b.Center()
-	dx := playerX - centerX
-	dy := playerY - centerY
-	distance := float32(math.Sqrt(float64(dx*dx + dy*dy)))
-	if distance > b.AggroRange {
+	if !b.canStartAttack(playerX, playerY) {
 		return
 	}
+	b.castAreaDenial(playerX, playerY)
+	b.AreaCooldownRemaining = b.currentAreaCooldown()
+}
 
+func (b *Boss) castAreaDenial(playerX, playerY float32) {
 	count := b.Config.AreaDenial.ZoneCount
 	if b.EnrageTriggered {
 		count += b.Config.Enrage.ZoneCountBonus
@@ -314,8 +501,6 @@ func (b *Boss) updateAreaDenial(deltaTime float32, playerX, playerY float32) {
 		}
 		b.spawnAreaZone(zoneX, zoneY)
 	}
-
-	b.AreaCooldownRemaining = b.currentAreaCooldown()
 }
 
 func (b *Boss) spawnAreaZone(x, y float32) {
@@ -441,6 +626,16 @@ func (b *Boss) ConsumeDamageEvents() []BossDamageEvent {
 	return out
 }
 
+// ConsumeAddWaves hands over add waves that came due since the last call.
+func (b *Boss) ConsumeAddWaves() []gamedata.BossAddWaveConfig {
+	if len(b.pendingAddWaves) == 0 {
+		return nil
+	}
+	out := b.pendingAddWaves
+	b.pendingAddWaves = nil
+	return out
+}
+
 func (b *Boss) ActiveHeavyTelegraph() (BossTelegraph, bool) {
 	if b.HeavyState != BossHeavyAttackTelegraph || b.HeavyTelegraph.TimeLeft <= 0 {
 		return BossTelegraph{}, false
@@ -1,9 +1,13 @@
 package gameobjects
 
-import "testing"
+import (
+	"testing"
+
+	"singlefantasy/app/gamedata"
+)
 
 func TestBossEnrageTriggersOnceAtThreshold(t *testing.T) {
-	boss := NewBoss(0, 0, "forest")
+	boss := newUnscriptedBoss()
 	threshold := int(float32(boss.MaxHP) * boss.Config.Enrage.ThresholdHPPercent)
 	if threshold < 1 {
 		threshold = 1
@@ -33,7 +37,7 @@ func TestBossEnrageTriggersOnceAtThreshold(t *testing.T) {
 }
 
 func TestBossHeavyTelegraphResolvesAtSnapshottedLocation(t *testing.T) {
-	boss := NewBoss(0, 0, "forest")
+	boss := newUnscriptedBoss()
 	boss.HeavyCooldownRemaining = 0
 	boss.AreaCooldownRemaining = boss.Config.AreaDenial.Cooldown
 
@@ -70,7 +74,7 @@ func TestBossHeavyTelegraphResolvesAtSnapshottedLocation(t *testing.T) {
 }
 
 func TestBossAreaDenialTicksAndCleansUp(t *testing.T) {
-	boss := NewBoss(0, 0, "forest")
+	boss := newUnscriptedBoss()
 	boss.HeavyCooldownRemaining = 999
 	boss.AreaCooldownRemaining = 0
 
@@ -104,7 +108,7 @@ func TestBossAreaDenialTicksAndCleansUp(t *testing.T) {
 }
 
 func TestBossEnrageIncreasesAreaZoneCount(t *testing.T) {
-	boss := NewBoss(0, 0, "forest")
+	boss := newUnscriptedBoss()
 	threshold := int(float32(boss.MaxHP) * boss.Config.Enrage.ThresholdHPPercent)
 	if threshold < 1 {
 		threshold = 1
@@ -150,3 +154,108 @@ func TestBossProvokedOutsideAggroRangeStillChases(t *testing.T) {
 		t.Fatalf("expected provoked boss to chase outside aggro range, got %d", boss.State)
 	}
 }
+
+// newUnscriptedBoss drops the shipped phase script so the boss runs its cooldown and enrage fallback.
+func newUnscriptedBoss() *Boss {
+	boss := NewBoss(0, 0, "forest")
+	boss.Config.Phases = nil
+	return boss
+}
+
+func newScriptedBoss(phases ...gamedata.BossPhaseConfig) *Boss {
+	boss := NewBoss(0, 0, "forest")
+	boss.Config.Phases = phases
+	boss.enterPhase(0)
+	return boss
+}
+
+func TestScriptedBossAdvancesPhasesOnHPAndTime(t *testing.T) {
+	boss := newScriptedBoss(
+		gamedata.BossPhaseConfig{Name: "opening", RotationInterval: 2, DamageMultiplier: 1, MoveSpeedMultiplier: 1},
+		gamedata.BossPhaseConfig{Name: "wounded", HPThresholdPercent: 0.6, RotationInterval: 2, DamageMultiplier: 1.5, MoveSpeedMultiplier: 1, InvulnerableDuration: 1},
+		gamedata.BossPhaseConfig{Name: "frenzy", TimeThreshold: 30, RotationInterval: 2, DamageMultiplier: 2, MoveSpeedMultiplier: 1.2},
+	)
+	boss.Update(0.016, 100, 0)
+	if boss.PhaseName() != "opening" {
+		t.Fatalf("expected opening phase, got %s", boss.PhaseName())
+	}
+
+	boss.HP = boss.MaxHP / 2
+	boss.Update(0.016, 100, 0)
+	if boss.PhaseName() != "wounded" || boss.Damage != int(float32(boss.BaseDamage)*1.5) {
+		t.Fatalf("expected wounded phase stats, got %s damage %d", boss.PhaseName(), boss.Damage)
+	}
+	hp := boss.HP
+	boss.TakeDamage(50)
+	if boss.HP != hp {
+		t.Fatalf("expected phase transition invulnerability to block damage")
+	}
+
+	boss.Update(30, 100, 0)
+	if boss.PhaseName() != "frenzy" {
+		t.Fatalf("expected time threshold to reach frenzy, got %s", boss.PhaseName())
+	}
+	boss.Update(0.016, 100, 0)
+	boss.TakeDamage(50)
+	if boss.HP != hp-50 {
+		t.Fatalf("expected damage once the invulnerability window ends")
+	}
+}
+
+func TestScriptedBossFollowsPhaseRotation(t *testing.T) {
+	boss := newScriptedBoss(gamedata.BossPhaseConfig{
+		Name:                "opening",
+		Rotation:            []gamedata.BossRotationStep{{Attack: gamedata.BossAttackArea}, {Attack: gamedata.BossAttackHeavy}},
+		RotationInterval:    2,
+		DamageMultiplier:    1,
+		MoveSpeedMultiplier: 1,
+	})
+	boss.RotationCooldown = 0
+
+	boss.Update(0.016, 100, 0)
+	if len(boss.AreaZones) == 0 || boss.HeavyState != BossHeavyAttackIdle {
+		t.Fatalf("expected rotation to open with area denial")
+	}
+
+	boss.Update(2.1, 100, 0)
+	boss.Update(0.016, 100, 0)
+	if _, ok := boss.ActiveHeavyTelegraph(); !ok {
+		t.Fatalf("expected heavy attack next in rotation")
+	}
+}
+
+func TestScriptedBossReleasesAddWavesAfterDelay(t *testing.T) {
+	wave := gamedata.BossAddWaveConfig{Delay: 1, Count: 2, Archetypes: []gamedata.EnemyArchetypeType{gamedata.EnemyArchetypeSwarmling}}
+	boss := newScriptedBoss(
+		gamedata.BossPhaseConfig{Name: "opening", RotationInterval: 2, DamageMultiplier: 1, MoveSpeedMultiplier: 1},
+		gamedata.BossPhaseConfig{Name: "swarm", HPThresholdPercent: 0.5, RotationInterval: 2, DamageMultiplier: 1, MoveSpeedMultiplier: 1, AddWaves: []gamedata.BossAddWaveConfig{wave}},
+	)
+	boss.HP = boss.MaxHP / 2
+	boss.Update(0.5, 100, 0)
+	if len(boss.ConsumeAddWaves()) != 0 {
+		t.Fatalf("expected add wave to wait for its delay")
+	}
+
+	boss.Update(0.6, 100, 0)
+	waves := boss.ConsumeAddWaves()
+	if len(waves) != 1 || waves[0].Count != 2 {
+		t.Fatalf("expected the swarm wave, got %+v", waves)
+	}
+	boss.Update(5, 100, 0)
+	if len(boss.ConsumeAddWaves()) != 0 {
+		t.Fatalf("expected each add wave to spawn once")
+	}
+}
+
+func TestShippedWardenRunsItsPhaseScript(t *testing.T) {
+	boss := NewBoss(0, 0, "forest")
+	if len(boss.Config.Phases) < 2 || boss.PhaseName() != boss.Config.Phases[0].Name {
+		t.Fatalf("expected the warden to open its phase script, got %q", boss.PhaseName())
+	}
+
+	boss.HP = boss.MaxHP / 2
+	boss.Update(0.016, 100, 0)
+	if boss.PhaseIndex != 1 || boss.InvulnerableTimer <= 0 {
+		t.Fatalf("expected the warden to enter its second phase behind invulnerability, got phase %d", boss.PhaseIndex)
+	}
+}
@@ -0,0 +1,165 @@
+package gameobjects
+
+import (
+	"math"
+
+	"singlefantasy/app/gamedata"
+)
+
+type bossVolleyRun struct {
+	Volley     gamedata.BossVolleyConfig
+	WavesFired int
+	WaveTimer  float32
+	BaseAngle  float64
+}
+
+// playerMotionTracker estimates player velocity from the positions the boss sees each frame.
+type playerMotionTracker struct {
+	X, Y    float32
+	VX, VY  float32
+	tracked bool
+}
+
+func (t *playerMotionTracker) Observe(deltaTime, x, y float32) {
+	if t.tracked && deltaTime > 0 {
+		t.VX = (x - t.X) / deltaTime
+		t.VY = (y - t.Y) / deltaTime
+	}
+	t.X, t.Y = x, y
+	t.tracked = true
+}
+
+// updateVolleyCooldown cycles through the configured volleys, firing the next one each time the cooldown expires.
+func (b *Boss) updateVolleyCooldown(deltaTime float32, playerX, playerY float32) {
+	if len(b.Config.Volleys) == 0 || b.activeVolley != nil {
+		return
+	}
+	b.VolleyCooldown -= deltaTime
+	if b.VolleyCooldown > 0 {
+		return
+	}
+	if b.HeavyState == BossHeavyAttackTelegraph || !b.canStartAttack(playerX, playerY) {
+		return
+	}
+	volley := b.Config.Volleys[b.volleyIndex%len(b.Config.Volleys)]
+	b.volleyIndex++
+	b.startVolley(volley, playerX, playerY)
+	b.VolleyCooldown = volley.Cooldown
+}
+
+func (b *Boss) startVolley(volley gamedata.BossVolleyConfig, playerX, playerY float32) {
+	centerX, centerY := b.Center()
+	b.activeVolley = &bossVolleyRun{
+		Volley:    volley,
+		BaseAngle: math.Atan2(float64(playerY-centerY), float64(playerX-centerX)),
+	}
+}
+
+func (b *Boss) advanceVolley(deltaTime float32, playerX, playerY float32) {
+	run := b.activeVolley
+	if run == nil {
+		return
+	}
+	run.WaveTimer -= deltaTime
+	if run.WaveTimer <= 0 {
+		b.fireVolleyWave(playerX, playerY)
+	}
+	if run.WavesFired >= run.Volley.Waves {
+		b.activeVolley = nil
+	}
+}
+
+func (b *Boss) fireVolleyWave(playerX, playerY float32) {
+	run := b.activeVolley
+	volley := run.Volley
+	for _, angle := range b.volleyAngles(run, playerX, playerY) {
+		b.spawnProjectile(volley, angle)
+	}
+	run.WavesFired++
+	run.WaveTimer = volley.Cadence
+}
+
+func (b *Boss) volleyAngles(run *bossVolleyRun, playerX, playerY float32) []float64 {
+	volley := run.Volley
+	centerX, centerY := b.Center()
+	aim := math.Atan2(float64(playerY-centerY), float64(playerX-centerX))
+	spin := degreesToRadians(volley.Spin) * float64(run.WavesFired)
+	arc := degreesToRadians(volley.Arc)
+
+	switch volley.Pattern {
+	case gamedata.BossVolleySpiral:
+		return ringAngles(run.BaseAngle+spin, volley.Count)
+	case gamedata.BossVolleyAimedFan:
+		return fanAngles(aim, arc, volley.Count)
+	case gamedata.BossVolleyPredictive:
+		distance := math.Hypot(float64(playerX-centerX), float64(playerY-centerY))
+		lead := float32(distance / float64(volley.Speed))
+		targetX := playerX + b.playerTracker.VX*lead
+		targetY := playerY + b.playerTracker.VY*lead
+		return fanAngles(math.Atan2(float64(targetY-centerY), float64(targetX-centerX)), arc, volley.Count)
+	case gamedata.BossVolleyGappedRing:
+		// The gap opens toward the player when the volley starts and then turns by Spin each wave.
+		gapCenter := run.BaseAngle + spin
+		angles := make([]float64, 0, volley.Count)
+		for _, angle := range ringAngles(gapCenter+math.Pi/float64(volley.Count), volley.Count) {
+			if angularDistance(angle, gapCenter) < arc/2 {
+				continue
+			}
+			angles = append(angles, angle)
+		}
+		return angles
+	default:
+		return ringAngles(aim+spin, volley.Count)
+	}
+}
+
+func (b *Boss) spawnProjectile(volley gamedata.BossVolleyConfig, angle float64) {
+	centerX, centerY := b.Center()
+	damage := volley.Damage
+	if b.BaseDamage > 0 {
+		damage = max(1, int(float32(volley.Damage)*float32(b.Damage)/float32(b.BaseDamage)))
+	}
+	b.Projectiles = append(b.Projectiles, &BossProjectile{
+		X:          centerX,
+		Y:          centerY,
+		VX:         float32(math.Cos(angle)) * volley.Speed,
+		VY:         float32(math.Sin(angle)) * volley.Speed,
+		Speed:      volley.Speed,
+		Damage:     damage,
+		DamageType: volley.DamageType,
+		Effects:    volley.Effects,
+		Radius:     volley.Radius,
+		Alive:      true,
+	})
+}
+
+func ringAngles(start float64, count int) []float64 {
+	angles := make([]float64, count)
+	for i := range angles {
+		angles[i] = start + 2*math.Pi*float64(i)/float64(count)
+	}
+	return angles
+}
+
+func fanAngles(center, arc float64, count int) []float64 {
+	if count == 1 {
+		return []float64{center}
+	}
+	angles := make([]float64, count)
+	for i := range angles {
+		angles[i] = center - arc/2 + arc*float64(i)/float64(count-1)
+	}
+	return angles
+}
+
+func angularDistance(a, b float64) float64 {
+	diff := math.Mod(math.Abs(a-b), 2*math.Pi)
+	if diff > math.Pi {
+		diff = 2*math.Pi - diff
+	}
+	return diff
+}
+
+func degreesToRadians(degrees float32) float64 {
+	return float64(degrees) * math.Pi / 180
+}
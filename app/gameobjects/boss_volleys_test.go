@@ -0,0 +1,108 @@
+package gameobjects
+
+import (
+	"math"
+	"testing"
+
+	"singlefantasy/app/gamedata"
+)
+
+func newVolleyBoss(volley gamedata.BossVolleyConfig) *Boss {
+	boss := newUnscriptedBoss()
+	boss.Config.Volleys = []gamedata.BossVolleyConfig{volley}
+	boss.VolleyCooldown = 0
+	boss.HeavyCooldownRemaining = 100
+	boss.AreaCooldownRemaining = 100
+	return boss
+}
+
+func projectileAngles(projectiles []*BossProjectile) []float64 {
+	angles := make([]float64, len(projectiles))
+	for i, proj := range projectiles {
+		angles[i] = math.Atan2(float64(proj.VY), float64(proj.VX))
+	}
+	return angles
+}
+
+func TestBossFiresConfiguredVolleyOnCooldown(t *testing.T) {
+	boss := newVolleyBoss(gamedata.BossVolleyConfig{
+		ID: "burst", Pattern: gamedata.BossVolleyRadial, Count: 8, Waves: 2, Cadence: 0.5, Cooldown: 4, Speed: 200, Radius: 8, Damage: 10,
+		DamageType: gamedata.DamageMagical, Effects: []gamedata.EffectSpec{{Type: gamedata.EffectSlow, Duration: 1, Magnitude: 0.2}},
+	})
+
+	boss.Update(0.016, 200, 0)
+	if len(boss.Projectiles) != 8 {
+		t.Fatalf("expected first radial wave of 8, got %d", len(boss.Projectiles))
+	}
+	proj := boss.Projectiles[0]
+	if proj.Speed != 200 || proj.DamageType != gamedata.DamageMagical || len(proj.Effects) != 1 {
+		t.Fatalf("expected projectile to carry the volley payload, got %+v", proj)
+	}
+
+	boss.Update(0.5, 200, 0)
+	if len(boss.Projectiles) != 16 {
+		t.Fatalf("expected second wave after the cadence, got %d", len(boss.Projectiles))
+	}
+	boss.Update(1, 200, 0)
+	if len(boss.Projectiles) != 16 {
+		t.Fatalf("expected the volley to stop after its waves")
+	}
+	boss.Update(3, 200, 0)
+	if len(boss.Projectiles) != 24 {
+		t.Fatalf("expected the next volley once the cooldown expires, got %d", len(boss.Projectiles))
+	}
+}
+
+func TestBossAimedFanCentersOnPlayer(t *testing.T) {
+	boss := newVolleyBoss(gamedata.BossVolleyConfig{ID: "fan", Pattern: gamedata.BossVolleyAimedFan, Count: 3, Cooldown: 4, Speed: 200, Arc: 40, Damage: 5})
+	centerX, centerY := boss.Center()
+
+	boss.Update(0.016, centerX, centerY+300)
+	angles := projectileAngles(boss.Projectiles)
+	if len(angles) != 3 || math.Abs(angles[1]-math.Pi/2) > 1e-3 || math.Abs(angles[2]-angles[0]-degreesToRadians(40)) > 1e-3 {
+		t.Fatalf("expected three shots spread 40 degrees around the player, got %v", angles)
+	}
+}
+
+func TestBossPredictiveVolleyLeadsMovingPlayer(t *testing.T) {
+	boss := newVolleyBoss(gamedata.BossVolleyConfig{ID: "snipe", Pattern: gamedata.BossVolleyPredictive, Count: 1, Cooldown: 4, Speed: 300, Damage: 5})
+	boss.VolleyCooldown = 0.15
+	centerX, centerY := boss.Center()
+
+	boss.Update(0.1, centerX+300, centerY)
+	boss.Update(0.1, centerX+300, centerY+10)
+	if len(boss.Projectiles) != 1 {
+		t.Fatalf("expected one predictive shot, got %d", len(boss.Projectiles))
+	}
+	if proj := boss.Projectiles[0]; proj.VY <= 0 || math.Atan2(float64(proj.VY), float64(proj.VX)) <= math.Atan2(10, 300) {
+		t.Fatalf("expected shot to lead the player's downward motion, got %+v", proj)
+	}
+}
+
+func TestBossSpiralTurnsBetweenWaves(t *testing.T) {
+	boss := newVolleyBoss(gamedata.BossVolleyConfig{ID: "spiral", Pattern: gamedata.BossVolleySpiral, Count: 4, Waves: 2, Cadence: 0.2, Cooldown: 4, Speed: 150, Spin: 30, Damage: 5})
+	centerX, centerY := boss.Center()
+
+	boss.Update(0.016, centerX+200, centerY)
+	boss.Update(0.2, centerX, centerY+200)
+	angles := projectileAngles(boss.Projectiles)
+	if len(angles) != 8 || math.Abs(angles[4]-angles[0]-degreesToRadians(30)) > 1e-3 {
+		t.Fatalf("expected second spiral wave turned 30 degrees from the first regardless of aim, got %v", angles)
+	}
+}
+
+func TestBossGappedRingLeavesGapTowardPlayer(t *testing.T) {
+	boss := newVolleyBoss(gamedata.BossVolleyConfig{ID: "ring", Pattern: gamedata.BossVolleyGappedRing, Count: 12, Cooldown: 4, Speed: 150, Arc: 60, Damage: 5})
+	centerX, centerY := boss.Center()
+
+	boss.Update(0.016, centerX+200, centerY)
+	angles := projectileAngles(boss.Projectiles)
+	if len(angles) != 10 {
+		t.Fatalf("expected a 60 degree gap to drop two of twelve shots, got %d", len(angles))
+	}
+	for _, angle := range angles {
+		if angularDistance(angle, 0) < degreesToRadians(30) {
+			t.Fatalf("expected no shot inside the gap toward the player, got %v", angle)
+		}
+	}
+}
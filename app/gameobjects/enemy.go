@@ -1,6 +1,8 @@
 package gameobjects
 
 import (
+	"math"
+
 	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 )
@@ -47,10 +49,15 @@ type Enemy struct {
 	ProjectileRadius   float32
 	ProjectileLifetime float32
 	OnHitEffects       []gamedata.EffectSpec
+	Resistances        gamedata.ResistanceProfile
+	Defense            gamedata.DefenseStats
+	Skills             []gamedata.EnemySkill
+	PendingSkill       *gamedata.EnemySkill
 	XPReward           int
 	ThreatValue        int
 	HitFlashTimer      float32
 	AttackFlashTimer   float32
+	InvulnerableTimer  float32
 	FacingRight        bool
 	State              EnemyState
 	IsElite            bool
@@ -58,8 +65,11 @@ type Enemy struct {
 	EliteModifierName  string
 	IntentMoveX        float32
 	IntentMoveY        float32
+	KnockbackVelX      float32
+	KnockbackVelY      float32
 	WantsAttack        bool
 	Provoked           bool
+	SightBlocked       bool
 }
 
 func NewEnemy(x, y float32, isElite bool) *Enemy {
@@ -122,10 +132,14 @@ func NewEnemyFromArchetype(x, y float32, archetypeType gamedata.EnemyArchetypeTy
 		ProjectileRadius:   archetype.ProjectileRadius,
 		ProjectileLifetime: archetype.ProjectileLifetime,
 		OnHitEffects:       combinedEffects,
+		Resistances:        archetype.Resistances.Clone(),
+		Defense:            archetype.Defense,
+		Skills:             gamedata.CloneEnemySkills(archetype.Skills),
 		XPReward:           archetype.XPReward,
 		ThreatValue:        archetype.ThreatValue,
 		HitFlashTimer:      0,
 		AttackFlashTimer:   0,
+		InvulnerableTimer:  0,
 		FacingRight:        true,
 		State:              EnemyStateIdle,
 		IsElite:            isElite,
@@ -133,8 +147,11 @@ func NewEnemyFromArchetype(x, y float32, archetypeType gamedata.EnemyArchetypeTy
 		EliteModifierName:  modifierName,
 		IntentMoveX:        0,
 		IntentMoveY:        0,
+		KnockbackVelX:      0,
+		KnockbackVelY:      0,
 		WantsAttack:        false,
 		Provoked:           false,
+		SightBlocked:       false,
 	}
 }
 
@@ -164,10 +181,23 @@ func (e *Enemy) Update(deltaTime float32) {
 		}
 	}
 
-	gamedata.UpdateEffects(&e.Entity.Effects, deltaTime, e.TakeDamage)
+	if e.InvulnerableTimer > 0 {
+		e.InvulnerableTimer -= deltaTime
+		if e.InvulnerableTimer < 0 {
+			e.InvulnerableTimer = 0
+		}
+	}
+
+	for i := range e.Skills {
+		e.Skills[i].Skill.Update(deltaTime)
+	}
+
+	gamedata.UpdateEffects(&e.Entity.Effects, deltaTime, e.TakeTypedDamage)
+	e.CC.Update(deltaTime)
 	e.IntentMoveX = 0
 	e.IntentMoveY = 0
 	e.WantsAttack = false
+	e.PendingSkill = nil
 	if !gamedata.CanAct(&e.Entity.Effects) {
 		e.State = EnemyStateIdle
 		return
@@ -188,7 +218,7 @@ func (e *Enemy) Attack(playerX, playerY float32) (bool, EnemyAttackPayload) {
 	onHit := make([]gamedata.EffectSpec, len(e.OnHitEffects))
 	copy(onHit, e.OnHitEffects)
 	return true, EnemyAttackPayload{
-		Damage:             e.Damage,
+		Damage:             e.ScaledDamage(e.Damage),
 		DamageType:         e.DamageType,
 		SourceX:            sourceX,
 		SourceY:            sourceY,
@@ -202,7 +232,22 @@ func (e *Enemy) Attack(playerX, playerY float32) (bool, EnemyAttackPayload) {
 	}
 }
 
+func (e *Enemy) ScaledDamage(damage int) int {
+	if gamedata.HasEffect(&e.Entity.Effects, gamedata.EffectDamageBoost) {
+		damage = int(float32(damage) * (1 + gamedata.GetEffectMagnitude(&e.Entity.Effects, gamedata.EffectDamageBoost)))
+	}
+	return damage
+}
+
+// IsInvulnerable reports an enemy that currently ignores damage and effects, such as a boss changing phase.
+func (e *Enemy) IsInvulnerable() bool {
+	return e.InvulnerableTimer > 0
+}
+
 func (e *Enemy) TakeDamage(damage int) {
+	if e.IsInvulnerable() {
+		return
+	}
 	applied := e.Entity.ApplyDamage(damage)
 	if applied > 0 {
 		e.Provoked = true
@@ -210,6 +255,44 @@ func (e *Enemy) TakeDamage(damage int) {
 	e.HitFlashTimer = EntityHitFlashDuration
 }
 
+func (e *Enemy) TakeTypedDamage(damage int, damageType gamedata.DamageType) {
+	e.TakeDamage(gamedata.MitigateDamage(damage, e.GetResistance(damageType)))
+}
+
+func (e *Enemy) GetResistance(damageType gamedata.DamageType) float32 {
+	return e.Resistances.Resistance(damageType)
+}
+
+func (e *Enemy) GetDefense() gamedata.DefenseStats {
+	return e.Defense
+}
+
+func (e *Enemy) ApplyCombatDamage(damage int, damageType gamedata.DamageType, flash bool) int {
+	before := e.HP
+	e.TakeDamage(damage)
+	if before < e.HP {
+		return 0
+	}
+	return before - e.HP
+}
+
+func (e *Enemy) ApplyKnockbackFrom(sourceX, sourceY, impulse float32) {
+	if impulse <= 0 || !e.IsAlive() {
+		return
+	}
+
+	centerX, centerY := e.Center()
+	dx := centerX - sourceX
+	dy := centerY - sourceY
+	distance := float32(math.Sqrt(float64(dx*dx + dy*dy)))
+	if distance <= 0 {
+		return
+	}
+
+	e.KnockbackVelX += (dx / distance) * impulse
+	e.KnockbackVelY += (dy / distance) * impulse
+}
+
 func (e *Enemy) DisplayName() string {
 	if e == nil {
 		return "Enemy"
@@ -225,3 +308,64 @@ func (e *Enemy) DisplayName() string {
 	}
 	return "Enemy"
 }
+
+// RefreshArchetype re-reads archetype and elite tuning, keeping position, HP ratio and AI state.
+func (e *Enemy) RefreshArchetype() {
+	if e == nil {
+		return
+	}
+	fresh := NewEnemyFromArchetype(e.PosX, e.PosY, e.Archetype, e.IsElite, e.EliteModifierType)
+	e.Name = fresh.Name
+	e.Role = fresh.Role
+	e.AttackMode = fresh.AttackMode
+	e.DamageType = fresh.DamageType
+	e.Damage = fresh.Damage
+	e.MoveSpeed = fresh.MoveSpeed
+	e.AttackCooldown = fresh.AttackCooldown
+	if e.CurrentCooldown > e.AttackCooldown {
+		e.CurrentCooldown = e.AttackCooldown
+	}
+	e.AttackRange = fresh.AttackRange
+	e.AggroRange = fresh.AggroRange
+	e.PreferredRange = fresh.PreferredRange
+	e.RetreatRange = fresh.RetreatRange
+	e.ProjectileSpeed = fresh.ProjectileSpeed
+	e.ProjectileRadius = fresh.ProjectileRadius
+	e.ProjectileLifetime = fresh.ProjectileLifetime
+	e.OnHitEffects = fresh.OnHitEffects
+	e.Resistances = fresh.Resistances
+	e.Defense = fresh.Defense
+	e.refreshSkills(fresh.Skills)
+	e.XPReward = fresh.XPReward
+	e.ThreatValue = fresh.ThreatValue
+	e.EliteModifierName = fresh.EliteModifierName
+	e.rescale(fresh.MaxHP, fresh.Hitbox.Width, fresh.Hitbox.Height)
+}
+
+func (e *Enemy) refreshSkills(skills []gamedata.EnemySkill) {
+	cooldowns := make(map[string]float32, len(e.Skills))
+	for _, skill := range e.Skills {
+		cooldowns[skill.ID] = skill.Skill.CurrentCooldown
+	}
+	for i := range skills {
+		skills[i].Skill.CurrentCooldown = min(cooldowns[skills[i].ID], skills[i].Skill.Cooldown)
+	}
+	e.Skills = skills
+	e.PendingSkill = nil
+}
+
+func (e *Enemy) rescale(maxHP int, width, height float32) {
+	if e.MaxHP > 0 && e.Alive {
+		e.HP = int(float32(e.HP) * float32(maxHP) / float32(e.MaxHP))
+		if e.HP < 1 {
+			e.HP = 1
+		}
+	}
+	e.MaxHP = maxHP
+
+	centerX, centerY := e.Center()
+	e.Hitbox.Width = width
+	e.Hitbox.Height = height
+	e.PosX = centerX - width/2
+	e.PosY = centerY - height/2
+}
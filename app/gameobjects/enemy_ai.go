@@ -43,6 +43,13 @@ func resolveMeleeIntent(enemy *Enemy, dx, dy, distance float32) {
 }
 
 func resolveRangedOrCasterIntent(enemy *Enemy, dx, dy, distance float32) {
+	if enemy.SightBlocked {
+		// Close in to get around the cover instead of shooting into it.
+		enemy.State = EnemyStateChasing
+		setEnemyMoveIntent(enemy, dx, dy, distance)
+		return
+	}
+
 	preferred := enemy.PreferredRange
 	if preferred <= 0 {
 		preferred = enemy.AttackRange * 0.75
@@ -75,6 +82,17 @@ func resolveRangedOrCasterIntent(enemy *Enemy, dx, dy, distance float32) {
 	setEnemyMoveIntent(enemy, dx, dy, distance)
 }
 
+// SteerEnemyToward points the enemy's move intent at a waypoint while keeping its current state.
+func SteerEnemyToward(enemy *Enemy, x, y float32) {
+	if enemy == nil {
+		return
+	}
+	enemyX, enemyY := enemy.Center()
+	dx := x - enemyX
+	dy := y - enemyY
+	setEnemyMoveIntent(enemy, dx, dy, float32(math.Sqrt(float64(dx*dx+dy*dy))))
+}
+
 func setEnemyMoveIntent(enemy *Enemy, dx, dy, distance float32) {
 	if distance <= 0.0001 {
 		enemy.IntentMoveX = 0
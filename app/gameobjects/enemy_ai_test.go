@@ -44,6 +44,25 @@ func TestResolveEnemyIntentRangedRetreat(t *testing.T) {
 	}
 }
 
+func TestResolveEnemyIntentRangedWithoutSightRepositions(t *testing.T) {
+	enemy := NewEnemyFromArchetype(100, 100, gamedata.EnemyArchetypeArcher, false, gamedata.EliteModifierScorching)
+	enemy.Update(0.016)
+	enemyX, enemyY := enemy.Center()
+	targetX := enemyX + enemy.AttackRange*0.7
+
+	enemy.SightBlocked = true
+	ResolveEnemyIntent(enemy, targetX, enemyY)
+	if enemy.State != EnemyStateChasing || enemy.WantsAttack || enemy.IntentMoveX <= 0 {
+		t.Fatalf("expected blocked archer to move toward the target instead of firing, got state %d", enemy.State)
+	}
+
+	enemy.SightBlocked = false
+	ResolveEnemyIntent(enemy, targetX, enemyY)
+	if enemy.State != EnemyStateAttacking || !enemy.WantsAttack {
+		t.Fatalf("expected archer with sight to attack, got state %d", enemy.State)
+	}
+}
+
 func TestResolveEnemyIntentOutOfAggroRangeWithoutProvocationStaysIdle(t *testing.T) {
 	enemy := NewEnemyFromArchetype(0, 0, gamedata.EnemyArchetypeRaider, false, gamedata.EliteModifierScorching)
 	enemy.Update(0.016)
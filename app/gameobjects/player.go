@@ -92,6 +92,26 @@ func NewPlayer(x, y float32, classType gamedata.ClassType) *Player {
 	return player
 }
 
+// RefreshClassData re-reads class and skill definitions, keeping remaining skill cooldowns.
+func (p *Player) RefreshClassData() {
+	if p == nil || p.Class == nil {
+		return
+	}
+	p.Class = gamedata.GetClassData(p.Class.Type)
+	p.AttackRange = p.Class.AttackRange
+
+	skills := gamedata.GetClassSkillData(p.Class.Type)
+	for _, skill := range skills {
+		for _, current := range p.Skills {
+			if current != nil && current.Type == skill.Type {
+				skill.CurrentCooldown = min(current.CurrentCooldown, skill.Cooldown)
+			}
+		}
+	}
+	p.Skills = skills
+	p.ApplyStats()
+}
+
 func (p *Player) ApplyStats() {
 	if p.Entity.Stats == nil {
 		p.Entity.Stats = gamedata.NewStats()
@@ -158,7 +178,8 @@ func (p *Player) Update(deltaTime float32) {
 		skill.Update(deltaTime)
 	}
 
-	gamedata.UpdateEffects(&p.Entity.Effects, deltaTime, p.TakeDamage)
+	gamedata.UpdateEffects(&p.Entity.Effects, deltaTime, p.TakeTypedDamage)
+	p.CC.Update(deltaTime)
 
 	p.regenerateMana(deltaTime)
 }
@@ -183,23 +204,48 @@ func (p *Player) ApplyTypedDamage(damage int, damageType gamedata.DamageType, fl
 	return p.takeDamageInternal(damage, damageType, flash)
 }
 
+// ApplyCombatDamage takes damage the resolver has already mitigated by resistance.
+func (p *Player) ApplyCombatDamage(damage int, damageType gamedata.DamageType, flash bool) int {
+	if damage <= 0 {
+		return 0
+	}
+	return p.absorbDamage(p.reduceIncomingDamage(damage), flash)
+}
+
+func (p *Player) GetResistance(damageType gamedata.DamageType) float32 {
+	switch damageType {
+	case gamedata.DamagePhysical:
+		return p.DerivedStats.PhysicalResist
+	case gamedata.DamageMagical, gamedata.DamageFire, gamedata.DamageFrost, gamedata.DamagePoison, gamedata.DamageLightning:
+		return p.DerivedStats.MagicalResist
+	default:
+		return 0
+	}
+}
+
+func (p *Player) GetDefense() gamedata.DefenseStats {
+	return p.DerivedStats.Defense()
+}
+
 func (p *Player) takeDamageInternal(damage int, damageType gamedata.DamageType, flash bool) int {
 	if damage <= 0 {
 		return 0
 	}
 
+	damage = gamedata.MitigateDamage(p.reduceIncomingDamage(damage), p.GetResistance(damageType))
+	return p.absorbDamage(damage, flash)
+}
+
+func (p *Player) reduceIncomingDamage(damage int) int {
 	if gamedata.HasEffect(&p.Entity.Effects, gamedata.EffectDamageReduction) {
 		magnitude := gamedata.GetEffectMagnitude(&p.Entity.Effects, gamedata.EffectDamageReduction)
 		damage = int(float32(damage) * (1.0 - magnitude))
 	}
+	return damage
+}
 
-	switch damageType {
-	case gamedata.DamagePhysical:
-		damage = applyResistance(damage, p.DerivedStats.PhysicalResist)
-	case gamedata.DamageMagical:
-		damage = applyResistance(damage, p.DerivedStats.MagicalResist)
-	}
-
+// absorbDamage drains the mana shield before applying the remainder to HP.
+func (p *Player) absorbDamage(damage int, flash bool) int {
 	if p.ManaShieldActive && p.ManaShieldAmount > 0 {
 		if damage <= p.ManaShieldAmount {
 			p.ManaShieldAmount -= damage
@@ -376,18 +422,6 @@ func (p *Player) GetItemEffects() []gamedata.ItemEffect {
 	return effects
 }
 
-func applyResistance(damage int, resistance float32) int {
-	if damage <= 0 {
-		return 0
-	}
-
-	mitigated := int(float32(damage) * (1.0 - resistance))
-	if mitigated < 1 {
-		return 1
-	}
-	return mitigated
-}
-
 func (p *Player) regenerateMana(deltaTime float32) {
 	if p == nil || deltaTime <= 0 {
 		return
@@ -0,0 +1,69 @@
+package gameobjects
+
+import (
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+)
+
+// Prop is a destructible room object that skills and attacks can break.
+type Prop struct {
+	core.Entity
+	Type          gamedata.PropType
+	Name          string
+	HitFlashTimer float32
+	Resolved      bool
+}
+
+func NewProp(centerX, centerY float32, propType gamedata.PropType) *Prop {
+	spec := gamedata.GetPropSpec(propType)
+	maxHP := spec.MaxHP
+	if maxHP <= 1 {
+		maxHP = 1
+	}
+	return &Prop{
+		Entity: core.Entity{
+			PosX:    centerX - spec.Width/2,
+			PosY:    centerY - spec.Height/2,
+			HP:      maxHP,
+			MaxHP:   maxHP,
+			Hitbox:  core.Hitbox{Width: spec.Width, Height: spec.Height},
+			Faction: core.FactionNeutral,
+			Alive:   true,
+		},
+		Type: spec.Type,
+		Name: spec.Name,
+	}
+}
+
+func (p *Prop) Spec() gamedata.PropSpec {
+	return gamedata.GetPropSpec(p.Type)
+}
+
+func (p *Prop) Update(deltaTime float32) {
+	if !p.Entity.IsAlive() {
+		return
+	}
+	if p.HitFlashTimer > 0 {
+		p.HitFlashTimer -= deltaTime
+		if p.HitFlashTimer < 0 {
+			p.HitFlashTimer = 0
+		}
+	}
+	gamedata.UpdateEffects(&p.Entity.Effects, deltaTime, func(damage int, _ gamedata.DamageType) {
+		p.TakeDamage(damage)
+	})
+}
+
+func (p *Prop) TakeDamage(damage int) {
+	p.Entity.ApplyDamage(damage)
+	p.HitFlashTimer = EntityHitFlashDuration
+}
+
+func (p *Prop) ApplyCombatDamage(damage int, damageType gamedata.DamageType, flash bool) int {
+	before := p.HP
+	p.Entity.ApplyDamage(damage)
+	if flash {
+		p.HitFlashTimer = EntityHitFlashDuration
+	}
+	return before - p.HP
+}
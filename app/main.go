@@ -1,16 +1,42 @@
 package main
 
 import (
+	"flag"
+	"log"
+
 	"singlefantasy/app/assets"
 	"singlefantasy/app/game"
+	"singlefantasy/app/gamedata"
 	"singlefantasy/app/settings"
+	"singlefantasy/app/world"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 func main() {
+	recordPath := flag.String("record", "", "write a replay of the last run to this path on exit")
+	replayPath := flag.String("replay", "", "play back a replay file instead of showing the main menu")
+	profilePath := flag.String("profile-csv", "", "write per-system step timings to this CSV path on exit")
+	hotReload := flag.Bool("hot-reload", false, "watch room templates and content data and apply edits during a run")
+	flag.Parse()
+
 	cfg := settings.Load()
 
+	content, err := gamedata.LoadContent(gamedata.DefaultDataRoot)
+	if err != nil {
+		log.Fatal(err)
+	}
+	gamedata.ApplyContent(content)
+
+	var replay *game.Replay
+	if *replayPath != "" {
+		loaded, err := game.LoadReplay(*replayPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		replay = loaded
+	}
+
 	rl.InitWindow(game.WindowWidth, game.WindowHeight, "Single Fantasy")
 	defer func() {
 		_ = settings.Save(cfg)
@@ -29,6 +55,34 @@ func main() {
 	rl.SetTargetFPS(60)
 
 	g := game.NewGame(cfg)
+	defer func() {
+		if err := g.SaveRunIfActive(); err != nil {
+			log.Printf("save run: %v", err)
+		}
+	}()
+	if *recordPath != "" {
+		g.EnableReplayRecording()
+		defer func() {
+			if recorded := g.CurrentReplay(); recorded != nil {
+				if err := game.SaveReplay(*recordPath, recorded); err != nil {
+					log.Printf("save replay: %v", err)
+				}
+			}
+		}()
+	}
+	if *profilePath != "" {
+		defer func() {
+			if err := g.RunPipeline.Profiler().WriteCSV(*profilePath); err != nil {
+				log.Printf("save profile: %v", err)
+			}
+		}()
+	}
+	if *hotReload {
+		g.EnableHotReload(world.DefaultRoomsRoot, gamedata.DefaultDataRoot)
+	}
+	if replay != nil {
+		g.QueueReplay(replay)
+	}
 
 	accumulator := float32(0)
 
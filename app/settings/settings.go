@@ -10,12 +10,13 @@ import (
 const DefaultPath = "settings.json"
 
 type KeybindDisplay struct {
-	Move   string `json:"move"`
-	Attack string `json:"attack"`
-	Skill1 string `json:"skill_1"`
-	Skill2 string `json:"skill_2"`
-	Skill3 string `json:"skill_3"`
-	Skill4 string `json:"skill_4"`
+	Move     string `json:"move"`
+	Attack   string `json:"attack"`
+	Skill1   string `json:"skill_1"`
+	Skill2   string `json:"skill_2"`
+	Skill3   string `json:"skill_3"`
+	Skill4   string `json:"skill_4"`
+	Interact string `json:"interact"`
 }
 
 type Settings struct {
@@ -29,12 +30,13 @@ func Default() Settings {
 		MasterVolume: 1.0,
 		Fullscreen:   false,
 		KeybindDisplay: KeybindDisplay{
-			Move:   "RMB",
-			Attack: "LMB",
-			Skill1: "Q",
-			Skill2: "W",
-			Skill3: "E",
-			Skill4: "R",
+			Move:     "RMB",
+			Attack:   "LMB",
+			Skill1:   "Q",
+			Skill2:   "W",
+			Skill3:   "E",
+			Skill4:   "R",
+			Interact: "F",
 		},
 	}
 }
@@ -124,6 +126,9 @@ func applyDefaults(cfg *Settings, defaults Settings) {
 	if cfg.KeybindDisplay.Skill4 == "" {
 		cfg.KeybindDisplay.Skill4 = defaults.KeybindDisplay.Skill4
 	}
+	if cfg.KeybindDisplay.Interact == "" {
+		cfg.KeybindDisplay.Interact = defaults.KeybindDisplay.Interact
+	}
 }
 
 func clamp(v, min, max float32) float32 {
@@ -17,3 +17,39 @@ func ResolvePlayerMovement(
 ) (float32, float32) {
 	return pure.ResolvePlayerMovement(posX, posY, width, height, deltaX, deltaY, room)
 }
+
+// ResolvePlayerMovementInSpace resolves against only the obstacles the space finds near the swept hitbox.
+func ResolvePlayerMovementInSpace(
+	posX, posY,
+	width, height,
+	deltaX, deltaY float32,
+	room *world.Room,
+	space *CombatSpace,
+) (float32, float32) {
+	if room == nil || space == nil {
+		return ResolvePlayerMovement(posX, posY, width, height, deltaX, deltaY, room)
+	}
+
+	swept := sweptMovementArea(posX, posY, width, height, deltaX, deltaY)
+	bounds := world.AABB{X: room.X, Y: room.Y, Width: room.Width, Height: room.Height}
+	return pure.ResolveMovementAgainst(posX, posY, width, height, deltaX, deltaY, bounds, space.ObstaclesNear(swept))
+}
+
+func ResolveEnemyMovementInSpace(
+	posX, posY,
+	width, height,
+	deltaX, deltaY float32,
+	room *world.Room,
+	space *CombatSpace,
+) (float32, float32) {
+	return ResolvePlayerMovementInSpace(posX, posY, width, height, deltaX, deltaY, room, space)
+}
+
+func sweptMovementArea(posX, posY, width, height, deltaX, deltaY float32) world.AABB {
+	return world.AABB{
+		X:      minFloat32(posX, posX+deltaX) - width,
+		Y:      minFloat32(posY, posY+deltaY) - height,
+		Width:  absFloat32(deltaX) + width*3,
+		Height: absFloat32(deltaY) + height*3,
+	}
+}
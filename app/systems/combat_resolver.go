@@ -1,15 +1,14 @@
 package systems
 
 import (
-	"math/rand"
-
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 )
 
 type CombatHitRequest struct {
 	Caster             *gameobjects.Player
-	Target             interface{}
+	Target             core.Combatant
 	Skill              *gamedata.Skill
 	BaseDamage         int
 	DamageType         gamedata.DamageType
@@ -20,13 +19,17 @@ type CombatHitRequest struct {
 	UseSourceModifiers bool
 	SuppressFlash      bool
 	CritRoll           *float32
+	HitRoll            *float32
 	OnHitProcRoll      *float32
+	RNG                *core.RunRNG
+	ProcWorld          ItemProcWorld
 }
 
 type CombatHitResult struct {
-	Damage         DamageResult
-	EffectsApplied int
-	TargetKilled   bool
+	Damage          DamageResult
+	EffectsApplied  int
+	ResistedEffects []gamedata.EffectSpec
+	TargetKilled    bool
 }
 
 func ApplyCombatHit(request CombatHitRequest) CombatHitResult {
@@ -40,24 +43,36 @@ func ApplyCombatHit(request CombatHitRequest) CombatHitResult {
 	damageRequest, hasDamage := buildDamageRequest(request)
 	if hasDamage {
 		result.Damage = ResolveAndApplyDamage(damageRequest)
+		if result.Damage.Avoided() {
+			return result
+		}
 	}
 
 	for _, effectSpec := range resolveEffects(request) {
 		if applyEffectSpecToTarget(request.Target, effectSpec) {
 			result.EffectsApplied++
+		} else {
+			result.ResistedEffects = append(result.ResistedEffects, effectSpec)
 		}
 	}
 
 	if request.ApplyOnHitHooks && request.Caster != nil && result.Damage.AppliedDamage > 0 {
-		damageType := resolveDamageType(request)
-		applyOnHitHooks(request.Caster, request.Target, result.Damage.AppliedDamage, damageType, request.OnHitProcRoll)
+		applyOnHitHooks(request, result.Damage)
 	}
 
 	result.TargetKilled = beforeAlive && !isTargetAlive(request.Target)
+	if request.ApplyOnHitHooks && request.Caster != nil && result.TargetKilled {
+		TriggerItemEffects(request.Caster, gamedata.ItemTriggerOnKill, itemProcContext(request, result.Damage.AppliedDamage))
+	}
+	if player, ok := request.Target.(*gameobjects.Player); ok && result.Damage.AppliedDamage > 0 {
+		ctx := itemProcContext(request, result.Damage.AppliedDamage)
+		ctx.Target = nil
+		TriggerItemEffects(player, gamedata.ItemTriggerOnDamaged, ctx)
+	}
 	return result
 }
 
-func ApplySkill(caster *gameobjects.Player, skill *gamedata.Skill, targets []interface{}) {
+func ApplySkill(caster *gameobjects.Player, skill *gamedata.Skill, targets []core.Combatant) {
 	if caster == nil || skill == nil {
 		return
 	}
@@ -73,7 +88,7 @@ func ApplySkill(caster *gameobjects.Player, skill *gamedata.Skill, targets []int
 }
 
 func buildDamageRequest(request CombatHitRequest) (DamageRequest, bool) {
-	itemCritBonus := itemCritChanceBonus(request.Caster, request.Target)
+	itemCritBonus := itemCritChanceBonus(request)
 
 	if request.Skill != nil && request.Skill.DamageSpec != nil && request.Caster != nil {
 		baseDamage := ComputeDamage(request.Skill.DamageSpec, request.Caster.GetEffectiveStats())
@@ -87,6 +102,8 @@ func buildDamageRequest(request CombatHitRequest) (DamageRequest, bool) {
 			UseSourceModifiers: request.UseSourceModifiers,
 			SuppressFlash:      request.SuppressFlash,
 			CritRoll:           request.CritRoll,
+			HitRoll:            request.HitRoll,
+			RNG:                request.RNG,
 		}, true
 	}
 
@@ -109,6 +126,8 @@ func buildDamageRequest(request CombatHitRequest) (DamageRequest, bool) {
 		UseSourceModifiers: request.UseSourceModifiers,
 		SuppressFlash:      request.SuppressFlash,
 		CritRoll:           request.CritRoll,
+		HitRoll:            request.HitRoll,
+		RNG:                request.RNG,
 	}, true
 }
 
@@ -129,37 +148,45 @@ func resolveDamageType(request CombatHitRequest) gamedata.DamageType {
 	return request.DamageType
 }
 
-func applyEffectSpecToTarget(target interface{}, effectSpec gamedata.EffectSpec) bool {
+// invulnerableTarget is a combatant that can briefly shrug off every hit, such as a boss changing phase.
+type invulnerableTarget interface {
+	IsInvulnerable() bool
+}
+
+// applyEffectSpecToTarget skips invulnerable targets before touching their crowd-control tracker, so an
+// invulnerability window never advances diminishing returns.
+func applyEffectSpecToTarget(target core.Combatant, effectSpec gamedata.EffectSpec) bool {
+	if target == nil {
+		return false
+	}
+	if invulnerable, ok := target.(invulnerableTarget); ok && invulnerable.IsInvulnerable() {
+		return false
+	}
+
+	duration, landed := target.GetCrowdControl().Apply(effectSpec.Type, effectSpec.Duration)
+	if !landed {
+		return false
+	}
+
 	magnitude := resolveEffectMagnitude(target, effectSpec)
 	effect := gamedata.Effect{
 		Type:      effectSpec.Type,
-		Duration:  effectSpec.Duration,
+		Duration:  duration,
 		Magnitude: magnitude,
 		TickRate:  effectSpec.TickRate,
 	}
 
-	switch t := target.(type) {
-	case *gameobjects.Player:
-		gamedata.ApplyEffect(&t.Effects, effect)
-		return true
-	case *gameobjects.Enemy:
-		gamedata.ApplyEffect(&t.Effects, effect)
-		return true
-	case *gameobjects.Boss:
-		gamedata.ApplyEffect(&t.Effects, effect)
-		return true
-	default:
-		return false
-	}
+	gamedata.ApplyEffect(target.GetEffects(), effect)
+	return true
 }
 
-func resolveEffectMagnitude(target interface{}, effectSpec gamedata.EffectSpec) float32 {
+func resolveEffectMagnitude(target core.Combatant, effectSpec gamedata.EffectSpec) float32 {
 	magnitude := effectSpec.Magnitude
 	if effectSpec.PercentMaxHPPerTick <= 0 {
 		return magnitude
 	}
 
-	maxHP := targetMaxHP(target)
+	maxHP := target.GetMaxHP()
 	if maxHP <= 0 {
 		return magnitude
 	}
@@ -175,118 +202,69 @@ func resolveEffectMagnitude(target interface{}, effectSpec gamedata.EffectSpec)
 	return magnitude
 }
 
-func targetMaxHP(target interface{}) int {
-	switch t := target.(type) {
-	case *gameobjects.Player:
-		return t.MaxHP
-	case *gameobjects.Enemy:
-		return t.MaxHP
-	case *gameobjects.Boss:
-		return t.MaxHP
-	default:
-		return 0
-	}
-}
-
-func applyOnHitHooks(caster *gameobjects.Player, target interface{}, appliedDamage int, damageType gamedata.DamageType, procRoll *float32) {
-	if caster == nil || appliedDamage <= 0 || target == nil {
+func applyOnHitHooks(request CombatHitRequest, damage DamageResult) {
+	caster := request.Caster
+	appliedDamage := damage.AppliedDamage
+	if caster == nil || appliedDamage <= 0 || request.Target == nil {
 		return
 	}
 
-	if damageType == gamedata.DamagePhysical && caster.Class != nil && caster.Class.LifestealPercent > 0 {
+	if resolveDamageType(request) == gamedata.DamagePhysical && caster.Class != nil && caster.Class.LifestealPercent > 0 {
 		caster.Heal(int(float32(appliedDamage) * caster.Class.LifestealPercent))
 	}
 	if gamedata.HasEffect(&caster.Effects, gamedata.EffectLifesteal) {
 		caster.Heal(int(float32(appliedDamage) * gamedata.GetEffectMagnitude(&caster.Effects, gamedata.EffectLifesteal)))
 	}
 
-	for _, effect := range caster.GetItemEffects() {
-		switch effect.Type {
-		case gamedata.ItemEffectLifestealOnHit:
-			caster.Heal(int(float32(appliedDamage) * effect.Magnitude))
-		case gamedata.ItemEffectManaOnHit:
-			if effect.Magnitude > 0 {
-				caster.GainMana(int(effect.Magnitude))
-			}
-		case gamedata.ItemEffectBurnOnHit:
-			if !shouldTriggerItemProc(effect.Chance, procRoll) {
-				continue
-			}
-
-			duration := effect.Duration
-			if duration <= 0 {
-				duration = 4
-			}
-			tickRate := effect.TickRate
-			if tickRate <= 0 {
-				tickRate = 1
-			}
-			applyEffectSpecToTarget(target, gamedata.EffectSpec{
-				Type:      gamedata.EffectBurn,
-				Duration:  duration,
-				Magnitude: effect.Magnitude,
-				TickRate:  tickRate,
-			})
-		}
+	ctx := itemProcContext(request, appliedDamage)
+	TriggerItemEffects(caster, gamedata.ItemTriggerOnHit, ctx)
+	if damage.IsCrit {
+		TriggerItemEffects(caster, gamedata.ItemTriggerOnCrit, ctx)
 	}
 }
 
-func shouldTriggerItemProc(chance float32, roll *float32) bool {
+func itemProcContext(request CombatHitRequest, damage int) ItemProcContext {
+	return ItemProcContext{
+		Target: request.Target,
+		Damage: damage,
+		Roll:   request.OnHitProcRoll,
+		RNG:    request.RNG,
+		World:  request.ProcWorld,
+	}
+}
+
+func shouldTriggerItemProc(chance float32, roll *float32, rng *core.RunRNG) bool {
 	if chance <= 0 {
 		chance = 1
 	}
 	if chance >= 1 {
 		return true
 	}
-	value := rand.Float32()
 	if roll != nil {
-		value = *roll
+		return *roll < clamp01(chance)
 	}
-	return value < clamp01(chance)
+	return rng.Float32(core.RNGStreamItemProcs) < clamp01(chance)
 }
 
-func itemCritChanceBonus(caster *gameobjects.Player, target interface{}) float32 {
-	if caster == nil || !targetHasSlowedState(target) {
+// itemCritChanceBonus sums on-attack crit chance item effects whose conditions hold against target.
+func itemCritChanceBonus(request CombatHitRequest) float32 {
+	if request.Caster == nil {
 		return 0
 	}
 
+	ctx := itemProcContext(request, 0)
 	bonus := float32(0)
-	for _, effect := range caster.GetItemEffects() {
-		if effect.Type == gamedata.ItemEffectCritChanceVsSlowed && effect.Magnitude > 0 {
-			bonus += effect.Magnitude
+	for _, effect := range request.Caster.GetItemEffects() {
+		if effect.Trigger != gamedata.ItemTriggerOnAttack || effect.Action.Type != gamedata.ItemActionCritChance {
+			continue
+		}
+		if effect.Action.Amount > 0 && itemConditionsHold(request.Caster, effect, ctx) {
+			bonus += effect.Action.Amount
 		}
 	}
 	return bonus
 }
 
-func targetHasSlowedState(target interface{}) bool {
-	switch t := target.(type) {
-	case *gameobjects.Player:
-		return gamedata.HasEffect(&t.Effects, gamedata.EffectSlow) ||
-			gamedata.HasEffect(&t.Effects, gamedata.EffectFreeze) ||
-			gamedata.HasEffect(&t.Effects, gamedata.EffectMoveSpeedReduction)
-	case *gameobjects.Enemy:
-		return gamedata.HasEffect(&t.Effects, gamedata.EffectSlow) ||
-			gamedata.HasEffect(&t.Effects, gamedata.EffectFreeze) ||
-			gamedata.HasEffect(&t.Effects, gamedata.EffectMoveSpeedReduction)
-	case *gameobjects.Boss:
-		return gamedata.HasEffect(&t.Effects, gamedata.EffectSlow) ||
-			gamedata.HasEffect(&t.Effects, gamedata.EffectFreeze) ||
-			gamedata.HasEffect(&t.Effects, gamedata.EffectMoveSpeedReduction)
-	default:
-		return false
-	}
-}
-
-func isTargetAlive(target interface{}) bool {
-	switch t := target.(type) {
-	case *gameobjects.Player:
-		return t.IsAlive()
-	case *gameobjects.Enemy:
-		return t.IsAlive()
-	case *gameobjects.Boss:
-		return t.IsAlive()
-	default:
-		return false
-	}
+func isTargetAlive(target core.Combatant) bool {
+	return target != nil && target.IsAlive()
 }
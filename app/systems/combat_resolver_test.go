@@ -6,6 +6,7 @@ package systems
 import (
 	"testing"
 
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 )
@@ -55,6 +56,81 @@ func TestResolveAndApplyDamageUsesTypedMitigation(t *testing.T) {
 	}
 }
 
+func TestResolveAndApplyDamageUsesEnemyResistanceProfile(t *testing.T) {
+	cases := []struct {
+		damageType gamedata.DamageType
+		want       int
+	}{
+		{gamedata.DamageFire, 150},
+		{gamedata.DamagePoison, 70},
+		{gamedata.DamageFrost, 100},
+		{gamedata.DamageTrue, 100},
+	}
+	for _, tc := range cases {
+		enemy := gameobjects.NewEnemyFromArchetype(0, 0, gamedata.EnemyArchetypeSwarmling, false, 0)
+		enemy.MaxHP = 1000
+		enemy.HP = 1000
+		result := ResolveAndApplyDamage(DamageRequest{Target: enemy, BaseDamage: 100, DamageType: tc.damageType})
+		if result.RequestedDamage != 100 || result.AppliedDamage != tc.want {
+			t.Fatalf("expected %v damage to apply %d, got %+v", tc.damageType, tc.want, result)
+		}
+	}
+}
+
+func TestResolveAndApplyDamageWalksMissDodgeBlockTable(t *testing.T) {
+	defender := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	defender.DerivedStats.DodgeChance = 0.2
+	defender.DerivedStats.BlockChance = 0.2
+	defender.DerivedStats.BlockAmount = 0.5
+	defender.DerivedStats.PhysicalResist = 0
+
+	cases := []struct {
+		accuracy float32
+		roll     float32
+		want     HitOutcome
+	}{
+		{accuracy: 0.9, roll: 0.05, want: HitOutcomeMiss},
+		{accuracy: 0.9, roll: 0.25, want: HitOutcomeDodged},
+		{accuracy: 0.9, roll: 0.45, want: HitOutcomeBlocked},
+		{accuracy: 0.9, roll: 0.55, want: HitOutcomeHit},
+		{accuracy: 1.3, roll: 0.05, want: HitOutcomeBlocked},
+		{accuracy: 1.3, roll: 0.15, want: HitOutcomeHit},
+		{accuracy: 1.5, roll: 0, want: HitOutcomeHit},
+	}
+	for _, tc := range cases {
+		defender.HP = defender.MaxHP
+		roll := tc.roll
+		result := ResolveAndApplyDamage(DamageRequest{Target: defender, BaseDamage: 40, DamageType: gamedata.DamagePhysical, Accuracy: tc.accuracy, HitRoll: &roll, SuppressFlash: true})
+		if result.Outcome != tc.want {
+			t.Fatalf("accuracy %.1f roll %.2f: expected outcome %d, got %d", tc.accuracy, tc.roll, tc.want, result.Outcome)
+		}
+		wantDamage := map[HitOutcome]int{HitOutcomeMiss: 0, HitOutcomeDodged: 0, HitOutcomeBlocked: 20, HitOutcomeHit: 40}[tc.want]
+		if result.AppliedDamage != wantDamage {
+			t.Fatalf("accuracy %.1f roll %.2f: expected %d damage, got %d", tc.accuracy, tc.roll, wantDamage, result.AppliedDamage)
+		}
+	}
+}
+
+func TestResolveAndApplyDamageUsesSourcePenetrationAndCritMultiplier(t *testing.T) {
+	source := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	source.DerivedStats.ArmorPenetration = 0.5
+	source.DerivedStats.CritMultiplier = 2
+	enemy := gameobjects.NewEnemyFromArchetype(0, 0, gamedata.EnemyArchetypeBrute, false, 0)
+	enemy.MaxHP = 1000
+	enemy.HP = 1000
+	roll := float32(0)
+	hit := float32(0.99)
+
+	result := ResolveAndApplyDamage(DamageRequest{Source: source, Target: enemy, BaseDamage: 100, DamageType: gamedata.DamagePhysical, CritChance: 1, CritRoll: &roll, HitRoll: &hit})
+	if result.Outcome != HitOutcomeCrit || result.RequestedDamage != 200 {
+		t.Fatalf("expected a crit using the source multiplier, got %+v", result)
+	}
+	// Brutes resist 25% physical; half penetration leaves 12.5%.
+	if result.AppliedDamage != 175 {
+		t.Fatalf("expected penetration to halve the brute's armor, got %d", result.AppliedDamage)
+	}
+}
+
 func TestApplyCombatHitAppliesSkillEffectsAndOnHitHooks(t *testing.T) {
 	caster := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
 	caster.HP = 40
@@ -148,7 +224,7 @@ func TestApplyCombatHitItemCritVsSlowedAffectsCritRoll(t *testing.T) {
 		gamedata.ItemSlotHead,
 		map[gamedata.StatType]int{},
 		gamedata.ClassTypeMelee,
-		gamedata.ItemMetadata{Effects: []gamedata.ItemEffect{{Type: gamedata.ItemEffectCritChanceVsSlowed, Magnitude: 1}}},
+		gamedata.ItemMetadata{Effects: []gamedata.ItemEffect{gamedata.CritChanceVsSlowed(1)}},
 	)
 	caster.EquipItem(critItem)
 	gamedata.ApplyEffect(&enemy.Effects, gamedata.Effect{Type: gamedata.EffectSlow, Duration: 2, Magnitude: 0.3})
@@ -181,7 +257,7 @@ func TestApplyCombatHitItemBurnOnHitAppliesBurnEffect(t *testing.T) {
 		gamedata.ItemSlotWeapon,
 		map[gamedata.StatType]int{},
 		gamedata.ClassTypeRanged,
-		gamedata.ItemMetadata{Effects: []gamedata.ItemEffect{{Type: gamedata.ItemEffectBurnOnHit, Magnitude: 5, Chance: 0.5, Duration: 4, TickRate: 1}}},
+		gamedata.ItemMetadata{Effects: []gamedata.ItemEffect{gamedata.BurnOnHit(5, 0.5, 4, 1)}},
 	)
 	caster.EquipItem(burnItem)
 
@@ -207,3 +283,70 @@ func TestApplyCombatHitItemBurnOnHitAppliesBurnEffect(t *testing.T) {
 		t.Fatalf("expected burn magnitude 5, got %.2f", magnitude)
 	}
 }
+
+type testProp struct {
+	core.Entity
+}
+
+func TestApplyCombatHitAcceptsAnyCombatant(t *testing.T) {
+	player := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	prop := &testProp{Entity: core.Entity{PosX: 40, PosY: 0, HP: 30, MaxHP: 30, Alive: true, Faction: core.FactionNeutral, Hitbox: core.Hitbox{Width: 20, Height: 20}}}
+
+	targets := ResolveCombatantTargets(player, BuildCastIntent(player, 50, 10), gamedata.TargetingSpec{Type: gamedata.TargetEnemy, Range: 200, MaxTargets: 1}, []core.Combatant{prop})
+	if len(targets) != 1 || targets[0] != prop {
+		t.Fatalf("expected custom combatant to be targetable")
+	}
+
+	result := ApplyCombatHit(CombatHitRequest{
+		Caster:     player,
+		Target:     prop,
+		BaseDamage: 50,
+		DamageType: gamedata.DamagePhysical,
+		Effects:    []gamedata.EffectSpec{{Type: gamedata.EffectSlow, Duration: 1, Magnitude: 0.5}},
+	})
+	if result.Damage.AppliedDamage != 30 || !result.TargetKilled {
+		t.Fatalf("expected prop to take 30 damage and die, got %+v", result)
+	}
+	if result.EffectsApplied != 1 || !gamedata.HasEffect(&prop.Effects, gamedata.EffectSlow) {
+		t.Fatalf("expected effect to be applied to custom combatant")
+	}
+}
+
+func TestApplyCombatHitSkipsEffectsOnInvulnerableBoss(t *testing.T) {
+	player := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	boss := gameobjects.NewBoss(40, 0, "forest")
+	boss.InvulnerableTimer = 1
+	stun := []gamedata.EffectSpec{{Type: gamedata.EffectStun, Duration: 1}}
+
+	result := ApplyCombatHit(CombatHitRequest{Caster: player, Target: boss, BaseDamage: 10, DamageType: gamedata.DamageTrue, Effects: stun})
+	if result.EffectsApplied != 0 || gamedata.HasEffect(&boss.Effects, gamedata.EffectStun) {
+		t.Fatalf("expected an invulnerable boss to ignore the stun, got %+v", result)
+	}
+	if boss.CC.Landed(gamedata.CCCategoryStun) != 0 {
+		t.Fatalf("expected the invulnerability window not to advance diminishing returns")
+	}
+
+	boss.InvulnerableTimer = 0
+	ApplyCombatHit(CombatHitRequest{Caster: player, Target: boss, BaseDamage: 10, DamageType: gamedata.DamageTrue, Effects: stun})
+	if boss.CC.Landed(gamedata.CCCategoryStun) != 1 {
+		t.Fatalf("expected the stun to land once the window ends")
+	}
+}
+
+func TestApplyCombatHitPlayerHitIsDodgedBySwarmling(t *testing.T) {
+	player := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	swarmling := gameobjects.NewEnemyFromArchetype(40, 0, gamedata.EnemyArchetypeSwarmling, false, 0)
+	roll := float32(0.05)
+	noCrit := float32(1)
+
+	result := ApplyCombatHit(CombatHitRequest{Caster: player, Target: swarmling, BaseDamage: 10, DamageType: gamedata.DamagePhysical, HitRoll: &roll, CritRoll: &noCrit})
+	if result.Damage.Outcome != HitOutcomeDodged || swarmling.HP != swarmling.MaxHP {
+		t.Fatalf("expected the swarmling to dodge a low roll, got %+v", result.Damage)
+	}
+
+	player.DerivedStats.Accuracy = 1.2
+	result = ApplyCombatHit(CombatHitRequest{Caster: player, Target: swarmling, BaseDamage: 10, DamageType: gamedata.DamagePhysical, HitRoll: &roll, CritRoll: &noCrit})
+	if result.Damage.Outcome != HitOutcomeHit || swarmling.HP >= swarmling.MaxHP {
+		t.Fatalf("expected enough accuracy to cancel the swarmling's dodge, got %+v", result.Damage)
+	}
+}
@@ -0,0 +1,234 @@
+package systems
+
+import (
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/systems/pure"
+	"singlefantasy/app/world"
+)
+
+// CombatSpace indexes live combatants and the current room's obstacles for broadphase queries.
+// Actors and blockers are re-indexed every fixed step; obstacles only when the room changes.
+type CombatSpace struct {
+	actors        *SpatialHash
+	combatants    []core.Combatant
+	maxHalfExtent float32
+	obstacles     *SpatialHash
+	obstacleRoom  *world.Room
+	obstacleCount int
+	blockers      *SpatialHash
+}
+
+func NewCombatSpace() *CombatSpace {
+	return &CombatSpace{
+		actors:     NewSpatialHash(DefaultSpatialCellSize),
+		combatants: []core.Combatant{},
+		obstacles:  NewSpatialHash(DefaultSpatialCellSize),
+		blockers:   NewSpatialHash(DefaultSpatialCellSize),
+	}
+}
+
+func (s *CombatSpace) Rebuild(combatants []core.Combatant, room *world.Room) {
+	s.actors.Clear()
+	s.combatants = s.combatants[:0]
+	s.maxHalfExtent = 0
+	for _, combatant := range combatants {
+		if combatant == nil || !combatant.IsAlive() {
+			continue
+		}
+		x, y, width, height := combatant.GetBounds()
+		s.actors.Insert(world.AABB{X: x, Y: y, Width: width, Height: height})
+		s.combatants = append(s.combatants, combatant)
+		if half := width / 2; half > s.maxHalfExtent {
+			s.maxHalfExtent = half
+		}
+		if half := height / 2; half > s.maxHalfExtent {
+			s.maxHalfExtent = half
+		}
+	}
+	s.rebuildObstacles(room)
+}
+
+func (s *CombatSpace) rebuildObstacles(room *world.Room) {
+	count := 0
+	if room != nil {
+		count = len(room.Obstacles)
+	}
+	if room == s.obstacleRoom && count == s.obstacleCount {
+		return
+	}
+
+	s.obstacles.Clear()
+	s.obstacleRoom = room
+	s.obstacleCount = count
+	if room == nil {
+		return
+	}
+	for _, obstacle := range room.Obstacles {
+		s.obstacles.Insert(obstacle)
+	}
+}
+
+// SetBlockers replaces the dynamic obstacles, such as intact props, that block movement and projectiles until destroyed.
+func (s *CombatSpace) SetBlockers(blockers []world.AABB) {
+	s.blockers.Clear()
+	for _, blocker := range blockers {
+		s.blockers.Insert(blocker)
+	}
+}
+
+func (s *CombatSpace) Combatants() []core.Combatant {
+	return s.combatants
+}
+
+// MaxHalfExtent is the largest half width or height indexed this step, used to widen center-based queries.
+func (s *CombatSpace) MaxHalfExtent() float32 {
+	return s.maxHalfExtent
+}
+
+func (s *CombatSpace) CombatantsInAABB(area world.AABB) []core.Combatant {
+	return s.resolve(s.actors.QueryAABB(area))
+}
+
+func (s *CombatSpace) CombatantsInCircle(x, y, radius float32) []core.Combatant {
+	return s.resolve(s.actors.QueryCircle(x, y, radius))
+}
+
+func (s *CombatSpace) CombatantsInCone(x, y, dirX, dirY, rangeLimit, halfArcDegrees float32) []core.Combatant {
+	return s.resolve(s.actors.QueryCone(x, y, dirX, dirY, rangeLimit, halfArcDegrees))
+}
+
+func (s *CombatSpace) CombatantsAlongSegment(x0, y0, x1, y1, radius float32) []core.Combatant {
+	return s.resolve(s.actors.QuerySegment(x0, y0, x1, y1, radius))
+}
+
+func (s *CombatSpace) resolve(ids []int) []core.Combatant {
+	result := make([]core.Combatant, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, s.combatants[id])
+	}
+	return result
+}
+
+func (s *CombatSpace) OverlapsObstacle(area world.AABB) bool {
+	for _, id := range s.obstacles.QueryAABB(area) {
+		if AABBOverlap(area, s.obstacles.Bounds(id)) {
+			return true
+		}
+	}
+	return s.OverlapsBlocker(area)
+}
+
+func (s *CombatSpace) OverlapsBlocker(area world.AABB) bool {
+	for _, id := range s.blockers.QueryAABB(area) {
+		if AABBOverlap(area, s.blockers.Bounds(id)) {
+			return true
+		}
+	}
+	return false
+}
+
+// SweepTerrain returns the fraction (0..1) of the segment a circle of the given radius covers before touching a room obstacle.
+// Blockers are not terrain: props take hits and are checked separately.
+func (s *CombatSpace) SweepTerrain(x0, y0, x1, y1, radius float32) (float32, bool) {
+	first := float32(1)
+	hit := false
+	for _, id := range s.obstacles.QuerySegment(x0, y0, x1, y1, radius) {
+		fraction, ok := pure.SweepSegmentAABB(x0, y0, x1, y1, radius, s.obstacles.Bounds(id))
+		if ok && fraction <= first {
+			first = fraction
+			hit = true
+		}
+	}
+	return first, hit
+}
+
+// HasLineOfSight reports whether nothing in the room's terrain blocks the straight line between two points.
+// The tile grid is walked first as a cheap reject; room obstacles are then checked exactly.
+func (s *CombatSpace) HasLineOfSight(x0, y0, x1, y1 float32) bool {
+	if !s.obstacleRoom.TileLineOfSight(x0, y0, x1, y1) {
+		return false
+	}
+	_, blocked := s.SweepTerrain(x0, y0, x1, y1, 0)
+	return !blocked
+}
+
+func (s *CombatSpace) ObstaclesNear(area world.AABB) []world.AABB {
+	obstacles := s.TerrainNear(area)
+	for _, id := range s.blockers.QueryAABB(area) {
+		obstacles = append(obstacles, s.blockers.Bounds(id))
+	}
+	return obstacles
+}
+
+// TerrainNear returns only the room obstacles near the area, leaving out blockers.
+func (s *CombatSpace) TerrainNear(area world.AABB) []world.AABB {
+	ids := s.obstacles.QueryAABB(area)
+	obstacles := make([]world.AABB, 0, len(ids))
+	for _, id := range ids {
+		obstacles = append(obstacles, s.obstacles.Bounds(id))
+	}
+	return obstacles
+}
+
+// TargetCandidates narrows the indexed combatants to those the targeting spec could possibly reach. Ally targeting
+// keeps the caster's living faction mates, the caster included.
+func (s *CombatSpace) TargetCandidates(caster core.Combatant, intent CastIntent, spec gamedata.TargetingSpec) []core.Combatant {
+	if caster == nil {
+		return nil
+	}
+	casterX, casterY := caster.Center()
+
+	switch spec.Type {
+	case gamedata.TargetEnemy:
+		if spec.Range <= 0 {
+			return s.combatants
+		}
+		return s.CombatantsInCircle(casterX, casterY, spec.Range)
+	case gamedata.TargetArea:
+		if spec.Range < 0 {
+			return s.CombatantsInCircle(intent.CursorX, intent.CursorY, spec.Radius)
+		}
+		return s.CombatantsInCircle(casterX, casterY, spec.Range+spec.Radius)
+	case gamedata.TargetDirection:
+		if spec.Range <= 0 {
+			return nil
+		}
+		dirLen := GetDistance(0, 0, intent.DirectionX, intent.DirectionY)
+		if dirLen <= 0 {
+			return nil
+		}
+		if spec.DirectionalLineWidth > 0 {
+			endX := casterX + intent.DirectionX/dirLen*spec.Range
+			endY := casterY + intent.DirectionY/dirLen*spec.Range
+			return s.CombatantsAlongSegment(casterX, casterY, endX, endY, spec.DirectionalLineWidth*0.5)
+		}
+		arc := spec.DirectionalArcDegrees
+		if arc <= 0 {
+			arc = 60
+		}
+		return s.CombatantsInCone(casterX, casterY, intent.DirectionX, intent.DirectionY, spec.Range, arc*0.5)
+	case gamedata.TargetAlly:
+		pool := s.combatants
+		if spec.Range > 0 {
+			pool = s.CombatantsInCircle(casterX, casterY, spec.Range)
+		}
+		allies := make([]core.Combatant, 0, len(pool))
+		for _, combatant := range pool {
+			if combatant != nil && combatant.IsAlive() && combatant.GetFaction() == caster.GetFaction() {
+				allies = append(allies, combatant)
+			}
+		}
+		return allies
+	default:
+		return nil
+	}
+}
+
+func ResolveTargetsInSpace(caster *gameobjects.Player, intent CastIntent, spec gamedata.TargetingSpec, space *CombatSpace) []core.Combatant {
+	if space == nil {
+		return ResolveCombatantTargets(caster, intent, spec, nil)
+	}
+	return ResolveCombatantTargets(caster, intent, spec, space.TargetCandidates(caster, intent, spec))
+}
@@ -1,28 +1,46 @@
 package systems
 
 import (
-	"math/rand"
-
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 )
 
 type DamageRequest struct {
 	Source             *gameobjects.Player
-	Target             interface{}
+	Target             core.Combatant
 	BaseDamage         float32
 	DamageType         gamedata.DamageType
 	CritChance         float32
 	CritMultiplier     float32
 	UseSourceModifiers bool
 	SuppressFlash      bool
+	Accuracy           float32
 	CritRoll           *float32
+	HitRoll            *float32
+	RNG                *core.RunRNG
 }
 
+type HitOutcome int
+
+const (
+	HitOutcomeHit HitOutcome = iota
+	HitOutcomeCrit
+	HitOutcomeBlocked
+	HitOutcomeDodged
+	HitOutcomeMiss
+)
+
 type DamageResult struct {
 	RequestedDamage int
 	AppliedDamage   int
 	IsCrit          bool
+	Outcome         HitOutcome
+}
+
+// Avoided reports whether the hit never connected, so neither damage nor effects should land.
+func (r DamageResult) Avoided() bool {
+	return r.Outcome == HitOutcomeMiss || r.Outcome == HitOutcomeDodged
 }
 
 func ComputeDamage(spec *gamedata.DamageSpec, stats *gamedata.Stats) float32 {
@@ -42,6 +60,11 @@ func ResolveAndApplyDamage(request DamageRequest) DamageResult {
 		return DamageResult{}
 	}
 
+	outcome := rollHitOutcome(request)
+	if outcome == HitOutcomeMiss || outcome == HitOutcomeDodged {
+		return DamageResult{Outcome: outcome}
+	}
+
 	damage := request.BaseDamage
 	if request.UseSourceModifiers && request.Source != nil {
 		damage *= sourceDamageMultiplier(request.Source)
@@ -50,7 +73,10 @@ func ResolveAndApplyDamage(request DamageRequest) DamageResult {
 	isCrit := false
 	if shouldCrit(request) {
 		isCrit = true
-		damage *= resolveCritMultiplier(request.CritMultiplier)
+		damage *= resolveCritMultiplier(request)
+		if outcome == HitOutcomeHit {
+			outcome = HitOutcomeCrit
+		}
 	}
 
 	finalDamage := int(damage)
@@ -58,14 +84,74 @@ func ResolveAndApplyDamage(request DamageRequest) DamageResult {
 		return DamageResult{}
 	}
 
-	applied := applyDamageToTarget(request.Target, finalDamage, request.DamageType, request.SuppressFlash)
+	resistance := request.Target.GetResistance(request.DamageType)
+	if resistance > 0 && request.Source != nil {
+		resistance *= 1 - request.Source.DerivedStats.Penetration(request.DamageType)
+	}
+	mitigated := gamedata.MitigateDamage(finalDamage, resistance)
+	if outcome == HitOutcomeBlocked {
+		mitigated = gamedata.MitigateDamage(mitigated, request.Target.GetDefense().BlockAmount)
+	}
+	applied := applyDamageToTarget(request.Target, mitigated, request.DamageType, request.SuppressFlash)
 	return DamageResult{
 		RequestedDamage: finalDamage,
 		AppliedDamage:   applied,
 		IsCrit:          isCrit,
+		Outcome:         outcome,
 	}
 }
 
+// rollHitOutcome walks a single-roll attack table of miss, dodge and block. Accuracy below 1 is the
+// attacker's own miss chance; accuracy above 1 cancels that much of the defender's dodge, then block.
+// Only physical hits can be blocked and true damage is never avoided.
+func rollHitOutcome(request DamageRequest) HitOutcome {
+	if request.DamageType == gamedata.DamageTrue {
+		return HitOutcomeHit
+	}
+
+	defense := request.Target.GetDefense()
+	accuracy := resolveAccuracy(request)
+	miss := max(0, 1-accuracy)
+	overflow := max(0, accuracy-1)
+	dodge := max(0, defense.DodgeChance-overflow)
+	overflow = max(0, overflow-defense.DodgeChance)
+	block := float32(0)
+	if request.DamageType == gamedata.DamagePhysical {
+		block = max(0, defense.BlockChance-overflow)
+	}
+	if miss+dodge+block <= 0 {
+		return HitOutcomeHit
+	}
+
+	roll := request.RNG.Float32(core.RNGStreamCombatHits)
+	if request.HitRoll != nil {
+		roll = *request.HitRoll
+	}
+	switch {
+	case roll < miss:
+		return HitOutcomeMiss
+	case roll < miss+dodge:
+		return HitOutcomeDodged
+	case roll < miss+dodge+block:
+		return HitOutcomeBlocked
+	default:
+		return HitOutcomeHit
+	}
+}
+
+func resolveAccuracy(request DamageRequest) float32 {
+	if request.Accuracy > 0 {
+		return request.Accuracy
+	}
+	if request.Source == nil {
+		return gamedata.EnemyBaseAccuracy
+	}
+	if request.Source.DerivedStats.Accuracy > 0 {
+		return request.Source.DerivedStats.Accuracy
+	}
+	return 1
+}
+
 func sourceDamageMultiplier(source *gameobjects.Player) float32 {
 	if source == nil {
 		return 1
@@ -81,41 +167,27 @@ func shouldCrit(request DamageRequest) bool {
 	if request.CritChance <= 0 {
 		return false
 	}
-	roll := rand.Float32()
 	if request.CritRoll != nil {
-		roll = *request.CritRoll
+		return *request.CritRoll < clamp01(request.CritChance)
 	}
-	return roll < clamp01(request.CritChance)
+	return request.RNG.Float32(core.RNGStreamCombatCrits) < clamp01(request.CritChance)
 }
 
-func resolveCritMultiplier(multiplier float32) float32 {
-	if multiplier <= 1 {
-		return 1.5
+func resolveCritMultiplier(request DamageRequest) float32 {
+	if request.CritMultiplier > 1 {
+		return request.CritMultiplier
 	}
-	return multiplier
+	if request.Source != nil && request.Source.DerivedStats.CritMultiplier > 1 {
+		return request.Source.DerivedStats.CritMultiplier
+	}
+	return gamedata.BaseCritMultiplier
 }
 
-func applyDamageToTarget(target interface{}, damage int, damageType gamedata.DamageType, suppressFlash bool) int {
-	switch t := target.(type) {
-	case *gameobjects.Player:
-		return t.ApplyTypedDamage(damage, damageType, !suppressFlash)
-	case *gameobjects.Enemy:
-		before := t.HP
-		t.TakeDamage(damage)
-		if before < t.HP {
-			return 0
-		}
-		return before - t.HP
-	case *gameobjects.Boss:
-		before := t.HP
-		t.TakeDamage(damage)
-		if before < t.HP {
-			return 0
-		}
-		return before - t.HP
-	default:
+func applyDamageToTarget(target core.Combatant, damage int, damageType gamedata.DamageType, suppressFlash bool) int {
+	if target == nil {
 		return 0
 	}
+	return target.ApplyCombatDamage(damage, damageType, !suppressFlash)
 }
 
 func clamp01(value float32) float32 {
@@ -1,18 +1,84 @@
 package systems
 
-import rl "github.com/gen2brain/raylib-go/raylib"
+import (
+	"singlefantasy/app/gamedata"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
 
 type Input struct {
-	MoveToX       float32
-	MoveToY       float32
-	HasMoveTarget bool
-	CursorWorldX  float32
-	CursorWorldY  float32
-	Attack        bool
-	Skill1        bool
-	Skill2        bool
-	Skill3        bool
-	Skill4        bool
+	MoveToX         float32
+	MoveToY         float32
+	HasMoveTarget   bool
+	CursorWorldX    float32
+	CursorWorldY    float32
+	Attack          bool
+	Skill1          bool
+	Skill2          bool
+	Skill3          bool
+	Skill4          bool
+	Interact        bool
+	StatAllocations []gamedata.StatType
+}
+
+// InputSource supplies one Input snapshot per fixed runtime step.
+type InputSource interface {
+	Poll(camera *Camera) *Input
+}
+
+type RaylibInputSource struct{}
+
+func (s *RaylibInputSource) Poll(camera *Camera) *Input {
+	return UpdateInput(camera)
+}
+
+// ScriptedInputSource replays a fixed list of inputs and reports empty input once exhausted.
+type ScriptedInputSource struct {
+	Steps []Input
+	index int
+}
+
+func NewScriptedInputSource(steps ...Input) *ScriptedInputSource {
+	return &ScriptedInputSource{
+		Steps: append([]Input(nil), steps...),
+		index: 0,
+	}
+}
+
+func (s *ScriptedInputSource) Poll(_ *Camera) *Input {
+	if s == nil || s.index >= len(s.Steps) {
+		return &Input{}
+	}
+	input := s.Steps[s.index]
+	input.StatAllocations = append([]gamedata.StatType(nil), input.StatAllocations...)
+	s.index++
+	return &input
+}
+
+func (s *ScriptedInputSource) Push(steps ...Input) {
+	if s == nil {
+		return
+	}
+	s.Steps = append(s.Steps, steps...)
+}
+
+func (s *ScriptedInputSource) Remaining() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.Steps) - s.index
+}
+
+var statAllocationKeys = []struct {
+	key  int32
+	stat gamedata.StatType
+}{
+	{key: rl.KeyOne, stat: gamedata.StatTypeSTR},
+	{key: rl.KeyTwo, stat: gamedata.StatTypeAGI},
+	{key: rl.KeyThree, stat: gamedata.StatTypeVIT},
+	{key: rl.KeyFour, stat: gamedata.StatTypeINT},
+	{key: rl.KeyFive, stat: gamedata.StatTypeDEX},
+	{key: rl.KeySix, stat: gamedata.StatTypeLUK},
 }
 
 func UpdateInput(camera *Camera) *Input {
@@ -29,17 +95,26 @@ func UpdateInput(camera *Camera) *Input {
 		hasMoveTarget = true
 	}
 
+	statAllocations := []gamedata.StatType{}
+	for _, binding := range statAllocationKeys {
+		if rl.IsKeyPressed(binding.key) {
+			statAllocations = append(statAllocations, binding.stat)
+		}
+	}
+
 	return &Input{
-		MoveToX:       moveToX,
-		MoveToY:       moveToY,
-		HasMoveTarget: hasMoveTarget,
-		CursorWorldX:  cursorWorldX,
-		CursorWorldY:  cursorWorldY,
-		Attack:        rl.IsMouseButtonPressed(rl.MouseLeftButton),
-		Skill1:        rl.IsKeyPressed(rl.KeyQ) || rl.IsKeyPressed(rl.KeyOne),
-		Skill2:        rl.IsKeyPressed(rl.KeyW) || rl.IsKeyPressed(rl.KeyTwo),
-		Skill3:        rl.IsKeyPressed(rl.KeyE) || rl.IsKeyPressed(rl.KeyThree),
-		Skill4:        rl.IsKeyPressed(rl.KeyR) || rl.IsKeyPressed(rl.KeyFour),
+		MoveToX:         moveToX,
+		MoveToY:         moveToY,
+		HasMoveTarget:   hasMoveTarget,
+		CursorWorldX:    cursorWorldX,
+		CursorWorldY:    cursorWorldY,
+		Attack:          rl.IsMouseButtonPressed(rl.MouseLeftButton),
+		Skill1:          rl.IsKeyPressed(rl.KeyQ) || rl.IsKeyPressed(rl.KeyOne),
+		Skill2:          rl.IsKeyPressed(rl.KeyW) || rl.IsKeyPressed(rl.KeyTwo),
+		Skill3:          rl.IsKeyPressed(rl.KeyE) || rl.IsKeyPressed(rl.KeyThree),
+		Skill4:          rl.IsKeyPressed(rl.KeyR) || rl.IsKeyPressed(rl.KeyFour),
+		Interact:        rl.IsKeyPressed(rl.KeyF),
+		StatAllocations: statAllocations,
 	}
 }
 
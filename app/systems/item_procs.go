@@ -0,0 +1,140 @@
+package systems
+
+import (
+	"math"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+)
+
+type ItemProcWorld interface {
+	ItemAreaDamage(owner *gameobjects.Player, x, y, radius float32, damage int, damageType gamedata.DamageType)
+	ItemProjectile(owner *gameobjects.Player, x, y float32, target core.Combatant, damage int, damageType gamedata.DamageType, speed float32)
+}
+
+type ItemProcContext struct {
+	Target core.Combatant
+	Damage int
+	Roll   *float32
+	RNG    *core.RunRNG
+	World  ItemProcWorld
+}
+
+func TriggerItemEffects(owner *gameobjects.Player, trigger gamedata.ItemTrigger, ctx ItemProcContext) int {
+	if owner == nil || !owner.IsAlive() {
+		return 0
+	}
+
+	fired := 0
+	for _, effect := range owner.GetItemEffects() {
+		if effect.Trigger != trigger || !itemConditionsHold(owner, effect, ctx) {
+			continue
+		}
+		applyItemAction(owner, effect.Action, ctx)
+		fired++
+	}
+	return fired
+}
+
+func TriggerPeriodicItemEffects(owner *gameobjects.Player, elapsed, dt float32, ctx ItemProcContext) int {
+	if owner == nil || !owner.IsAlive() || dt <= 0 {
+		return 0
+	}
+
+	fired := 0
+	for _, effect := range owner.GetItemEffects() {
+		if effect.Trigger != gamedata.ItemTriggerPeriodic || effect.Interval <= 0 {
+			continue
+		}
+		if math.Floor(float64(elapsed/effect.Interval)) <= math.Floor(float64((elapsed-dt)/effect.Interval)) {
+			continue
+		}
+		if !itemConditionsHold(owner, effect, ctx) {
+			continue
+		}
+		applyItemAction(owner, effect.Action, ctx)
+		fired++
+	}
+	return fired
+}
+
+func itemConditionsHold(owner *gameobjects.Player, effect gamedata.ItemEffect, ctx ItemProcContext) bool {
+	for _, condition := range effect.Conditions {
+		switch condition.Type {
+		case gamedata.ItemConditionTargetHasEffect:
+			if !targetHasAnyEffect(ctx.Target, condition.Effects) {
+				return false
+			}
+		case gamedata.ItemConditionTargetHPBelow:
+			if ctx.Target == nil || !ctx.Target.IsAlive() || HealthRatio(ctx.Target) >= condition.Value {
+				return false
+			}
+		case gamedata.ItemConditionSelfHPBelow:
+			if HealthRatio(owner) >= condition.Value {
+				return false
+			}
+		}
+	}
+
+	chance := effect.Chance()
+	if chance >= 1 {
+		return true
+	}
+	return shouldTriggerItemProc(chance, ctx.Roll, ctx.RNG)
+}
+
+func applyItemAction(owner *gameobjects.Player, action gamedata.ItemAction, ctx ItemProcContext) {
+	amount := action.Amount + action.PercentOfDamage*float32(ctx.Damage)
+
+	switch action.Type {
+	case gamedata.ItemActionApplyEffect:
+		target := ctx.Target
+		if action.OnSelf {
+			target = owner
+		}
+		if target != nil && target.IsAlive() {
+			applyEffectSpecToTarget(target, action.Effect)
+		}
+	case gamedata.ItemActionAreaDamage:
+		if ctx.World == nil || int(amount) <= 0 {
+			return
+		}
+		x, y := owner.Center()
+		if ctx.Target != nil {
+			x, y = ctx.Target.Center()
+		}
+		ctx.World.ItemAreaDamage(owner, x, y, action.Radius, int(amount), action.DamageType)
+	case gamedata.ItemActionHeal:
+		owner.Heal(int(amount))
+	case gamedata.ItemActionRestoreMana:
+		if int(amount) > 0 {
+			owner.GainMana(int(amount))
+		}
+	case gamedata.ItemActionReduceCooldowns:
+		for _, skill := range owner.Skills {
+			if skill != nil {
+				skill.CurrentCooldown = max(0, skill.CurrentCooldown-action.Amount)
+			}
+		}
+	case gamedata.ItemActionSpawnProjectile:
+		if ctx.World == nil || int(amount) <= 0 {
+			return
+		}
+		x, y := owner.Center()
+		ctx.World.ItemProjectile(owner, x, y, ctx.Target, int(amount), action.DamageType, action.Speed)
+	}
+}
+
+func targetHasAnyEffect(target core.Combatant, effectTypes []gamedata.EffectType) bool {
+	if target == nil {
+		return false
+	}
+	effects := target.GetEffects()
+	for _, effectType := range effectTypes {
+		if gamedata.HasEffect(effects, effectType) {
+			return true
+		}
+	}
+	return false
+}
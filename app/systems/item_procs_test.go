@@ -0,0 +1,127 @@
+//go:build raylib
+// +build raylib
+
+package systems
+
+import (
+	"testing"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+)
+
+type recordingProcWorld struct {
+	areaDamage  []int
+	projectiles []int
+	areaX       float32
+	areaY       float32
+}
+
+func (w *recordingProcWorld) ItemAreaDamage(owner *gameobjects.Player, x, y, radius float32, damage int, damageType gamedata.DamageType) {
+	w.areaDamage = append(w.areaDamage, damage)
+	w.areaX, w.areaY = x, y
+}
+
+func (w *recordingProcWorld) ItemProjectile(owner *gameobjects.Player, x, y float32, target core.Combatant, damage int, damageType gamedata.DamageType, speed float32) {
+	w.projectiles = append(w.projectiles, damage)
+}
+
+func equipTestItemEffect(player *gameobjects.Player, slot gamedata.ItemSlot, effect gamedata.ItemEffect) {
+	player.EquipItem(gamedata.NewCuratedItem("test_proc", "Test Proc", "", slot, map[gamedata.StatType]int{}, gamedata.ClassTypeAny, gamedata.ItemMetadata{Effects: []gamedata.ItemEffect{effect}}))
+}
+
+func TestApplyCombatHitOnKillItemEffectHealsCaster(t *testing.T) {
+	caster := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeCaster)
+	equipTestItemEffect(caster, gamedata.ItemSlotWeapon, gamedata.ItemEffect{Trigger: gamedata.ItemTriggerOnKill, Action: gamedata.ItemAction{Type: gamedata.ItemActionHeal, Amount: 12}})
+	caster.HP = caster.MaxHP / 2
+	enemy := gameobjects.NewEnemy(0, 0, false)
+
+	critRoll := float32(1)
+	ApplyCombatHit(CombatHitRequest{Caster: caster, Target: enemy, BaseDamage: 5, DamageType: gamedata.DamageTrue, ApplyOnHitHooks: true, CritRoll: &critRoll})
+	if caster.HP != caster.MaxHP/2 {
+		t.Fatalf("expected no on-kill heal while the target survives, got %d", caster.HP)
+	}
+
+	ApplyCombatHit(CombatHitRequest{Caster: caster, Target: enemy, BaseDamage: enemy.MaxHP, DamageType: gamedata.DamageTrue, ApplyOnHitHooks: true, CritRoll: &critRoll})
+	if enemy.IsAlive() || caster.HP != caster.MaxHP/2+12 {
+		t.Fatalf("expected kill to heal caster by 12, got hp=%d alive=%v", caster.HP, enemy.IsAlive())
+	}
+}
+
+func TestApplyCombatHitOnDamagedItemEffectsRespectConditions(t *testing.T) {
+	player := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	equipTestItemEffect(player, gamedata.ItemSlotLower, gamedata.ItemEffect{
+		Trigger:    gamedata.ItemTriggerOnDamaged,
+		Conditions: []gamedata.ItemCondition{{Type: gamedata.ItemConditionSelfHPBelow, Value: 0.35}},
+		Action:     gamedata.ItemAction{Type: gamedata.ItemActionApplyEffect, OnSelf: true, Effect: gamedata.EffectSpec{Type: gamedata.EffectDamageReduction, Duration: 3, Magnitude: 0.3}},
+	})
+	equipTestItemEffect(player, gamedata.ItemSlotChest, gamedata.ItemEffect{
+		Trigger:    gamedata.ItemTriggerOnDamaged,
+		Conditions: []gamedata.ItemCondition{{Type: gamedata.ItemConditionChance, Value: 0.3}},
+		Action:     gamedata.ItemAction{Type: gamedata.ItemActionAreaDamage, Amount: 12, Radius: 90},
+	})
+	world := &recordingProcWorld{}
+
+	missRoll := float32(0.9)
+	ApplyCombatHit(CombatHitRequest{Target: player, BaseDamage: 5, DamageType: gamedata.DamageTrue, OnHitProcRoll: &missRoll, ProcWorld: world})
+	if gamedata.HasEffect(&player.Effects, gamedata.EffectDamageReduction) || len(world.areaDamage) != 0 {
+		t.Fatalf("expected no on-damaged procs above the HP gate and over the proc roll, got effects=%+v area=%v", player.Effects, world.areaDamage)
+	}
+
+	player.HP = player.MaxHP / 4
+	procRoll := float32(0.1)
+	ApplyCombatHit(CombatHitRequest{Target: player, BaseDamage: 5, DamageType: gamedata.DamageTrue, OnHitProcRoll: &procRoll, ProcWorld: world})
+	if !gamedata.HasEffect(&player.Effects, gamedata.EffectDamageReduction) {
+		t.Fatalf("expected low-HP self buff after taking damage")
+	}
+	centerX, centerY := player.Center()
+	if len(world.areaDamage) != 1 || world.areaDamage[0] != 12 || world.areaX != centerX || world.areaY != centerY {
+		t.Fatalf("expected one 12 damage area proc around the player, got %v at (%.1f,%.1f)", world.areaDamage, world.areaX, world.areaY)
+	}
+}
+
+func TestApplyCombatHitOnCritItemEffectSpawnsProjectile(t *testing.T) {
+	caster := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeRanged)
+	equipTestItemEffect(caster, gamedata.ItemSlotLower, gamedata.ItemEffect{Trigger: gamedata.ItemTriggerOnCrit, Action: gamedata.ItemAction{Type: gamedata.ItemActionSpawnProjectile, Amount: 10, Speed: 420}})
+	enemy := gameobjects.NewEnemy(100, 0, false)
+	world := &recordingProcWorld{}
+
+	noCrit := float32(1)
+	ApplyCombatHit(CombatHitRequest{Caster: caster, Target: enemy, BaseDamage: 4, ApplyOnHitHooks: true, CritRoll: &noCrit, ProcWorld: world})
+	crit := float32(0)
+	ApplyCombatHit(CombatHitRequest{Caster: caster, Target: enemy, BaseDamage: 4, CritChance: 1, ApplyOnHitHooks: true, CritRoll: &crit, ProcWorld: world})
+	if len(world.projectiles) != 1 || world.projectiles[0] != 10 {
+		t.Fatalf("expected exactly one crit projectile proc, got %v", world.projectiles)
+	}
+}
+
+func TestTriggerItemEffectsReducesCooldownsOnSkillCast(t *testing.T) {
+	player := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeCaster)
+	equipTestItemEffect(player, gamedata.ItemSlotHead, gamedata.ItemEffect{Trigger: gamedata.ItemTriggerOnSkillCast, Action: gamedata.ItemAction{Type: gamedata.ItemActionReduceCooldowns, Amount: 1}})
+	player.Skills[0].CurrentCooldown = 3
+	player.Skills[1].CurrentCooldown = 0.5
+
+	if fired := TriggerItemEffects(player, gamedata.ItemTriggerOnSkillCast, ItemProcContext{}); fired != 1 {
+		t.Fatalf("expected one on-cast effect to fire, got %d", fired)
+	}
+	if player.Skills[0].CurrentCooldown != 2 || player.Skills[1].CurrentCooldown != 0 {
+		t.Fatalf("expected cooldowns reduced and clamped at zero, got %.2f and %.2f", player.Skills[0].CurrentCooldown, player.Skills[1].CurrentCooldown)
+	}
+}
+
+func TestTriggerPeriodicItemEffectsFiresOnIntervalBoundary(t *testing.T) {
+	player := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeCaster)
+	equipTestItemEffect(player, gamedata.ItemSlotLower, gamedata.ItemEffect{Trigger: gamedata.ItemTriggerPeriodic, Interval: 5, Action: gamedata.ItemAction{Type: gamedata.ItemActionRestoreMana, Amount: 6}})
+	player.Mana = 0
+
+	if fired := TriggerPeriodicItemEffects(player, 4.9, 0.1, ItemProcContext{}); fired != 0 || player.Mana != 0 {
+		t.Fatalf("expected no periodic proc before the interval, fired=%d mana=%d", fired, player.Mana)
+	}
+	if fired := TriggerPeriodicItemEffects(player, 5.05, 0.15, ItemProcContext{}); fired != 1 || player.Mana != 6 {
+		t.Fatalf("expected periodic mana when crossing the interval, fired=%d mana=%d", fired, player.Mana)
+	}
+	if fired := TriggerPeriodicItemEffects(player, 5.2, 0.15, ItemProcContext{}); fired != 0 || player.Mana != 6 {
+		t.Fatalf("expected periodic proc to fire once per interval, fired=%d mana=%d", fired, player.Mana)
+	}
+}
@@ -25,9 +25,18 @@ func ResolvePlayerMovement(
 		Width:  room.Width,
 		Height: room.Height,
 	}
+	return ResolveMovementAgainst(posX, posY, width, height, deltaX, deltaY, roomBounds, room.Obstacles)
+}
 
-	newX := resolveX(posX, posY, width, height, deltaX, roomBounds, room.Obstacles)
-	newY := resolveY(newX, posY, width, height, deltaY, roomBounds, room.Obstacles)
+func ResolveMovementAgainst(
+	posX, posY,
+	width, height,
+	deltaX, deltaY float32,
+	bounds world.AABB,
+	obstacles []world.AABB,
+) (float32, float32) {
+	newX := resolveX(posX, posY, width, height, deltaX, bounds, obstacles)
+	newY := resolveY(newX, posY, width, height, deltaY, bounds, obstacles)
 	return newX, newY
 }
 
@@ -92,3 +101,38 @@ func clamp(value, minValue, maxValue float32) float32 {
 	}
 	return value
 }
+
+// SweepSegmentAABB returns the fraction of the segment (0..1) at which a circle of the given radius moving along it
+// first touches the box. Grazing contact along a face does not count, so shots can skim walls.
+func SweepSegmentAABB(x0, y0, x1, y1, radius float32, box world.AABB) (float32, bool) {
+	tMin := float32(0)
+	tMax := float32(1)
+	deltas := [2]float32{x1 - x0, y1 - y0}
+	origins := [2]float32{x0, y0}
+	mins := [2]float32{box.X - radius, box.Y - radius}
+	maxs := [2]float32{box.X + box.Width + radius, box.Y + box.Height + radius}
+
+	for axis := 0; axis < 2; axis++ {
+		if deltas[axis] == 0 {
+			if origins[axis] <= mins[axis] || origins[axis] >= maxs[axis] {
+				return 0, false
+			}
+			continue
+		}
+		t0 := (mins[axis] - origins[axis]) / deltas[axis]
+		t1 := (maxs[axis] - origins[axis]) / deltas[axis]
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if t0 > tMin {
+			tMin = t0
+		}
+		if t1 < tMax {
+			tMax = t1
+		}
+		if tMin >= tMax {
+			return 0, false
+		}
+	}
+	return tMin, true
+}
@@ -43,3 +43,24 @@ func TestResolvePlayerMovementSliding(t *testing.T) {
 		t.Fatalf("expected y to resolve against obstacle top at 90, got %.2f", y)
 	}
 }
+
+func TestSweepSegmentAABBFindsFirstContact(t *testing.T) {
+	wall := world.AABB{X: 100, Y: 0, Width: 50, Height: 100}
+
+	fraction, hit := SweepSegmentAABB(0, 50, 200, 50, 10, wall)
+	if !hit || fraction != 0.45 {
+		t.Fatalf("expected contact at 0.45 of the sweep, got %.3f hit=%v", fraction, hit)
+	}
+
+	if _, hit := SweepSegmentAABB(0, 50, 80, 50, 10, wall); hit {
+		t.Fatalf("expected sweep that stops short of the wall to miss")
+	}
+	if _, hit := SweepSegmentAABB(0, 110, 200, 110, 10, wall); hit {
+		t.Fatalf("expected sweep grazing the wall face to miss")
+	}
+
+	fraction, hit = SweepSegmentAABB(120, 50, 200, 50, 4, wall)
+	if !hit || fraction != 0 {
+		t.Fatalf("expected sweep starting inside the wall to hit immediately, got %.3f hit=%v", fraction, hit)
+	}
+}
@@ -24,6 +24,11 @@ var EnemyColorRGBA = rl.NewColor(255, 0, 0, 255)
 var EliteColorRGBA = rl.NewColor(255, 136, 0, 255)
 var BossColorRGBA = rl.NewColor(136, 0, 255, 255)
 var ProjectileColorRGBA = rl.NewColor(255, 255, 0, 255)
+var HazardBurningColorRGBA = rl.NewColor(226, 104, 38, 110)
+var HazardPoisonColorRGBA = rl.NewColor(104, 170, 58, 110)
+var TrapPlateColorRGBA = rl.NewColor(70, 64, 58, 200)
+var TrapTelegraphColorRGBA = rl.NewColor(240, 196, 64, 255)
+var TrapActiveColorRGBA = rl.NewColor(214, 48, 40, 170)
 
 type Camera struct {
 	X         float32
@@ -306,6 +311,8 @@ func drawTemplateRoom(room *world.Room, camera *Camera) {
 		}
 	}
 
+	drawRoomHazards(room, camera)
+
 	// Draw wall facades in a separate pass to maintain clean layering.
 	for y, row := range room.Tiles {
 		for x, tile := range row {
@@ -351,6 +358,43 @@ func drawTemplateRoom(room *world.Room, camera *Camera) {
 	}
 }
 
+func drawRoomHazards(room *world.Room, camera *Camera) {
+	if room.Hazards != nil {
+		fill := HazardBurningColorRGBA
+		if room.Hazards.Config.Kind == world.HazardKindPoisonBog {
+			fill = HazardPoisonColorRGBA
+		}
+		for _, tile := range room.Hazards.Tiles {
+			corners := projectAABBBase(tile, camera)
+			drawIsoQuad(corners, fill)
+			drawIsoOutline(corners, shadeColor(fill, -40))
+		}
+	}
+	for _, trap := range room.Traps {
+		drawTrap(trap, camera)
+	}
+}
+
+// drawTrap shows the plate at all times, a strike-area warning that brightens through the telegraph, and a solid fill while active.
+func drawTrap(trap *world.Trap, camera *Camera) {
+	plate := projectAABBBase(trap.Bounds, camera)
+	drawIsoOutline(plate, TrapPlateColorRGBA)
+
+	strike := projectAABBBase(trap.Strike, camera)
+	switch trap.Phase {
+	case world.TrapPhaseTelegraph:
+		progress := trap.TelegraphProgress()
+		fill := TrapTelegraphColorRGBA
+		fill.A = uint8(30 + 110*progress)
+		drawIsoQuad(strike, fill)
+		drawIsoOutline(strike, TrapTelegraphColorRGBA)
+	case world.TrapPhaseActive:
+		drawIsoQuad(strike, TrapActiveColorRGBA)
+		drawIsoOutline(strike, shadeColor(TrapActiveColorRGBA, -40))
+	default:
+	}
+}
+
 func wallAtlasTileForPosition(room *world.Room, x, y int) (int, int, bool) {
 	hasWalkableAbove := tileIsWalkable(room, x, y-1)
 	hasWalkableBelow := tileIsWalkable(room, x, y+1)
@@ -561,6 +605,94 @@ func DrawBoss(boss *gameobjects.Boss, camera *Camera) {
 	drawHealthBar(destRect, float32(boss.HP)/float32(boss.MaxHP), 8)
 }
 
+func DrawProp(prop *gameobjects.Prop, camera *Camera) {
+	if !prop.IsAlive() {
+		return
+	}
+
+	screenX, screenY := actorScreenRect(prop.PosX, prop.PosY, prop.Hitbox.Width, prop.Hitbox.Height, camera)
+	destRect := rl.NewRectangle(screenX, screenY, prop.Hitbox.Width, prop.Hitbox.Height)
+	color := propColor(prop.Type)
+	if prop.HitFlashTimer > 0 {
+		color = rl.Orange
+	}
+	rl.DrawRectangleRec(destRect, color)
+	rl.DrawRectangleLinesEx(destRect, 1, shadeColor(color, -70))
+	if prop.HP < prop.MaxHP {
+		drawHealthBar(destRect, float32(prop.HP)/float32(prop.MaxHP), 4)
+	}
+}
+
+func propColor(propType gamedata.PropType) rl.Color {
+	switch propType {
+	case gamedata.PropBarrel:
+		return rl.NewColor(132, 86, 48, 255)
+	case gamedata.PropExplosiveUrn:
+		return rl.NewColor(188, 64, 44, 255)
+	case gamedata.PropBrazier:
+		return rl.NewColor(214, 142, 52, 255)
+	default:
+		return rl.NewColor(164, 124, 76, 255)
+	}
+}
+
+func DrawPickup(x, y, radius float32, dropType gamedata.PropDropType, camera *Camera) {
+	screenX, screenY := WorldToScreenIso(x, y, camera)
+	color := rl.NewColor(84, 214, 96, 255)
+	if dropType == gamedata.PropDropManaOrb {
+		color = rl.NewColor(84, 144, 255, 255)
+	}
+	rl.DrawCircle(int32(screenX), int32(screenY), radius, color)
+	rl.DrawCircleLines(int32(screenX), int32(screenY), radius+2, rl.RayWhite)
+}
+
+// DrawEventSite draws an event room's anchor and its reach ring. progress fills the ring for capture points; spent sites are greyed out.
+func DrawEventSite(x, y, radius float32, kind world.EventKind, progress float32, spent bool, camera *Camera) {
+	screenX, screenY := WorldToScreenIso(x, y, camera)
+	color := eventSiteColor(kind)
+	if spent {
+		color = rl.NewColor(120, 120, 120, 255)
+	}
+	if radius > 0 {
+		rl.DrawCircle(int32(screenX), int32(screenY), radius, rl.NewColor(color.R, color.G, color.B, 36))
+		if progress > 0 {
+			rl.DrawCircle(int32(screenX), int32(screenY), radius*progress, rl.NewColor(color.R, color.G, color.B, 90))
+		}
+		rl.DrawCircleLines(int32(screenX), int32(screenY), radius, rl.NewColor(color.R, color.G, color.B, 200))
+	}
+	if kind == world.EventKindHealingShrine || kind == world.EventKindAltar {
+		rl.DrawCircle(int32(screenX), int32(screenY), 18, color)
+		rl.DrawCircleLines(int32(screenX), int32(screenY), 20, rl.RayWhite)
+	}
+}
+
+func DrawEventTotem(x, y, radius, hpRatio float32, flash bool, camera *Camera) {
+	screenX, screenY := WorldToScreenIso(x, y, camera)
+	destRect := rl.NewRectangle(screenX-radius/2, screenY-radius*2, radius, radius*2)
+	color := eventSiteColor(world.EventKindDefendTotem)
+	if flash {
+		color = rl.Orange
+	}
+	rl.DrawRectangleRec(destRect, color)
+	rl.DrawRectangleLinesEx(destRect, 1, shadeColor(color, -70))
+	drawHealthBar(destRect, hpRatio, 4)
+}
+
+func eventSiteColor(kind world.EventKind) rl.Color {
+	switch kind {
+	case world.EventKindHealingShrine:
+		return rl.NewColor(96, 214, 132, 255)
+	case world.EventKindAltar:
+		return rl.NewColor(168, 96, 220, 255)
+	case world.EventKindCapturePoint:
+		return rl.NewColor(230, 196, 72, 255)
+	case world.EventKindDefendTotem:
+		return rl.NewColor(92, 168, 220, 255)
+	default:
+		return rl.NewColor(200, 200, 200, 255)
+	}
+}
+
 func DrawBossProjectile(x, y, radius float32, camera *Camera) {
 	screenX, screenY := WorldToScreenIso(x, y, camera)
 	rl.DrawCircle(int32(screenX), int32(screenY), radius, rl.Purple)
@@ -889,9 +1021,16 @@ func DrawSkillBar(player *gameobjects.Player, keyLabels []string) {
 	}
 }
 
-func DrawDebugOverlay(lines []string) {
+type DebugTimingBar struct {
+	Label string
+	AvgMs float32
+	MaxMs float32
+}
+
+// DrawDebugOverlay draws the text panel and returns the Y coordinate just below it.
+func DrawDebugOverlay(lines []string) int32 {
 	if len(lines) == 0 {
-		return
+		return 0
 	}
 
 	padding := int32(10)
@@ -917,4 +1056,43 @@ func DrawDebugOverlay(lines []string) {
 		y := panelY + padding + int32(i)*lineHeight
 		rl.DrawText(line, x, y, 18, rl.RayWhite)
 	}
+	return panelY + panelHeight
+}
+
+// DrawDebugTimingBars draws one bar per system scaled against budgetMs: solid for the average, a tick for the max.
+func DrawDebugTimingBars(bars []DebugTimingBar, budgetMs float32, x, y int32) {
+	if len(bars) == 0 || budgetMs <= 0 {
+		return
+	}
+
+	padding := int32(10)
+	rowHeight := int32(18)
+	labelWidth := int32(150)
+	barWidth := int32(220)
+	panelWidth := labelWidth + barWidth + padding*3 + 90
+	panelHeight := int32(len(bars))*rowHeight + padding*2
+
+	rl.DrawRectangle(x, y, panelWidth, panelHeight, rl.NewColor(0, 0, 0, 170))
+	rl.DrawRectangleLines(x, y, panelWidth, panelHeight, rl.NewColor(220, 220, 220, 220))
+
+	for i, bar := range bars {
+		rowY := y + padding + int32(i)*rowHeight
+		barX := x + padding*2 + labelWidth
+		rl.DrawText(bar.Label, x+padding, rowY, 16, rl.RayWhite)
+		rl.DrawRectangle(barX, rowY+3, barWidth, rowHeight-6, rl.NewColor(50, 50, 50, 220))
+
+		avgWidth := int32(clamp(bar.AvgMs/budgetMs, 0, 1) * float32(barWidth))
+		color := rl.NewColor(90, 200, 120, 255)
+		if bar.MaxMs > budgetMs*0.25 {
+			color = rl.NewColor(230, 190, 70, 255)
+		}
+		if bar.MaxMs > budgetMs*0.5 {
+			color = rl.NewColor(230, 80, 70, 255)
+		}
+		rl.DrawRectangle(barX, rowY+3, avgWidth, rowHeight-6, color)
+
+		maxX := barX + int32(clamp(bar.MaxMs/budgetMs, 0, 1)*float32(barWidth))
+		rl.DrawLine(maxX, rowY+1, maxX, rowY+rowHeight-1, rl.RayWhite)
+		rl.DrawText(fmt.Sprintf("%.2f/%.2f", bar.AvgMs, bar.MaxMs), barX+barWidth+padding, rowY, 16, rl.RayWhite)
+	}
 }
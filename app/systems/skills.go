@@ -2,6 +2,7 @@ package systems
 
 import (
 	"math"
+	"singlefantasy/app/core"
 	"singlefantasy/app/gamedata"
 	"singlefantasy/app/gameobjects"
 	"sort"
@@ -15,7 +16,7 @@ type CastIntent struct {
 }
 
 type targetCandidate struct {
-	Target    interface{}
+	Target    core.Combatant
 	CenterX   float32
 	CenterY   float32
 	Distance2 float32
@@ -23,15 +24,18 @@ type targetCandidate struct {
 }
 
 func BuildCastIntent(caster *gameobjects.Player, cursorX, cursorY float32) CastIntent {
+	if caster == nil {
+		return CastIntent{CursorX: cursorX, CursorY: cursorY}
+	}
+	casterX, casterY := caster.Center()
+	return BuildCastIntentFrom(casterX, casterY, cursorX, cursorY, caster.FacingRight)
+}
+
+func BuildCastIntentFrom(casterX, casterY, cursorX, cursorY float32, facingRight bool) CastIntent {
 	intent := CastIntent{
 		CursorX: cursorX,
 		CursorY: cursorY,
 	}
-	if caster == nil {
-		return intent
-	}
-
-	casterX, casterY := caster.Center()
 	dx := cursorX - casterX
 	dy := cursorY - casterY
 	distance := float32(math.Sqrt(float64(dx*dx + dy*dy)))
@@ -41,7 +45,7 @@ func BuildCastIntent(caster *gameobjects.Player, cursorX, cursorY float32) CastI
 		return intent
 	}
 
-	if caster.FacingRight {
+	if facingRight {
 		intent.DirectionX = 1
 		intent.DirectionY = 0
 	} else {
@@ -51,29 +55,54 @@ func BuildCastIntent(caster *gameobjects.Player, cursorX, cursorY float32) CastI
 	return intent
 }
 
-func ResolveTargets(caster *gameobjects.Player, intent CastIntent, spec gamedata.TargetingSpec, enemies []*gameobjects.Enemy, boss *gameobjects.Boss) []interface{} {
+func ResolveTargets(caster *gameobjects.Player, intent CastIntent, spec gamedata.TargetingSpec, enemies []*gameobjects.Enemy, boss *gameobjects.Boss) []core.Combatant {
+	return ResolveCombatantTargets(caster, intent, spec, CombatantsFrom(enemies, boss))
+}
+
+func ResolveCombatantTargets(caster *gameobjects.Player, intent CastIntent, spec gamedata.TargetingSpec, combatants []core.Combatant) []core.Combatant {
 	if caster == nil {
 		return nil
 	}
+	return ResolveCasterTargets(caster, intent, spec, combatants)
+}
 
+func ResolveCasterTargets(caster core.Combatant, intent CastIntent, spec gamedata.TargetingSpec, combatants []core.Combatant) []core.Combatant {
 	casterX, casterY := caster.Center()
-	candidates := gatherCandidates(casterX, casterY, enemies, boss)
+	candidates := gatherCandidates(casterX, casterY, combatants)
 
 	switch spec.Type {
 	case gamedata.TargetSelf:
-		return []interface{}{caster}
+		return []core.Combatant{caster}
 	case gamedata.TargetEnemy:
 		return resolveEnemyTargets(intent, spec, candidates)
 	case gamedata.TargetArea:
 		return resolveAreaTargets(casterX, casterY, intent, spec, candidates)
 	case gamedata.TargetDirection:
 		return resolveDirectionalTargets(casterX, casterY, intent, spec, candidates)
+	case gamedata.TargetAlly:
+		return resolveAllyTargets(caster.GetFaction(), spec, candidates)
 	default:
 		return nil
 	}
 }
 
-func resolveEnemyTargets(intent CastIntent, spec gamedata.TargetingSpec, candidates []targetCandidate) []interface{} {
+// CombatantsFrom lists enemies then the boss, keeping nil and dead slots so candidate order stays stable.
+func CombatantsFrom(enemies []*gameobjects.Enemy, boss *gameobjects.Boss) []core.Combatant {
+	combatants := make([]core.Combatant, 0, len(enemies)+1)
+	for _, enemy := range enemies {
+		if enemy == nil {
+			combatants = append(combatants, nil)
+			continue
+		}
+		combatants = append(combatants, enemy)
+	}
+	if boss != nil {
+		combatants = append(combatants, boss)
+	}
+	return combatants
+}
+
+func resolveEnemyTargets(intent CastIntent, spec gamedata.TargetingSpec, candidates []targetCandidate) []core.Combatant {
 	maxRange := spec.Range
 	if maxRange <= 0 {
 		maxRange = float32(math.MaxFloat32)
@@ -96,16 +125,55 @@ func resolveEnemyTargets(intent CastIntent, spec gamedata.TargetingSpec, candida
 	if singleTarget {
 		for _, candidate := range inRange {
 			if isPointOverTarget(candidate.Target, intent.CursorX, intent.CursorY) {
-				return []interface{}{candidate.Target}
+				return []core.Combatant{candidate.Target}
 			}
 		}
-		return []interface{}{inRange[0].Target}
+		return []core.Combatant{nearestHostileCandidate(inRange).Target}
 	}
 
 	return selectTargets(inRange, spec.MaxTargets)
 }
 
-func resolveAreaTargets(casterX, casterY float32, intent CastIntent, spec gamedata.TargetingSpec, candidates []targetCandidate) []interface{} {
+// nearestHostileCandidate skips neutral props when falling back to the nearest target, unless nothing else is in range.
+func nearestHostileCandidate(sorted []targetCandidate) targetCandidate {
+	for _, candidate := range sorted {
+		if candidate.Target.GetFaction() != core.FactionNeutral {
+			return candidate
+		}
+	}
+	return sorted[0]
+}
+
+func resolveAllyTargets(faction core.Faction, spec gamedata.TargetingSpec, candidates []targetCandidate) []core.Combatant {
+	maxRange2 := float32(math.MaxFloat32)
+	if spec.Range > 0 {
+		maxRange2 = spec.Range * spec.Range
+	}
+
+	allies := make([]targetCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.Target.GetFaction() != faction || candidate.Distance2 > maxRange2 {
+			continue
+		}
+		allies = append(allies, candidate)
+	}
+
+	sortCandidates(allies)
+	sort.SliceStable(allies, func(i, j int) bool {
+		return HealthRatio(allies[i].Target) < HealthRatio(allies[j].Target)
+	})
+	return selectTargets(allies, spec.MaxTargets)
+}
+
+func HealthRatio(target core.Combatant) float32 {
+	maxHP := target.GetMaxHP()
+	if maxHP <= 0 {
+		return 1
+	}
+	return float32(target.GetHP()) / float32(maxHP)
+}
+
+func resolveAreaTargets(casterX, casterY float32, intent CastIntent, spec gamedata.TargetingSpec, candidates []targetCandidate) []core.Combatant {
 	centerX := intent.CursorX
 	centerY := intent.CursorY
 	if spec.Range == 0 {
@@ -146,7 +214,7 @@ func resolveAreaTargets(casterX, casterY float32, intent CastIntent, spec gameda
 	return selectTargets(filtered, spec.MaxTargets)
 }
 
-func resolveDirectionalTargets(casterX, casterY float32, intent CastIntent, spec gamedata.TargetingSpec, candidates []targetCandidate) []interface{} {
+func resolveDirectionalTargets(casterX, casterY float32, intent CastIntent, spec gamedata.TargetingSpec, candidates []targetCandidate) []core.Combatant {
 	rangeLimit := spec.Range
 	if rangeLimit <= 0 {
 		return nil
@@ -221,40 +289,23 @@ func resolveDirectionalTargets(casterX, casterY float32, intent CastIntent, spec
 	return selectTargets(filtered, spec.MaxTargets)
 }
 
-func gatherCandidates(casterX, casterY float32, enemies []*gameobjects.Enemy, boss *gameobjects.Boss) []targetCandidate {
-	candidates := make([]targetCandidate, 0, len(enemies)+1)
-	order := 0
-	for _, enemy := range enemies {
-		if enemy == nil || !enemy.IsAlive() {
-			order++
+func gatherCandidates(casterX, casterY float32, combatants []core.Combatant) []targetCandidate {
+	candidates := make([]targetCandidate, 0, len(combatants))
+	for order, combatant := range combatants {
+		if combatant == nil || !combatant.IsAlive() {
 			continue
 		}
-		enemyX, enemyY := enemy.Center()
-		dx := enemyX - casterX
-		dy := enemyY - casterY
+		targetX, targetY := combatant.Center()
+		dx := targetX - casterX
+		dy := targetY - casterY
 		candidates = append(candidates, targetCandidate{
-			Target:    enemy,
-			CenterX:   enemyX,
-			CenterY:   enemyY,
+			Target:    combatant,
+			CenterX:   targetX,
+			CenterY:   targetY,
 			Distance2: dx*dx + dy*dy,
 			Order:     order,
 		})
-		order++
 	}
-
-	if boss != nil && boss.IsAlive() {
-		bossX, bossY := boss.Center()
-		dx := bossX - casterX
-		dy := bossY - casterY
-		candidates = append(candidates, targetCandidate{
-			Target:    boss,
-			CenterX:   bossX,
-			CenterY:   bossY,
-			Distance2: dx*dx + dy*dy,
-			Order:     order,
-		})
-	}
-
 	return candidates
 }
 
@@ -267,7 +318,7 @@ func sortCandidates(candidates []targetCandidate) {
 	})
 }
 
-func selectTargets(candidates []targetCandidate, maxTargets int) []interface{} {
+func selectTargets(candidates []targetCandidate, maxTargets int) []core.Combatant {
 	if len(candidates) == 0 {
 		return nil
 	}
@@ -277,22 +328,19 @@ func selectTargets(candidates []targetCandidate, maxTargets int) []interface{} {
 		limit = maxTargets
 	}
 
-	targets := make([]interface{}, 0, limit)
+	targets := make([]core.Combatant, 0, limit)
 	for i := 0; i < limit; i++ {
 		targets = append(targets, candidates[i].Target)
 	}
 	return targets
 }
 
-func isPointOverTarget(target interface{}, x, y float32) bool {
-	switch t := target.(type) {
-	case *gameobjects.Enemy:
-		return pointInAABB(x, y, t.PosX, t.PosY, t.Hitbox.Width, t.Hitbox.Height)
-	case *gameobjects.Boss:
-		return pointInAABB(x, y, t.PosX, t.PosY, t.Hitbox.Width, t.Hitbox.Height)
-	default:
+func isPointOverTarget(target core.Combatant, x, y float32) bool {
+	if target == nil {
 		return false
 	}
+	minX, minY, width, height := target.GetBounds()
+	return pointInAABB(x, y, minX, minY, width, height)
 }
 
 func pointInAABB(x, y, minX, minY, width, height float32) bool {
@@ -51,6 +51,30 @@ func TestResolveTargetsSingleTargetFallsBackToNearestAndStableOrder(t *testing.T
 	}
 }
 
+func TestResolveTargetsSingleTargetFallbackSkipsProps(t *testing.T) {
+	player := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
+	crate := gameobjects.NewProp(40, 30, gamedata.PropCrate)
+	enemy := testEnemy(140, 20, 30, 30)
+
+	intent := BuildCastIntent(player, 400, 400)
+	spec := gamedata.TargetingSpec{
+		Type:       gamedata.TargetEnemy,
+		Range:      200,
+		MaxTargets: 1,
+	}
+
+	targets := ResolveCombatantTargets(player, intent, spec, []core.Combatant{crate, enemy})
+	if len(targets) != 1 || targets[0] != enemy {
+		t.Fatalf("expected fallback to skip the nearer prop, got %v", targets)
+	}
+
+	intent = BuildCastIntent(player, crate.PosX+5, crate.PosY+5)
+	targets = ResolveCombatantTargets(player, intent, spec, []core.Combatant{crate, enemy})
+	if len(targets) != 1 || targets[0] != crate {
+		t.Fatalf("expected hovered prop to stay targetable")
+	}
+}
+
 func TestResolveTargetsArea(t *testing.T) {
 	player := gameobjects.NewPlayer(0, 0, gamedata.ClassTypeMelee)
 	enemyNear := testEnemy(120, 20, 30, 30)
@@ -161,3 +185,25 @@ func testEnemy(x, y, w, h float32) *gameobjects.Enemy {
 		},
 	}
 }
+
+func TestResolveCasterTargetsAllyPrefersMostHurtOfSameFaction(t *testing.T) {
+	healer := testEnemy(0, 0, 30, 30)
+	scratched := testEnemy(60, 0, 30, 30)
+	scratched.HP = scratched.MaxHP - 1
+	wounded := testEnemy(120, 0, 30, 30)
+	wounded.HP = wounded.MaxHP / 4
+	outOfRange := testEnemy(600, 0, 30, 30)
+	outOfRange.HP = 1
+	for _, enemy := range []*gameobjects.Enemy{healer, scratched, wounded, outOfRange} {
+		enemy.Faction = core.FactionEnemy
+	}
+	player := gameobjects.NewPlayer(20, 0, gamedata.ClassTypeMelee)
+	player.HP = 1
+
+	healerX, healerY := healer.Center()
+	spec := gamedata.TargetingSpec{Type: gamedata.TargetAlly, Range: 200, MaxTargets: 2}
+	targets := ResolveCasterTargets(healer, BuildCastIntentFrom(healerX, healerY, healerX, healerY, true), spec, []core.Combatant{player, healer, scratched, wounded, outOfRange})
+	if len(targets) != 2 || targets[0] != wounded || targets[1] != scratched {
+		t.Fatalf("expected the most hurt allies in range first, got %v", targets)
+	}
+}
@@ -0,0 +1,231 @@
+package systems
+
+import (
+	"math"
+	"sort"
+
+	"singlefantasy/app/world"
+)
+
+const (
+	DefaultSpatialCellSize float32 = 64
+	spatialConeEpsilon     float32 = 1e-4
+)
+
+type spatialCell struct {
+	X int
+	Y int
+}
+
+// SpatialHash is a uniform-grid broadphase. Entries are identified by their insertion index and
+// queries return indices in insertion order so callers keep deterministic iteration.
+type SpatialHash struct {
+	cellSize float32
+	cells    map[spatialCell][]int
+	bounds   []world.AABB
+	marks    []int
+	query    int
+}
+
+func NewSpatialHash(cellSize float32) *SpatialHash {
+	if cellSize <= 0 {
+		cellSize = DefaultSpatialCellSize
+	}
+	return &SpatialHash{
+		cellSize: cellSize,
+		cells:    map[spatialCell][]int{},
+		bounds:   []world.AABB{},
+		marks:    []int{},
+	}
+}
+
+func (h *SpatialHash) Clear() {
+	if h == nil {
+		return
+	}
+	for key, ids := range h.cells {
+		h.cells[key] = ids[:0]
+	}
+	h.bounds = h.bounds[:0]
+	h.marks = h.marks[:0]
+}
+
+func (h *SpatialHash) Len() int {
+	if h == nil {
+		return 0
+	}
+	return len(h.bounds)
+}
+
+func (h *SpatialHash) Insert(bounds world.AABB) int {
+	id := len(h.bounds)
+	h.bounds = append(h.bounds, bounds)
+	h.marks = append(h.marks, 0)
+
+	minCell, maxCell := h.cellRange(bounds)
+	for y := minCell.Y; y <= maxCell.Y; y++ {
+		for x := minCell.X; x <= maxCell.X; x++ {
+			key := spatialCell{X: x, Y: y}
+			h.cells[key] = append(h.cells[key], id)
+		}
+	}
+	return id
+}
+
+func (h *SpatialHash) Bounds(id int) world.AABB {
+	return h.bounds[id]
+}
+
+func (h *SpatialHash) QueryAABB(area world.AABB) []int {
+	return h.collect(area, func(bounds world.AABB) bool {
+		return aabbTouches(bounds, area)
+	})
+}
+
+func (h *SpatialHash) QueryCircle(x, y, radius float32) []int {
+	area := world.AABB{X: x - radius, Y: y - radius, Width: radius * 2, Height: radius * 2}
+	radius2 := radius * radius
+	return h.collect(area, func(bounds world.AABB) bool {
+		return distance2ToAABB(x, y, bounds) <= radius2
+	})
+}
+
+// QueryCone returns entries reaching into the circle of rangeLimit whose center lies inside the arc.
+func (h *SpatialHash) QueryCone(x, y, dirX, dirY, rangeLimit, halfArcDegrees float32) []int {
+	dirLen := float32(math.Sqrt(float64(dirX*dirX + dirY*dirY)))
+	if dirLen <= 0 || rangeLimit <= 0 {
+		return nil
+	}
+	dirX /= dirLen
+	dirY /= dirLen
+	minCos := float32(math.Cos(float64(halfArcDegrees) * math.Pi / 180))
+	area := world.AABB{X: x - rangeLimit, Y: y - rangeLimit, Width: rangeLimit * 2, Height: rangeLimit * 2}
+	range2 := rangeLimit * rangeLimit
+	return h.collect(area, func(bounds world.AABB) bool {
+		if distance2ToAABB(x, y, bounds) > range2 {
+			return false
+		}
+		if bounds.ContainsPoint(x, y) {
+			return true
+		}
+		dx := bounds.X + bounds.Width/2 - x
+		dy := bounds.Y + bounds.Height/2 - y
+		distance := float32(math.Sqrt(float64(dx*dx + dy*dy)))
+		if distance <= 0 {
+			return true
+		}
+		return (dx*dirX+dy*dirY)/distance >= minCos-spatialConeEpsilon
+	})
+}
+
+// QuerySegment returns entries whose bounds, grown by radius, are crossed by the segment.
+func (h *SpatialHash) QuerySegment(x0, y0, x1, y1, radius float32) []int {
+	area := world.AABB{
+		X:      minFloat32(x0, x1) - radius,
+		Y:      minFloat32(y0, y1) - radius,
+		Width:  absFloat32(x1-x0) + radius*2,
+		Height: absFloat32(y1-y0) + radius*2,
+	}
+	return h.collect(area, func(bounds world.AABB) bool {
+		grown := world.AABB{X: bounds.X - radius, Y: bounds.Y - radius, Width: bounds.Width + radius*2, Height: bounds.Height + radius*2}
+		return segmentIntersectsAABB(x0, y0, x1, y1, grown)
+	})
+}
+
+func (h *SpatialHash) collect(area world.AABB, accept func(bounds world.AABB) bool) []int {
+	if h == nil || len(h.bounds) == 0 {
+		return nil
+	}
+
+	h.query++
+	result := []int{}
+	minCell, maxCell := h.cellRange(area)
+	for y := minCell.Y; y <= maxCell.Y; y++ {
+		for x := minCell.X; x <= maxCell.X; x++ {
+			for _, id := range h.cells[spatialCell{X: x, Y: y}] {
+				if h.marks[id] == h.query {
+					continue
+				}
+				h.marks[id] = h.query
+				if accept(h.bounds[id]) {
+					result = append(result, id)
+				}
+			}
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+func (h *SpatialHash) cellRange(area world.AABB) (spatialCell, spatialCell) {
+	return h.cellAt(area.X, area.Y), h.cellAt(area.X+area.Width, area.Y+area.Height)
+}
+
+func (h *SpatialHash) cellAt(x, y float32) spatialCell {
+	return spatialCell{
+		X: int(math.Floor(float64(x / h.cellSize))),
+		Y: int(math.Floor(float64(y / h.cellSize))),
+	}
+}
+
+func aabbTouches(a, b world.AABB) bool {
+	return a.X <= b.X+b.Width &&
+		a.X+a.Width >= b.X &&
+		a.Y <= b.Y+b.Height &&
+		a.Y+a.Height >= b.Y
+}
+
+func distance2ToAABB(x, y float32, bounds world.AABB) float32 {
+	closestX := clamp(x, bounds.X, bounds.X+bounds.Width)
+	closestY := clamp(y, bounds.Y, bounds.Y+bounds.Height)
+	dx := x - closestX
+	dy := y - closestY
+	return dx*dx + dy*dy
+}
+
+func segmentIntersectsAABB(x0, y0, x1, y1 float32, bounds world.AABB) bool {
+	tMin := float32(0)
+	tMax := float32(1)
+	deltas := [2]float32{x1 - x0, y1 - y0}
+	origins := [2]float32{x0, y0}
+	mins := [2]float32{bounds.X, bounds.Y}
+	maxs := [2]float32{bounds.X + bounds.Width, bounds.Y + bounds.Height}
+
+	for axis := 0; axis < 2; axis++ {
+		if deltas[axis] == 0 {
+			if origins[axis] < mins[axis] || origins[axis] > maxs[axis] {
+				return false
+			}
+			continue
+		}
+		t0 := (mins[axis] - origins[axis]) / deltas[axis]
+		t1 := (maxs[axis] - origins[axis]) / deltas[axis]
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if t0 > tMin {
+			tMin = t0
+		}
+		if t1 < tMax {
+			tMax = t1
+		}
+		if tMin > tMax {
+			return false
+		}
+	}
+	return true
+}
+
+func minFloat32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func absFloat32(value float32) float32 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
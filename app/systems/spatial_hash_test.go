@@ -0,0 +1,109 @@
+//go:build raylib
+
+package systems
+
+import (
+	"math/rand"
+	"testing"
+
+	"singlefantasy/app/core"
+	"singlefantasy/app/gamedata"
+	"singlefantasy/app/gameobjects"
+	"singlefantasy/app/world"
+)
+
+func TestSpatialHashQueriesReturnInsertionOrder(t *testing.T) {
+	hash := NewSpatialHash(32)
+	near := hash.Insert(world.AABB{X: 10, Y: 10, Width: 10, Height: 10})
+	far := hash.Insert(world.AABB{X: 400, Y: 400, Width: 10, Height: 10})
+	wide := hash.Insert(world.AABB{X: -100, Y: 0, Width: 300, Height: 20})
+
+	if ids := hash.QueryCircle(15, 15, 5); len(ids) != 2 || ids[0] != near || ids[1] != wide {
+		t.Fatalf("expected near and wide entries in order, got %v", ids)
+	}
+	if ids := hash.QueryAABB(world.AABB{X: 390, Y: 390, Width: 30, Height: 30}); len(ids) != 1 || ids[0] != far {
+		t.Fatalf("expected only far entry, got %v", ids)
+	}
+	if ids := hash.QuerySegment(0, 200, 500, 420, 4); len(ids) != 0 {
+		t.Fatalf("expected segment to miss every entry, got %v", ids)
+	}
+	if ids := hash.QuerySegment(0, 300, 500, 420, 4); len(ids) != 1 || ids[0] != far {
+		t.Fatalf("expected segment to hit far entry, got %v", ids)
+	}
+	if ids := hash.QueryCone(0, 15, 1, 0, 50, 10); len(ids) != 2 {
+		t.Fatalf("expected cone to reach near and wide entries, got %v", ids)
+	}
+
+	hash.Clear()
+	if hash.Len() != 0 || len(hash.QueryCircle(15, 15, 500)) != 0 {
+		t.Fatalf("expected cleared hash to be empty")
+	}
+}
+
+func TestResolveTargetsInSpaceMatchesLinearScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	player := gameobjects.NewPlayer(500, 500, gamedata.ClassTypeMelee)
+	enemies := make([]*gameobjects.Enemy, 0, 150)
+	for i := 0; i < 150; i++ {
+		size := 12 + rng.Float32()*30
+		enemies = append(enemies, testEnemy(rng.Float32()*1000, rng.Float32()*1000, size, size))
+	}
+	enemies[3].Alive = false
+
+	space := NewCombatSpace()
+	space.Rebuild(CombatantsFrom(enemies, nil), nil)
+
+	specs := []gamedata.TargetingSpec{
+		{Type: gamedata.TargetEnemy, Range: 180, MaxTargets: 1},
+		{Type: gamedata.TargetEnemy, Range: 250, MaxTargets: 5},
+		{Type: gamedata.TargetArea, Range: 200, Radius: 90, MaxTargets: 0},
+		{Type: gamedata.TargetArea, Range: 0, Radius: 120, MaxTargets: 4},
+		{Type: gamedata.TargetDirection, Range: 300, DirectionalArcDegrees: 70},
+		{Type: gamedata.TargetDirection, Range: 400, DirectionalLineWidth: 40},
+	}
+	for i := 0; i < 40; i++ {
+		intent := BuildCastIntent(player, rng.Float32()*1000, rng.Float32()*1000)
+		for _, spec := range specs {
+			linear := ResolveTargets(player, intent, spec, enemies, nil)
+			indexed := ResolveTargetsInSpace(player, intent, spec, space)
+			if !sameCombatants(linear, indexed) {
+				t.Fatalf("expected indexed targets to match linear scan for %+v, got %d vs %d", spec, len(indexed), len(linear))
+			}
+		}
+	}
+}
+
+func sameCombatants(a, b []core.Combatant) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTargetCandidatesAllyKeepsLivingFactionMatesInRange(t *testing.T) {
+	healer := gameobjects.NewEnemy(100, 100, false)
+	near := gameobjects.NewEnemy(160, 100, false)
+	far := gameobjects.NewEnemy(700, 100, false)
+	dead := gameobjects.NewEnemy(120, 120, false)
+	dead.Alive = false
+	prop := gameobjects.NewProp(110, 140, gamedata.PropCrate)
+
+	space := NewCombatSpace()
+	space.Rebuild([]core.Combatant{healer, near, far, dead, prop}, nil)
+	spec := gamedata.TargetingSpec{Type: gamedata.TargetAlly, Range: 200, MaxTargets: 1}
+
+	candidates := space.TargetCandidates(healer, BuildCastIntentFrom(0, 0, 0, 0, true), spec)
+	if len(candidates) != 2 || candidates[0] != core.Combatant(healer) || candidates[1] != core.Combatant(near) {
+		t.Fatalf("expected the healer and its nearby living ally, got %d candidates", len(candidates))
+	}
+
+	spec.Range = 0
+	if got := space.TargetCandidates(healer, BuildCastIntentFrom(0, 0, 0, 0, true), spec); len(got) != 3 {
+		t.Fatalf("expected unlimited range to include the distant ally, got %d", len(got))
+	}
+}
@@ -436,12 +436,18 @@ func buildRoomFromTemplate(template *RoomTemplate, rotation int, x, y float32, p
 
 	room.Obstacles = templateToObstacles(template, room.X, room.Y)
 	room.Doors = templateToDoors(template, room.X, room.Y)
+	room.Props = templateToPropRefs(template, room.X, room.Y)
+	room.Hazards = templateToHazardField(template, room.X, room.Y)
+	room.Traps = templateToTraps(template, room.X, room.Y)
 	room.Enemies = buildEnemyRefsFromTemplate(template, room, rng, progressionIndex)
 	room.Tiles = cloneTileGrid(template.Tiles)
 
 	if room.Type == RoomTypeEvent {
-		room.EventDuration = DungeonEventDuration
-		room.EventTimeLeft = room.EventDuration
+		room.Event = templateToRoomEvent(template, room, rng, progressionIndex)
+		if room.Event.Config.UsesTimer() {
+			room.EventDuration = room.Event.Config.Duration
+			room.EventTimeLeft = room.EventDuration
+		}
 	}
 
 	for _, marker := range template.SpawnMarkers {
@@ -461,7 +467,7 @@ func templateToObstacles(template *RoomTemplate, originX, originY float32) []AAB
 	obstacles := make([]AABB, 0, template.Width*template.Height/3)
 	for y, row := range template.Tiles {
 		for x, tile := range row {
-			if tile != TileWall && tile != TileHazard && tile != TileTrap {
+			if tile != TileWall {
 				continue
 			}
 			obstacles = append(obstacles, AABB{
@@ -627,6 +633,59 @@ func (d *Dungeon) GetCurrentRoom() *Room {
 	return nil
 }
 
+// RebuildCurrentRoom re-instantiates the current room from its template in registry, keeping its
+// placement, rotation, door links and completion state.
+func (d *Dungeon) RebuildCurrentRoom(registry *RoomTemplateRegistry) (*Room, error) {
+	current := d.GetCurrentRoom()
+	if current == nil {
+		return nil, fmt.Errorf("dungeon has no current room")
+	}
+	if registry == nil {
+		return nil, fmt.Errorf("room template registry is nil")
+	}
+	template := registry.GetByID(current.TemplateID)
+	if template == nil {
+		return nil, fmt.Errorf("room template %q not found", current.TemplateID)
+	}
+	if current.Rotation != 0 {
+		rotated, err := RotateRoomTemplate(template, current.Rotation)
+		if err != nil {
+			return nil, fmt.Errorf("rotate room template %q: %w", current.TemplateID, err)
+		}
+		template = rotated
+	}
+
+	rng := rand.New(rand.NewSource(d.Seed + int64(d.CurrentRoom)))
+	room := buildRoomFromTemplate(template, current.Rotation, current.X, current.Y, current.ProgressionIndex, rng)
+	if room == nil {
+		return nil, fmt.Errorf("failed to instantiate room from template %q", current.TemplateID)
+	}
+	room.Completed = current.Completed
+	for _, door := range room.Doors {
+		previous := findDoorByMarker(current, DoorMarker{X: door.MarkerX, Y: door.MarkerY, Direction: door.Direction})
+		if previous == nil {
+			previous = findLinkedDoor(current, door.Direction)
+		}
+		if previous == nil {
+			continue
+		}
+		door.Locked = previous.Locked
+		door.TargetRoomIndex = previous.TargetRoomIndex
+	}
+
+	d.Rooms[d.CurrentRoom] = room
+	return room, nil
+}
+
+func findLinkedDoor(room *Room, direction DoorDirection) *Door {
+	for _, door := range room.Doors {
+		if door != nil && door.Direction == direction && door.TargetRoomIndex >= 0 {
+			return door
+		}
+	}
+	return nil
+}
+
 func (d *Dungeon) GetWorldBounds() (float32, float32) {
 	maxX := float32(0)
 	maxY := float32(0)
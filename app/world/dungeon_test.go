@@ -29,7 +29,10 @@ func TestDungeonGenerationInvariants(t *testing.T) {
 
 		if room.Type == RoomTypeEvent {
 			eventCount++
-			if room.EventTimeLeft <= 0 {
+			if room.Event == nil {
+				t.Fatalf("event room %d missing event", index)
+			}
+			if room.Event.Config.UsesTimer() && room.EventTimeLeft <= 0 {
 				t.Fatalf("event room %d missing timer", index)
 			}
 		}
@@ -138,3 +141,42 @@ func TestDungeonBossRoomHasBossSpawnMarkerAndWestDoor(t *testing.T) {
 		t.Fatalf("expected boss room to have at least one west entry door")
 	}
 }
+
+func TestRebuildCurrentRoomKeepsPlacementAndDoorLinks(t *testing.T) {
+	dungeon := NewDungeon()
+	registry, err := LoadRoomTemplateRegistry(DefaultRoomsRoot)
+	if err != nil {
+		t.Fatalf("load registry: %v", err)
+	}
+	dungeon.CurrentRoom = 1
+	before := dungeon.GetCurrentRoom()
+	before.Completed = true
+	linked := findLinkedDoor(before, DoorDirectionEast)
+	if linked == nil {
+		t.Fatalf("expected room 1 to link east")
+	}
+	linked.Locked = false
+
+	rebuilt, err := dungeon.RebuildCurrentRoom(registry)
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if rebuilt == before || dungeon.GetCurrentRoom() != rebuilt {
+		t.Fatalf("expected dungeon to hold a fresh room instance")
+	}
+	if rebuilt.X != before.X || rebuilt.Y != before.Y || rebuilt.Rotation != before.Rotation || rebuilt.TemplateID != before.TemplateID {
+		t.Fatalf("expected rebuilt room to keep placement")
+	}
+	if !rebuilt.Completed {
+		t.Fatalf("expected completion state to carry over")
+	}
+	door := findLinkedDoor(rebuilt, DoorDirectionEast)
+	if door == nil || door.TargetRoomIndex != linked.TargetRoomIndex || door.Locked {
+		t.Fatalf("expected east door link and lock state to carry over, got %+v", door)
+	}
+
+	dungeon.Rooms[1].TemplateID = "missing_template"
+	if _, err := dungeon.RebuildCurrentRoom(registry); err == nil {
+		t.Fatalf("expected missing template to fail")
+	}
+}
@@ -0,0 +1,51 @@
+package world
+
+import "math"
+
+// TileLineOfSight walks the template tile grid between two points and reports whether no wall tile is crossed.
+// Seeing past a corner where two wall tiles touch diagonally is blocked. Rooms without tiles are always clear.
+func (r *Room) TileLineOfSight(x0, y0, x1, y1 float32) bool {
+	if r == nil || len(r.Tiles) == 0 {
+		return true
+	}
+	col := int(math.Floor(float64((x0 - r.X) / RoomTemplateTileSize)))
+	row := int(math.Floor(float64((y0 - r.Y) / RoomTemplateTileSize)))
+	endCol := int(math.Floor(float64((x1 - r.X) / RoomTemplateTileSize)))
+	endRow := int(math.Floor(float64((y1 - r.Y) / RoomTemplateTileSize)))
+	if r.tileBlocksSight(col, row) {
+		return false
+	}
+
+	stepX, tMaxX, tDeltaX := gridTraversalAxis(x0, x1-x0, r.X, RoomTemplateTileSize, col)
+	stepY, tMaxY, tDeltaY := gridTraversalAxis(y0, y1-y0, r.Y, RoomTemplateTileSize, row)
+	for steps := absInt(endCol-col) + absInt(endRow-row); steps > 0 && (col != endCol || row != endRow); steps-- {
+		switch {
+		case tMaxX < tMaxY:
+			col += stepX
+			tMaxX += tDeltaX
+		case tMaxY < tMaxX:
+			row += stepY
+			tMaxY += tDeltaY
+		default:
+			if r.tileBlocksSight(col+stepX, row) && r.tileBlocksSight(col, row+stepY) {
+				return false
+			}
+			col += stepX
+			row += stepY
+			tMaxX += tDeltaX
+			tMaxY += tDeltaY
+			steps--
+		}
+		if r.tileBlocksSight(col, row) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Room) tileBlocksSight(col, row int) bool {
+	if row < 0 || row >= len(r.Tiles) || col < 0 || col >= len(r.Tiles[row]) {
+		return false
+	}
+	return r.Tiles[row][col] == TileWall
+}
@@ -0,0 +1,39 @@
+package world
+
+import "testing"
+
+func TestRoomTileLineOfSight(t *testing.T) {
+	room := &Room{X: 96, Y: 0, Width: 4 * RoomTemplateTileSize, Height: 3 * RoomTemplateTileSize}
+	room.Tiles = [][]TileType{
+		{TileFloor, TileFloor, TileWall, TileFloor},
+		{TileFloor, TileWall, TileFloor, TileFloor},
+		{TileFloor, TileFloor, TileFloor, TileFloor},
+	}
+	tileCenter := func(col, row int) (float32, float32) {
+		return room.X + (float32(col)+0.5)*RoomTemplateTileSize, room.Y + (float32(row)+0.5)*RoomTemplateTileSize
+	}
+
+	cases := []struct {
+		name     string
+		from, to [2]int
+		want     bool
+	}{
+		{name: "open row", from: [2]int{0, 2}, to: [2]int{3, 2}, want: true},
+		{name: "through wall", from: [2]int{0, 1}, to: [2]int{3, 1}, want: false},
+		{name: "diagonal wall corner", from: [2]int{1, 0}, to: [2]int{2, 1}, want: false},
+		{name: "past single corner", from: [2]int{0, 0}, to: [2]int{1, 0}, want: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			x0, y0 := tileCenter(tc.from[0], tc.from[1])
+			x1, y1 := tileCenter(tc.to[0], tc.to[1])
+			if got := room.TileLineOfSight(x0, y0, x1, y1); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if !(&Room{Width: 100, Height: 100}).TileLineOfSight(0, 0, 90, 90) {
+		t.Fatalf("expected rooms without tiles to be clear")
+	}
+}
@@ -0,0 +1,331 @@
+package world
+
+import (
+	"container/heap"
+	"math"
+)
+
+const NavCellSize = 32
+
+type NavPoint struct {
+	X float32
+	Y float32
+}
+
+// NavGrid is a walkability grid over a room for actors of one clearance (half their body size).
+type NavGrid struct {
+	OriginX   float32
+	OriginY   float32
+	Cols      int
+	Rows      int
+	Clearance float32
+	blocked   []bool
+}
+
+func NewNavGrid(room *Room, clearance float32, blockers ...AABB) *NavGrid {
+	if room == nil || room.Width <= 0 || room.Height <= 0 {
+		return nil
+	}
+	grid := &NavGrid{
+		OriginX:   room.X,
+		OriginY:   room.Y,
+		Cols:      int(math.Ceil(float64(room.Width / NavCellSize))),
+		Rows:      int(math.Ceil(float64(room.Height / NavCellSize))),
+		Clearance: clearance,
+	}
+	grid.blocked = make([]bool, grid.Cols*grid.Rows)
+	for row := 0; row < grid.Rows; row++ {
+		for col := 0; col < grid.Cols; col++ {
+			grid.blocked[row*grid.Cols+col] = !navCellStandable(room, grid.cellBounds(col, row), clearance, blockers)
+		}
+	}
+	return grid
+}
+
+func navCellStandable(room *Room, cell AABB, clearance float32, blockers []AABB) bool {
+	reach := AABB{
+		X:      cell.X - clearance,
+		Y:      cell.Y - clearance,
+		Width:  cell.Width + clearance*2,
+		Height: cell.Height + clearance*2,
+	}
+	if reach.X < room.X || reach.Y < room.Y || reach.X+reach.Width > room.X+room.Width || reach.Y+reach.Height > room.Y+room.Height {
+		return false
+	}
+	if roomTileAt(room, cell.X+cell.Width/2, cell.Y+cell.Height/2) == TileWall {
+		return false
+	}
+	return !overlapsAny(reach, room.Obstacles) && !overlapsAny(reach, blockers)
+}
+
+func roomTileAt(room *Room, x, y float32) TileType {
+	if len(room.Tiles) == 0 {
+		return TileFloor
+	}
+	row := int((y - room.Y) / RoomTemplateTileSize)
+	col := int((x - room.X) / RoomTemplateTileSize)
+	if row < 0 || row >= len(room.Tiles) || col < 0 || col >= len(room.Tiles[row]) {
+		return TileWall
+	}
+	return room.Tiles[row][col]
+}
+
+func (g *NavGrid) cellBounds(col, row int) AABB {
+	return AABB{
+		X:      g.OriginX + float32(col)*NavCellSize,
+		Y:      g.OriginY + float32(row)*NavCellSize,
+		Width:  NavCellSize,
+		Height: NavCellSize,
+	}
+}
+
+func (g *NavGrid) cellCenter(col, row int) NavPoint {
+	return NavPoint{
+		X: g.OriginX + (float32(col)+0.5)*NavCellSize,
+		Y: g.OriginY + (float32(row)+0.5)*NavCellSize,
+	}
+}
+
+func (g *NavGrid) cellAt(x, y float32) (int, int, bool) {
+	col := int(math.Floor(float64((x - g.OriginX) / NavCellSize)))
+	row := int(math.Floor(float64((y - g.OriginY) / NavCellSize)))
+	return col, row, col >= 0 && col < g.Cols && row >= 0 && row < g.Rows
+}
+
+func (g *NavGrid) Walkable(col, row int) bool {
+	return col >= 0 && col < g.Cols && row >= 0 && row < g.Rows && !g.blocked[row*g.Cols+col]
+}
+
+func (g *NavGrid) WalkableAt(x, y float32) bool {
+	col, row, ok := g.cellAt(x, y)
+	return ok && g.Walkable(col, row)
+}
+
+func (g *NavGrid) LineWalkable(x0, y0, x1, y1 float32) bool {
+	col, row, ok := g.cellAt(x0, y0)
+	endCol, endRow, endOK := g.cellAt(x1, y1)
+	if !ok || !endOK || !g.Walkable(col, row) {
+		return false
+	}
+
+	dx := x1 - x0
+	dy := y1 - y0
+	stepX, tMaxX, tDeltaX := gridTraversalAxis(x0, dx, g.OriginX, NavCellSize, col)
+	stepY, tMaxY, tDeltaY := gridTraversalAxis(y0, dy, g.OriginY, NavCellSize, row)
+
+	for steps := absInt(endCol-col) + absInt(endRow-row); steps > 0 && (col != endCol || row != endRow); steps-- {
+		switch {
+		case tMaxX < tMaxY:
+			col += stepX
+			tMaxX += tDeltaX
+		case tMaxY < tMaxX:
+			row += stepY
+			tMaxY += tDeltaY
+		default:
+			if !g.Walkable(col+stepX, row) || !g.Walkable(col, row+stepY) {
+				return false
+			}
+			col += stepX
+			row += stepY
+			tMaxX += tDeltaX
+			tMaxY += tDeltaY
+			steps--
+		}
+		if !g.Walkable(col, row) {
+			return false
+		}
+	}
+	return true
+}
+
+func gridTraversalAxis(start, delta, origin, cellSize float32, cell int) (int, float32, float32) {
+	if delta == 0 {
+		return 0, float32(math.Inf(1)), float32(math.Inf(1))
+	}
+	boundary := origin + float32(cell)*cellSize
+	step := -1
+	if delta > 0 {
+		step = 1
+		boundary += cellSize
+	}
+	return step, (boundary - start) / delta, cellSize / float32(math.Abs(float64(delta)))
+}
+
+// FindPath runs A* between two points and returns smoothed waypoints ending at the goal.
+func (g *NavGrid) FindPath(startX, startY, goalX, goalY float32) ([]NavPoint, bool) {
+	if g == nil {
+		return nil, false
+	}
+	start, ok := g.nearestWalkableCell(startX, startY)
+	if !ok {
+		return nil, false
+	}
+	goal, ok := g.nearestWalkableCell(goalX, goalY)
+	if !ok {
+		return nil, false
+	}
+
+	goalPoint := g.cellCenter(goal%g.Cols, goal/g.Cols)
+	if g.WalkableAt(goalX, goalY) {
+		goalPoint = NavPoint{X: goalX, Y: goalY}
+	}
+	if start == goal {
+		return []NavPoint{goalPoint}, true
+	}
+
+	cells, ok := g.searchCells(start, goal)
+	if !ok {
+		return nil, false
+	}
+	points := make([]NavPoint, 0, len(cells)+1)
+	points = append(points, NavPoint{X: startX, Y: startY})
+	if !g.WalkableAt(startX, startY) {
+		points[0] = g.cellCenter(start%g.Cols, start/g.Cols)
+	}
+	for _, cell := range cells[1 : len(cells)-1] {
+		points = append(points, g.cellCenter(cell%g.Cols, cell/g.Cols))
+	}
+	points = append(points, goalPoint)
+	return g.smoothPath(points), true
+}
+
+func (g *NavGrid) smoothPath(points []NavPoint) []NavPoint {
+	smoothed := make([]NavPoint, 0, len(points))
+	anchor := 0
+	for anchor < len(points)-1 {
+		next := anchor + 1
+		for candidate := len(points) - 1; candidate > next; candidate-- {
+			if g.LineWalkable(points[anchor].X, points[anchor].Y, points[candidate].X, points[candidate].Y) {
+				next = candidate
+				break
+			}
+		}
+		smoothed = append(smoothed, points[next])
+		anchor = next
+	}
+	return smoothed
+}
+
+var navNeighborOffsets = [8][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+
+func (g *NavGrid) searchCells(start, goal int) ([]int, bool) {
+	cost := make([]float32, len(g.blocked))
+	cameFrom := make([]int, len(g.blocked))
+	closed := make([]bool, len(g.blocked))
+	for i := range cost {
+		cost[i] = float32(math.Inf(1))
+		cameFrom[i] = -1
+	}
+	goalCol, goalRow := goal%g.Cols, goal/g.Cols
+
+	open := &navOpenSet{}
+	cost[start] = 0
+	heap.Push(open, navOpenCell{index: start, priority: navHeuristic(start%g.Cols, start/g.Cols, goalCol, goalRow)})
+	for open.Len() > 0 {
+		current := heap.Pop(open).(navOpenCell).index
+		if current == goal {
+			return g.reconstructCells(cameFrom, goal), true
+		}
+		if closed[current] {
+			continue
+		}
+		closed[current] = true
+
+		col, row := current%g.Cols, current/g.Cols
+		for _, offset := range navNeighborOffsets {
+			nextCol, nextRow := col+offset[0], row+offset[1]
+			if !g.Walkable(nextCol, nextRow) {
+				continue
+			}
+			step := float32(1)
+			if offset[0] != 0 && offset[1] != 0 {
+				if !g.Walkable(col+offset[0], row) || !g.Walkable(col, row+offset[1]) {
+					continue
+				}
+				step = math.Sqrt2
+			}
+			next := nextRow*g.Cols + nextCol
+			nextCost := cost[current] + step
+			if closed[next] || nextCost >= cost[next] {
+				continue
+			}
+			cost[next] = nextCost
+			cameFrom[next] = current
+			heap.Push(open, navOpenCell{index: next, priority: nextCost + navHeuristic(nextCol, nextRow, goalCol, goalRow)})
+		}
+	}
+	return nil, false
+}
+
+func (g *NavGrid) reconstructCells(cameFrom []int, goal int) []int {
+	cells := []int{}
+	for cell := goal; cell != -1; cell = cameFrom[cell] {
+		cells = append(cells, cell)
+	}
+	for i, j := 0, len(cells)-1; i < j; i, j = i+1, j-1 {
+		cells[i], cells[j] = cells[j], cells[i]
+	}
+	return cells
+}
+
+func navHeuristic(col, row, goalCol, goalRow int) float32 {
+	dx := float32(absInt(col - goalCol))
+	dy := float32(absInt(row - goalRow))
+	return dx + dy + (math.Sqrt2-2)*min(dx, dy)
+}
+
+func (g *NavGrid) nearestWalkableCell(x, y float32) (int, bool) {
+	col, row, _ := g.cellAt(x, y)
+	col = max(0, min(g.Cols-1, col))
+	row = max(0, min(g.Rows-1, row))
+	if g.Walkable(col, row) {
+		return row*g.Cols + col, true
+	}
+
+	for radius := 1; radius < max(g.Cols, g.Rows); radius++ {
+		best := -1
+		bestDistance := float32(math.Inf(1))
+		for ringRow := row - radius; ringRow <= row+radius; ringRow++ {
+			for ringCol := col - radius; ringCol <= col+radius; ringCol++ {
+				if absInt(ringCol-col) != radius && absInt(ringRow-row) != radius {
+					continue
+				}
+				if !g.Walkable(ringCol, ringRow) {
+					continue
+				}
+				center := g.cellCenter(ringCol, ringRow)
+				distance := (center.X-x)*(center.X-x) + (center.Y-y)*(center.Y-y)
+				if distance < bestDistance {
+					best = ringRow*g.Cols + ringCol
+					bestDistance = distance
+				}
+			}
+		}
+		if best >= 0 {
+			return best, true
+		}
+	}
+	return 0, false
+}
+
+type navOpenCell struct {
+	index    int
+	priority float32
+}
+
+type navOpenSet []navOpenCell
+
+func (s navOpenSet) Len() int           { return len(s) }
+func (s navOpenSet) Less(i, j int) bool { return s[i].priority < s[j].priority }
+func (s navOpenSet) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func (s *navOpenSet) Push(value any) {
+	*s = append(*s, value.(navOpenCell))
+}
+
+func (s *navOpenSet) Pop() any {
+	old := *s
+	last := old[len(old)-1]
+	*s = old[:len(old)-1]
+	return last
+}
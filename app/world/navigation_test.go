@@ -0,0 +1,70 @@
+package world
+
+import "testing"
+
+func newNavTestRoom() *Room {
+	return &Room{
+		Width:     640,
+		Height:    480,
+		Obstacles: []AABB{{X: 288, Y: 0, Width: 64, Height: 384}},
+	}
+}
+
+func TestNavGridFindPathRoutesAroundObstacle(t *testing.T) {
+	grid := NewNavGrid(newNavTestRoom(), 15)
+	if grid.LineWalkable(100, 100, 540, 100) {
+		t.Fatalf("expected the wall to block the straight line")
+	}
+
+	path, ok := grid.FindPath(100, 100, 540, 100)
+	if !ok || len(path) < 2 {
+		t.Fatalf("expected a multi-waypoint path around the wall, got %+v", path)
+	}
+	if last := path[len(path)-1]; last.X != 540 || last.Y != 100 {
+		t.Fatalf("expected path to end at the goal, got %+v", last)
+	}
+
+	prevX, prevY := float32(100), float32(100)
+	for _, point := range path {
+		if !grid.LineWalkable(prevX, prevY, point.X, point.Y) {
+			t.Fatalf("expected every path segment to be walkable, blocked at %+v in %+v", point, path)
+		}
+		prevX, prevY = point.X, point.Y
+	}
+	if path[0].Y <= 384 {
+		t.Fatalf("expected the first waypoint to go below the wall, got %+v", path[0])
+	}
+}
+
+func TestNavGridFindPathSmoothsOpenRoomToGoal(t *testing.T) {
+	grid := NewNavGrid(&Room{Width: 640, Height: 480}, 15)
+	path, ok := grid.FindPath(60, 60, 580, 420)
+	if !ok || len(path) != 1 || path[0].X != 580 || path[0].Y != 420 {
+		t.Fatalf("expected a straight smoothed path, got %+v", path)
+	}
+}
+
+func TestNavGridUsesRoomTilesAndSnapsBlockedGoal(t *testing.T) {
+	room := &Room{Width: 4 * RoomTemplateTileSize, Height: 3 * RoomTemplateTileSize}
+	room.Tiles = [][]TileType{
+		{TileFloor, TileWall, TileFloor, TileFloor},
+		{TileFloor, TileWall, TileFloor, TileFloor},
+		{TileFloor, TileWall, TileFloor, TileFloor},
+	}
+	grid := NewNavGrid(room, 15)
+	if _, ok := grid.FindPath(40, 40, 300, 40); ok {
+		t.Fatalf("expected a full wall column to split the room")
+	}
+
+	room.Tiles[2][1] = TileDoor
+	room.Obstacles = []AABB{{X: 288, Y: 0, Width: 96, Height: 96}}
+	grid = NewNavGrid(room, 15)
+	path, ok := grid.FindPath(40, 40, 330, 40)
+	if !ok {
+		t.Fatalf("expected a route through the opened tile")
+	}
+	last := path[len(path)-1]
+	if !grid.WalkableAt(last.X, last.Y) || room.Obstacles[0].ContainsPoint(last.X, last.Y) {
+		t.Fatalf("expected a goal inside an obstacle to snap to a walkable cell, got %+v", last)
+	}
+}
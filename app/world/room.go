@@ -165,6 +165,10 @@ type Room struct {
 	Enemies          []*EnemyRef
 	Obstacles        []AABB
 	Doors            []*Door
+	Props            []*PropRef
+	Hazards          *HazardField
+	Traps            []*Trap
+	Event            *RoomEvent
 	Tiles            [][]TileType
 	TemplateID       string
 	Biome            string
@@ -179,6 +183,12 @@ type Room struct {
 	Completed        bool
 }
 
+type PropRef struct {
+	X    float32
+	Y    float32
+	Type gamedata.PropType
+}
+
 type EnemyRef struct {
 	X             float32
 	Y             float32
@@ -237,6 +247,7 @@ func NewRoom(x, y float32, roomType RoomType, progressionIndex int) *Room {
 	if roomType == RoomTypeEvent {
 		room.EventDuration = 12
 		room.EventTimeLeft = room.EventDuration
+		room.Event = newSurviveRoomEvent(room, room.EventDuration)
 	}
 
 	return room
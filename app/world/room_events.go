@@ -0,0 +1,274 @@
+package world
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"singlefantasy/app/gamedata"
+)
+
+type EventKind string
+
+const (
+	EventKindSurvive       EventKind = "survive"
+	EventKindHealingShrine EventKind = "healing_shrine"
+	EventKindAltar         EventKind = "altar"
+	EventKindCapturePoint  EventKind = "capture_point"
+	EventKindDefendTotem   EventKind = "defend_totem"
+)
+
+const eventWaveSpawnSpacing float32 = 44
+
+// EventConfig describes what an event room asks of the player. Unused fields are zero for kinds that ignore them.
+type EventConfig struct {
+	Kind           EventKind
+	Duration       float32
+	Radius         float32
+	HealPercent    float32
+	BlessingChance float32
+	StatAmount     int
+	Waves          int
+	WaveSize       int
+	WaveInterval   float32
+	TotemHP        int
+	RewardXP       int
+}
+
+var eventPresets = map[EventKind]EventConfig{
+	EventKindSurvive: {
+		Kind:     EventKindSurvive,
+		Duration: DungeonEventDuration,
+	},
+	EventKindHealingShrine: {
+		Kind:        EventKindHealingShrine,
+		Radius:      110,
+		HealPercent: 0.5,
+	},
+	EventKindAltar: {
+		Kind:           EventKindAltar,
+		Radius:         110,
+		BlessingChance: 0.7,
+		StatAmount:     3,
+	},
+	EventKindCapturePoint: {
+		Kind:     EventKindCapturePoint,
+		Duration: 8,
+		Radius:   140,
+	},
+	EventKindDefendTotem: {
+		Kind:         EventKindDefendTotem,
+		Radius:       260,
+		Waves:        3,
+		WaveSize:     3,
+		WaveInterval: 8,
+		TotemHP:      200,
+		RewardXP:     60,
+	},
+}
+
+// DefaultEventConfig returns the timed survival event used by event rooms without an event block.
+func DefaultEventConfig() EventConfig {
+	return eventPresets[EventKindSurvive]
+}
+
+// UsesTimer reports whether the kind completes when the room's EventTimeLeft runs out.
+func (c EventConfig) UsesTimer() bool {
+	return c.Kind == EventKindSurvive || c.Kind == EventKindCapturePoint
+}
+
+// NeedsMarker reports whether the kind is anchored to the template's R marker.
+func (c EventConfig) NeedsMarker() bool {
+	return c.Kind != EventKindSurvive
+}
+
+type roomMetaEvent struct {
+	Kind           string  `json:"kind"`
+	Duration       float32 `json:"duration"`
+	Radius         float32 `json:"radius"`
+	HealPercent    float32 `json:"heal_percent"`
+	BlessingChance float32 `json:"blessing_chance"`
+	StatAmount     int     `json:"stat_amount"`
+	Waves          int     `json:"waves"`
+	WaveSize       int     `json:"wave_size"`
+	WaveInterval   float32 `json:"wave_interval"`
+	TotemHP        int     `json:"totem_hp"`
+	RewardXP       int     `json:"reward_xp"`
+}
+
+// resolveEventConfig starts from the survive default (or the named kind) and applies the non-zero meta overrides.
+func resolveEventConfig(meta *roomMetaEvent) (EventConfig, error) {
+	config := DefaultEventConfig()
+	if meta == nil {
+		return config, nil
+	}
+	if kind := strings.ToLower(strings.TrimSpace(meta.Kind)); kind != "" {
+		preset, ok := eventPresets[EventKind(kind)]
+		if !ok {
+			return EventConfig{}, fmt.Errorf("unsupported event kind %q", meta.Kind)
+		}
+		config = preset
+	}
+	if meta.Duration < 0 || meta.Radius < 0 || meta.StatAmount < 0 || meta.Waves < 0 || meta.WaveSize < 0 || meta.WaveInterval < 0 || meta.TotemHP < 0 || meta.RewardXP < 0 {
+		return EventConfig{}, fmt.Errorf("event values must be >= 0")
+	}
+	if meta.HealPercent < 0 || meta.HealPercent > 1 || meta.BlessingChance < 0 || meta.BlessingChance > 1 {
+		return EventConfig{}, fmt.Errorf("event heal_percent and blessing_chance must be within [0,1]")
+	}
+	if meta.Duration > 0 {
+		config.Duration = meta.Duration
+	}
+	if meta.Radius > 0 {
+		config.Radius = meta.Radius
+	}
+	if meta.HealPercent > 0 {
+		config.HealPercent = meta.HealPercent
+	}
+	if meta.BlessingChance > 0 {
+		config.BlessingChance = meta.BlessingChance
+	}
+	if meta.StatAmount > 0 {
+		config.StatAmount = meta.StatAmount
+	}
+	if meta.Waves > 0 {
+		config.Waves = meta.Waves
+	}
+	if meta.WaveSize > 0 {
+		config.WaveSize = meta.WaveSize
+	}
+	if meta.WaveInterval > 0 {
+		config.WaveInterval = meta.WaveInterval
+	}
+	if meta.TotemHP > 0 {
+		config.TotemHP = meta.TotemHP
+	}
+	if meta.RewardXP > 0 {
+		config.RewardXP = meta.RewardXP
+	}
+	return config, nil
+}
+
+// RoomEvent is an event room's resolved config anchored at its R marker (the room center when the template has none).
+// Waves holds the pre-rolled enemies for defend_totem events, one slice per wave.
+type RoomEvent struct {
+	Config EventConfig
+	X      float32
+	Y      float32
+	Waves  [][]*EnemyRef
+}
+
+func (e *RoomEvent) ContainsPoint(x, y float32) bool {
+	if e == nil || e.Config.Radius <= 0 {
+		return false
+	}
+	dx := x - e.X
+	dy := y - e.Y
+	return dx*dx+dy*dy <= e.Config.Radius*e.Config.Radius
+}
+
+func newSurviveRoomEvent(room *Room, duration float32) *RoomEvent {
+	config := DefaultEventConfig()
+	config.Duration = duration
+	return &RoomEvent{
+		Config: config,
+		X:      room.X + room.Width/2,
+		Y:      room.Y + room.Height/2,
+	}
+}
+
+func templateToRoomEvent(template *RoomTemplate, room *Room, rng *rand.Rand, progressionIndex int) *RoomEvent {
+	config := DefaultEventConfig()
+	if template.Event != nil {
+		config = *template.Event
+	}
+	event := &RoomEvent{
+		Config: config,
+		X:      room.X + room.Width/2,
+		Y:      room.Y + room.Height/2,
+	}
+	if len(template.EventMarkers) > 0 {
+		marker := template.EventMarkers[0]
+		event.X = room.X + float32(marker.X)*RoomTemplateTileSize + RoomTemplateTileSize/2
+		event.Y = room.Y + float32(marker.Y)*RoomTemplateTileSize + RoomTemplateTileSize/2
+	}
+	if config.Kind == EventKindDefendTotem {
+		event.Waves = buildEventWaves(config, room, rng, progressionIndex)
+	}
+	return event
+}
+
+// buildEventWaves lines each wave up across the non-entry doorways so enemies walk in rather than appearing on the totem.
+func buildEventWaves(config EventConfig, room *Room, rng *rand.Rand, progressionIndex int) [][]*EnemyRef {
+	points := eventWaveSpawnPoints(room)
+	allowed := allowedArchetypes(progressionIndex)
+	waves := make([][]*EnemyRef, 0, config.Waves)
+	for wave := 0; wave < config.Waves; wave++ {
+		enemies := make([]*EnemyRef, 0, config.WaveSize)
+		for i := 0; i < config.WaveSize; i++ {
+			point := points[(wave+i)%len(points)]
+			archetype := allowed[rng.Intn(len(allowed))]
+			spec := gamedata.GetEnemyArchetype(archetype)
+			offset := (float32(i) - float32(config.WaveSize-1)/2) * eventWaveSpawnSpacing
+			x, y := point.X, point.Y
+			if point.Direction == DoorDirectionNorth || point.Direction == DoorDirectionSouth {
+				x += offset
+			} else {
+				y += offset
+			}
+			enemies = append(enemies, &EnemyRef{
+				X:    x - spec.Width/2,
+				Y:    y - spec.Height/2,
+				Type: archetype,
+			})
+		}
+		waves = append(waves, enemies)
+	}
+	return waves
+}
+
+type eventSpawnPoint struct {
+	X         float32
+	Y         float32
+	Direction DoorDirection
+}
+
+func eventWaveSpawnPoints(room *Room) []eventSpawnPoint {
+	points := []eventSpawnPoint{}
+	for _, door := range room.Doors {
+		if door == nil || door.Direction == DoorDirectionWest {
+			continue
+		}
+		points = append(points, doorInteriorPoint(door))
+	}
+	if len(points) == 0 {
+		for _, door := range room.Doors {
+			if door != nil {
+				points = append(points, doorInteriorPoint(door))
+			}
+		}
+	}
+	if len(points) == 0 {
+		points = append(points, eventSpawnPoint{
+			X:         room.X + room.Width - RoomTemplateTileSize*1.5,
+			Y:         room.Y + room.Height/2,
+			Direction: DoorDirectionEast,
+		})
+	}
+	return points
+}
+
+func doorInteriorPoint(door *Door) eventSpawnPoint {
+	x := door.Bounds.X + door.Bounds.Width/2
+	y := door.Bounds.Y + door.Bounds.Height/2
+	switch door.Direction {
+	case DoorDirectionNorth:
+		y += RoomTemplateTileSize
+	case DoorDirectionSouth:
+		y -= RoomTemplateTileSize
+	case DoorDirectionEast:
+		x -= RoomTemplateTileSize
+	case DoorDirectionWest:
+		x += RoomTemplateTileSize
+	}
+	return eventSpawnPoint{X: x, Y: y, Direction: door.Direction}
+}
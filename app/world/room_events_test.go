@@ -0,0 +1,129 @@
+package world
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+var eventTestLayout = []string{
+	"########",
+	"#......#",
+	"#......#",
+	"D..R...D",
+	"#......#",
+	"#......#",
+	"#......#",
+	"########",
+}
+
+func TestLoadRoomTemplatePairResolvesEventConfig(t *testing.T) {
+	layoutPath, metaPath := writeHazardTestTemplate(t, eventTestLayout, `{
+  "id":"hazard_room",
+  "biome":"forest",
+  "type":"event",
+  "doors":[{"x":0,"y":3,"dir":"west"},{"x":7,"y":3,"dir":"east"}],
+  "event":{"kind":"defend_totem","waves":2,"wave_size":2,"totem_hp":90}
+}`)
+
+	template, err := loadRoomTemplatePair(layoutPath, metaPath)
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if template.Event == nil || template.Event.Kind != EventKindDefendTotem || template.Event.TotemHP != 90 || template.Event.Waves != 2 {
+		t.Fatalf("expected defend_totem override, got %+v", template.Event)
+	}
+	if template.Event.WaveInterval != eventPresets[EventKindDefendTotem].WaveInterval {
+		t.Fatalf("expected unset event values to keep preset values, got %+v", template.Event)
+	}
+
+	room := buildRoomFromTemplate(template, 0, 0, 0, 3, rand.New(rand.NewSource(1)))
+	if room.Event == nil {
+		t.Fatalf("expected room event")
+	}
+	if room.Event.X != 3*RoomTemplateTileSize+RoomTemplateTileSize/2 || room.Event.Y != 3*RoomTemplateTileSize+RoomTemplateTileSize/2 {
+		t.Fatalf("expected event anchored at the R marker, got (%v,%v)", room.Event.X, room.Event.Y)
+	}
+	if room.EventDuration != 0 {
+		t.Fatalf("expected defend_totem to run without a timer, got %v", room.EventDuration)
+	}
+	if len(room.Event.Waves) != 2 || len(room.Event.Waves[0]) != 2 {
+		t.Fatalf("expected 2 waves of 2, got %+v", room.Event.Waves)
+	}
+	eastDoorX := float32(7 * RoomTemplateTileSize)
+	for _, enemy := range room.Event.Waves[0] {
+		if enemy.X < eastDoorX-2*RoomTemplateTileSize || enemy.X > eastDoorX {
+			t.Fatalf("expected wave to enter from the east doorway, got x=%v", enemy.X)
+		}
+	}
+}
+
+func TestLoadRoomTemplatePairDefaultsEventRoomsToSurvive(t *testing.T) {
+	layoutPath, metaPath := writeHazardTestTemplate(t, eventTestLayout, `{
+  "id":"hazard_room",
+  "biome":"forest",
+  "type":"event",
+  "doors":[{"x":0,"y":3,"dir":"west"},{"x":7,"y":3,"dir":"east"}]
+}`)
+
+	template, err := loadRoomTemplatePair(layoutPath, metaPath)
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	room := buildRoomFromTemplate(template, 0, 0, 0, 0, rand.New(rand.NewSource(1)))
+	if room.Event == nil || room.Event.Config.Kind != EventKindSurvive || room.EventTimeLeft != DungeonEventDuration {
+		t.Fatalf("expected timed survive event, got %+v timer=%v", room.Event, room.EventTimeLeft)
+	}
+}
+
+func TestLoadRoomTemplatePairRejectsInvalidEventBlocks(t *testing.T) {
+	noMarker := append([]string(nil), eventTestLayout...)
+	noMarker[3] = "D......D"
+
+	cases := []struct {
+		name   string
+		layout []string
+		meta   string
+		want   string
+	}{
+		{
+			name:   "unknown kind",
+			layout: eventTestLayout,
+			meta:   `"type":"event","event":{"kind":"bonfire"}`,
+			want:   "unsupported event kind",
+		},
+		{
+			name:   "kind without marker",
+			layout: noMarker,
+			meta:   `"type":"event","event":{"kind":"capture_point"}`,
+			want:   "requires an R marker",
+		},
+		{
+			name:   "non-event room",
+			layout: eventTestLayout,
+			meta:   `"type":"combat","event":{"kind":"altar"}`,
+			want:   "requires room type event",
+		},
+		{
+			name:   "heal out of range",
+			layout: eventTestLayout,
+			meta:   `"type":"event","event":{"kind":"healing_shrine","heal_percent":1.5}`,
+			want:   "heal_percent",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			layoutPath, metaPath := writeHazardTestTemplate(t, tc.layout, `{
+  "id":"hazard_room",
+  "biome":"forest",
+  "doors":[{"x":0,"y":3,"dir":"west"},{"x":7,"y":3,"dir":"east"}],
+  `+tc.meta+`
+}`)
+			_, err := loadRoomTemplatePair(layoutPath, metaPath)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package world
+
+import (
+	"fmt"
+	"strings"
+
+	"singlefantasy/app/gamedata"
+)
+
+type HazardKind string
+
+const (
+	HazardKindBurningGround HazardKind = "burning_ground"
+	HazardKindPoisonBog     HazardKind = "poison_bog"
+)
+
+type TrapKind string
+
+const (
+	TrapKindSpikePlate    TrapKind = "spike_plate"
+	TrapKindArrowLauncher TrapKind = "arrow_launcher"
+)
+
+// HazardConfig describes what standing on a room's H tiles does.
+type HazardConfig struct {
+	Kind         HazardKind
+	Damage       int
+	TickInterval float32
+	DamageType   gamedata.DamageType
+	Effects      []gamedata.EffectSpec
+}
+
+// TrapConfig describes the arm -> telegraph -> active cycle shared by a room's T tiles.
+type TrapConfig struct {
+	Kind              TrapKind
+	Damage            int
+	DamageType        gamedata.DamageType
+	Effects           []gamedata.EffectSpec
+	ArmDuration       float32
+	TelegraphDuration float32
+	ActiveDuration    float32
+	Direction         DoorDirection
+	Range             int
+}
+
+var hazardPresets = map[HazardKind]HazardConfig{
+	HazardKindBurningGround: {
+		Kind:         HazardKindBurningGround,
+		Damage:       6,
+		TickInterval: 0.5,
+		DamageType:   gamedata.DamageMagical,
+		Effects: []gamedata.EffectSpec{
+			{Type: gamedata.EffectBurn, Duration: 2.0, Magnitude: 2.0, TickRate: 0.5, MinTickDamage: 1, MaxTickDamage: 6},
+		},
+	},
+	HazardKindPoisonBog: {
+		Kind:         HazardKindPoisonBog,
+		Damage:       3,
+		TickInterval: 0.75,
+		DamageType:   gamedata.DamageTrue,
+		Effects: []gamedata.EffectSpec{
+			{Type: gamedata.EffectPoison, Duration: 3.0, Magnitude: 1.0, TickRate: 1.0, MinTickDamage: 1, MaxTickDamage: 6},
+			{Type: gamedata.EffectSlow, Duration: 0.8, Magnitude: 0.3},
+		},
+	},
+}
+
+var trapPresets = map[TrapKind]TrapConfig{
+	TrapKindSpikePlate: {
+		Kind:              TrapKindSpikePlate,
+		Damage:            18,
+		DamageType:        gamedata.DamagePhysical,
+		ArmDuration:       2.4,
+		TelegraphDuration: 0.8,
+		ActiveDuration:    0.4,
+	},
+	TrapKindArrowLauncher: {
+		Kind:              TrapKindArrowLauncher,
+		Damage:            14,
+		DamageType:        gamedata.DamagePhysical,
+		ArmDuration:       3.0,
+		TelegraphDuration: 0.9,
+		ActiveDuration:    0.25,
+		Direction:         DoorDirectionEast,
+		Range:             6,
+	},
+}
+
+var biomeHazardKinds = map[string]HazardKind{
+	"forest": HazardKindPoisonBog,
+}
+
+var biomeTrapKinds = map[string]TrapKind{
+	"forest": TrapKindSpikePlate,
+}
+
+func DefaultHazardConfig(biome string) HazardConfig {
+	kind, ok := biomeHazardKinds[strings.ToLower(biome)]
+	if !ok {
+		kind = HazardKindBurningGround
+	}
+	return hazardPresets[kind].clone()
+}
+
+func DefaultTrapConfig(biome string) TrapConfig {
+	kind, ok := biomeTrapKinds[strings.ToLower(biome)]
+	if !ok {
+		kind = TrapKindSpikePlate
+	}
+	return trapPresets[kind].clone()
+}
+
+func (c HazardConfig) clone() HazardConfig {
+	c.Effects = append([]gamedata.EffectSpec(nil), c.Effects...)
+	return c
+}
+
+func (c TrapConfig) clone() TrapConfig {
+	c.Effects = append([]gamedata.EffectSpec(nil), c.Effects...)
+	return c
+}
+
+func (c TrapConfig) CycleDuration() float32 {
+	return c.ArmDuration + c.TelegraphDuration + c.ActiveDuration
+}
+
+type roomMetaHazard struct {
+	Kind         string                      `json:"kind"`
+	Damage       int                         `json:"damage"`
+	TickInterval float32                     `json:"tick_interval"`
+	DamageType   string                      `json:"damage_type"`
+	Effects      []gamedata.EffectDefinition `json:"effects"`
+}
+
+type roomMetaTrap struct {
+	Kind              string                      `json:"kind"`
+	Damage            int                         `json:"damage"`
+	DamageType        string                      `json:"damage_type"`
+	Effects           []gamedata.EffectDefinition `json:"effects"`
+	ArmDuration       float32                     `json:"arm_duration"`
+	TelegraphDuration float32                     `json:"telegraph_duration"`
+	ActiveDuration    float32                     `json:"active_duration"`
+	Dir               string                      `json:"dir"`
+	Range             int                         `json:"range"`
+}
+
+func resolveHazardConfig(meta *roomMetaHazard, biome string) (HazardConfig, error) {
+	config := DefaultHazardConfig(biome)
+	if meta == nil {
+		return config, nil
+	}
+	if kind := strings.ToLower(strings.TrimSpace(meta.Kind)); kind != "" {
+		preset, ok := hazardPresets[HazardKind(kind)]
+		if !ok {
+			return HazardConfig{}, fmt.Errorf("unsupported hazard kind %q", meta.Kind)
+		}
+		config = preset.clone()
+	}
+	if meta.Damage < 0 || meta.TickInterval < 0 {
+		return HazardConfig{}, fmt.Errorf("hazard damage and tick_interval must be >= 0")
+	}
+	if meta.Damage > 0 {
+		config.Damage = meta.Damage
+	}
+	if meta.TickInterval > 0 {
+		config.TickInterval = meta.TickInterval
+	}
+	if meta.DamageType != "" {
+		damageType, err := gamedata.ParseDamageType(meta.DamageType)
+		if err != nil {
+			return HazardConfig{}, fmt.Errorf("hazard: %w", err)
+		}
+		config.DamageType = damageType
+	}
+	if meta.Effects != nil {
+		effects, err := gamedata.BuildEffectSpecs(meta.Effects)
+		if err != nil {
+			return HazardConfig{}, fmt.Errorf("hazard: %w", err)
+		}
+		config.Effects = effects
+	}
+	return config, nil
+}
+
+func resolveTrapConfig(meta *roomMetaTrap, biome string) (TrapConfig, error) {
+	config := DefaultTrapConfig(biome)
+	if meta == nil {
+		return config, nil
+	}
+	if kind := strings.ToLower(strings.TrimSpace(meta.Kind)); kind != "" {
+		preset, ok := trapPresets[TrapKind(kind)]
+		if !ok {
+			return TrapConfig{}, fmt.Errorf("unsupported trap kind %q", meta.Kind)
+		}
+		config = preset.clone()
+	}
+	if meta.Damage < 0 || meta.ArmDuration < 0 || meta.TelegraphDuration < 0 || meta.ActiveDuration < 0 || meta.Range < 0 {
+		return TrapConfig{}, fmt.Errorf("trap damage, durations and range must be >= 0")
+	}
+	if meta.Damage > 0 {
+		config.Damage = meta.Damage
+	}
+	if meta.ArmDuration > 0 {
+		config.ArmDuration = meta.ArmDuration
+	}
+	if meta.TelegraphDuration > 0 {
+		config.TelegraphDuration = meta.TelegraphDuration
+	}
+	if meta.ActiveDuration > 0 {
+		config.ActiveDuration = meta.ActiveDuration
+	}
+	if meta.Range > 0 {
+		config.Range = meta.Range
+	}
+	if meta.Dir != "" {
+		direction, err := ParseDoorDirection(meta.Dir)
+		if err != nil {
+			return TrapConfig{}, fmt.Errorf("trap: %w", err)
+		}
+		config.Direction = direction
+	}
+	if meta.DamageType != "" {
+		damageType, err := gamedata.ParseDamageType(meta.DamageType)
+		if err != nil {
+			return TrapConfig{}, fmt.Errorf("trap: %w", err)
+		}
+		config.DamageType = damageType
+	}
+	if meta.Effects != nil {
+		effects, err := gamedata.BuildEffectSpecs(meta.Effects)
+		if err != nil {
+			return TrapConfig{}, fmt.Errorf("trap: %w", err)
+		}
+		config.Effects = effects
+	}
+	if config.Kind == TrapKindArrowLauncher && (!config.Direction.IsValid() || config.Range <= 0) {
+		return TrapConfig{}, fmt.Errorf("arrow_launcher trap requires dir and range")
+	}
+	return config, nil
+}
+
+type HazardField struct {
+	Config    HazardConfig
+	Tiles     []AABB
+	TickTimer float32
+}
+
+func (h *HazardField) Update(dt float32) bool {
+	if h == nil || h.Config.TickInterval <= 0 {
+		return false
+	}
+	h.TickTimer -= dt
+	if h.TickTimer > 0 {
+		return false
+	}
+	h.TickTimer += h.Config.TickInterval
+	if h.TickTimer <= 0 {
+		h.TickTimer = h.Config.TickInterval
+	}
+	return true
+}
+
+func (h *HazardField) ContainsPoint(x, y float32) bool {
+	if h == nil {
+		return false
+	}
+	for _, tile := range h.Tiles {
+		if tile.ContainsPoint(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+type TrapPhase int
+
+const (
+	TrapPhaseArming TrapPhase = iota
+	TrapPhaseTelegraph
+	TrapPhaseActive
+)
+
+type Trap struct {
+	Config TrapConfig
+	Bounds AABB
+	Strike AABB
+	Phase  TrapPhase
+	Timer  float32
+}
+
+func (t *Trap) Update(dt float32) bool {
+	if t == nil {
+		return false
+	}
+	t.Timer -= dt
+	fired := false
+	for t.Timer <= 0 {
+		switch t.Phase {
+		case TrapPhaseArming:
+			t.Phase = TrapPhaseTelegraph
+			t.Timer += t.Config.TelegraphDuration
+		case TrapPhaseTelegraph:
+			t.Phase = TrapPhaseActive
+			t.Timer += t.Config.ActiveDuration
+			fired = true
+		default:
+			t.Phase = TrapPhaseArming
+			t.Timer += t.Config.ArmDuration
+		}
+		if t.Config.CycleDuration() <= 0 {
+			break
+		}
+	}
+	return fired
+}
+
+func (t *Trap) TelegraphProgress() float32 {
+	if t == nil || t.Phase != TrapPhaseTelegraph || t.Config.TelegraphDuration <= 0 {
+		return 0
+	}
+	return clampUnit(1 - t.Timer/t.Config.TelegraphDuration)
+}
+
+func clampUnit(value float32) float32 {
+	if value < 0 {
+		return 0
+	}
+	if value > 1 {
+		return 1
+	}
+	return value
+}
+
+func templateToHazardField(template *RoomTemplate, originX, originY float32) *HazardField {
+	if template.Hazard == nil || len(template.HazardMarkers) == 0 {
+		return nil
+	}
+	field := &HazardField{
+		Config:    template.Hazard.clone(),
+		Tiles:     make([]AABB, 0, len(template.HazardMarkers)),
+		TickTimer: template.Hazard.TickInterval,
+	}
+	for _, marker := range template.HazardMarkers {
+		field.Tiles = append(field.Tiles, templateTileBounds(marker.X, marker.Y, originX, originY))
+	}
+	return field
+}
+
+func templateToTraps(template *RoomTemplate, originX, originY float32) []*Trap {
+	if template.Trap == nil || len(template.TrapMarkers) == 0 {
+		return nil
+	}
+	traps := make([]*Trap, 0, len(template.TrapMarkers))
+	for i, marker := range template.TrapMarkers {
+		config := template.Trap.clone()
+		bounds := templateTileBounds(marker.X, marker.Y, originX, originY)
+		traps = append(traps, &Trap{
+			Config: config,
+			Bounds: bounds,
+			Strike: trapStrikeBounds(config, bounds),
+			Phase:  TrapPhaseArming,
+			Timer:  config.ArmDuration * (1 + float32(i%3)/3),
+		})
+	}
+	return traps
+}
+
+func trapStrikeBounds(config TrapConfig, tile AABB) AABB {
+	if config.Kind != TrapKindArrowLauncher || config.Range <= 0 {
+		return tile
+	}
+	length := float32(config.Range * RoomTemplateTileSize)
+	switch config.Direction {
+	case DoorDirectionEast:
+		return AABB{X: tile.X + tile.Width, Y: tile.Y, Width: length, Height: tile.Height}
+	case DoorDirectionWest:
+		return AABB{X: tile.X - length, Y: tile.Y, Width: length, Height: tile.Height}
+	case DoorDirectionNorth:
+		return AABB{X: tile.X, Y: tile.Y - length, Width: tile.Width, Height: length}
+	case DoorDirectionSouth:
+		return AABB{X: tile.X, Y: tile.Y + tile.Height, Width: tile.Width, Height: length}
+	default:
+		return tile
+	}
+}
+
+func templateTileBounds(x, y int, originX, originY float32) AABB {
+	return AABB{
+		X:      originX + float32(x)*RoomTemplateTileSize,
+		Y:      originY + float32(y)*RoomTemplateTileSize,
+		Width:  RoomTemplateTileSize,
+		Height: RoomTemplateTileSize,
+	}
+}
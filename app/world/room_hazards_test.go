@@ -0,0 +1,149 @@
+package world
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"singlefantasy/app/gamedata"
+)
+
+func writeHazardTestTemplate(t *testing.T, layout []string, meta string) (string, string) {
+	t.Helper()
+	tempDir := t.TempDir()
+	layoutPath := filepath.Join(tempDir, "hazard_room.layout")
+	metaPath := filepath.Join(tempDir, "hazard_room.meta.json")
+	if err := os.WriteFile(layoutPath, []byte(strings.Join(layout, "\n")), 0o644); err != nil {
+		t.Fatalf("write layout: %v", err)
+	}
+	if err := os.WriteFile(metaPath, []byte(meta), 0o644); err != nil {
+		t.Fatalf("write meta: %v", err)
+	}
+	return layoutPath, metaPath
+}
+
+var hazardTestLayout = []string{
+	"########",
+	"#......#",
+	"#..H...#",
+	"D......D",
+	"#T.....#",
+	"#......#",
+	"#......#",
+	"########",
+}
+
+func TestLoadRoomTemplatePairResolvesHazardAndTrapConfig(t *testing.T) {
+	layoutPath, metaPath := writeHazardTestTemplate(t, hazardTestLayout, `{
+  "id":"hazard_room",
+  "biome":"forest",
+  "type":"combat",
+  "doors":[{"x":0,"y":3,"dir":"west"},{"x":7,"y":3,"dir":"east"}],
+  "trap":{"kind":"arrow_launcher","damage":9,"dir":"east","range":4,"effects":[{"type":"slow","duration":1,"magnitude":0.2}]}
+}`)
+
+	template, err := loadRoomTemplatePair(layoutPath, metaPath)
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if template.Hazard == nil || template.Hazard.Kind != DefaultHazardConfig("forest").Kind {
+		t.Fatalf("expected forest default hazard, got %+v", template.Hazard)
+	}
+	if template.Trap == nil || template.Trap.Kind != TrapKindArrowLauncher || template.Trap.Damage != 9 || template.Trap.Range != 4 {
+		t.Fatalf("expected arrow launcher override, got %+v", template.Trap)
+	}
+	if template.Trap.ArmDuration != trapPresets[TrapKindArrowLauncher].ArmDuration {
+		t.Fatalf("expected unset trap timings to keep preset values, got %+v", template.Trap)
+	}
+	if len(template.Trap.Effects) != 1 || template.Trap.Effects[0].Type != gamedata.EffectSlow {
+		t.Fatalf("expected trap effects from meta, got %+v", template.Trap.Effects)
+	}
+
+	rotated, err := RotateRoomTemplate(template, 90)
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if rotated.Trap.Direction != DoorDirectionSouth || template.Trap.Direction != DoorDirectionEast {
+		t.Fatalf("expected rotated copy to turn trap lane only, got %q and %q", rotated.Trap.Direction, template.Trap.Direction)
+	}
+
+	room := buildRoomFromTemplate(template, 0, 0, 0, 0, rand.New(rand.NewSource(1)))
+	if room.Hazards == nil || len(room.Hazards.Tiles) != 1 || len(room.Traps) != 1 {
+		t.Fatalf("expected hazard field and trap instances, got %+v %+v", room.Hazards, room.Traps)
+	}
+	hazardTile := room.Hazards.Tiles[0]
+	if overlapsAny(hazardTile, room.Obstacles) || overlapsAny(room.Traps[0].Bounds, room.Obstacles) {
+		t.Fatalf("expected hazard and trap tiles to be walkable")
+	}
+	lane := room.Traps[0].Strike
+	if lane.X != 2*RoomTemplateTileSize || lane.Width != 4*RoomTemplateTileSize || lane.Height != RoomTemplateTileSize {
+		t.Fatalf("expected lane east of the launcher, got %+v", lane)
+	}
+}
+
+func TestLoadRoomTemplatePairRejectsInvalidHazardConfig(t *testing.T) {
+	cases := map[string]string{
+		"hazard kind":  `"hazard":{"kind":"lava_moat"}`,
+		"damage type":  `"hazard":{"damage_type":"sonic"}`,
+		"trap timing":  `"trap":{"arm_duration":-1}`,
+		"arrow lane":   `"trap":{"kind":"arrow_launcher","dir":"up"}`,
+		"trap effects": `"trap":{"effects":[{"type":"slow"}]}`,
+	}
+	for name, block := range cases {
+		layoutPath, metaPath := writeHazardTestTemplate(t, hazardTestLayout, `{
+  "id":"hazard_room",
+  "biome":"forest",
+  "type":"combat",
+  "doors":[{"x":0,"y":3,"dir":"west"},{"x":7,"y":3,"dir":"east"}],
+  `+block+`
+}`)
+		if _, err := loadRoomTemplatePair(layoutPath, metaPath); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestTrapCycleTelegraphsThenFiresOncePerActivation(t *testing.T) {
+	trap := &Trap{
+		Config: TrapConfig{ArmDuration: 1, TelegraphDuration: 0.5, ActiveDuration: 0.25},
+		Phase:  TrapPhaseArming,
+		Timer:  1,
+	}
+
+	fired := 0
+	sawTelegraph := false
+	for step := 0; step < 28; step++ {
+		if trap.Update(0.125) {
+			fired++
+		}
+		if trap.Phase == TrapPhaseTelegraph && trap.TelegraphProgress() > 0 {
+			sawTelegraph = true
+		}
+	}
+	// 3.5s of updates covers two full 1.75s cycles.
+	if fired != 2 || !sawTelegraph {
+		t.Fatalf("expected two activations after telegraphs, got fired=%d telegraph=%v", fired, sawTelegraph)
+	}
+}
+
+func TestHazardFieldTicksOnInterval(t *testing.T) {
+	field := &HazardField{
+		Config:    HazardConfig{TickInterval: 0.5},
+		Tiles:     []AABB{{X: 0, Y: 0, Width: 10, Height: 10}},
+		TickTimer: 0.5,
+	}
+	ticks := 0
+	for step := 0; step < 8; step++ {
+		if field.Update(0.25) {
+			ticks++
+		}
+	}
+	if ticks != 4 {
+		t.Fatalf("expected 4 ticks in 2s, got %d", ticks)
+	}
+	if !field.ContainsPoint(5, 5) || field.ContainsPoint(15, 5) {
+		t.Fatalf("unexpected hazard tile containment")
+	}
+}
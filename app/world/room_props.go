@@ -0,0 +1,61 @@
+package world
+
+import (
+	"fmt"
+	"strings"
+
+	"singlefantasy/app/gamedata"
+)
+
+var biomePropPools = map[string][]gamedata.PropType{
+	"forest": {gamedata.PropBarrel, gamedata.PropCrate, gamedata.PropExplosiveUrn, gamedata.PropBrazier},
+}
+
+// DefaultPropTypes returns the rotation used for P markers the template metadata does not type explicitly.
+func DefaultPropTypes(biome string) []gamedata.PropType {
+	pool, ok := biomePropPools[strings.ToLower(biome)]
+	if !ok {
+		return []gamedata.PropType{gamedata.PropCrate, gamedata.PropBarrel}
+	}
+	return append([]gamedata.PropType(nil), pool...)
+}
+
+// resolvePropMarkers types each P marker from the metadata props list, cycling through the biome pool for the rest.
+func resolvePropMarkers(markers []PropMarker, metaProps []roomMetaProp, biome string) ([]PropMarker, error) {
+	overrides := map[string]gamedata.PropType{}
+	for _, metaProp := range metaProps {
+		propType, err := gamedata.ParsePropType(metaProp.Type)
+		if err != nil {
+			return nil, fmt.Errorf("prop (%d,%d): %w", metaProp.X, metaProp.Y, err)
+		}
+		overrides[fmt.Sprintf("%d,%d", metaProp.X, metaProp.Y)] = propType
+	}
+
+	pool := DefaultPropTypes(biome)
+	resolved := make([]PropMarker, 0, len(markers))
+	for i, marker := range markers {
+		key := fmt.Sprintf("%d,%d", marker.X, marker.Y)
+		marker.Type = pool[i%len(pool)]
+		if propType, ok := overrides[key]; ok {
+			marker.Type = propType
+			delete(overrides, key)
+		}
+		resolved = append(resolved, marker)
+	}
+	if len(overrides) > 0 {
+		return nil, fmt.Errorf("metadata props missing matching P marker in layout")
+	}
+	return resolved, nil
+}
+
+func templateToPropRefs(template *RoomTemplate, originX, originY float32) []*PropRef {
+	refs := make([]*PropRef, 0, len(template.PropMarkers))
+	for _, marker := range template.PropMarkers {
+		refs = append(refs, &PropRef{
+			X:    originX + float32(marker.X)*RoomTemplateTileSize + RoomTemplateTileSize/2,
+			Y:    originY + float32(marker.Y)*RoomTemplateTileSize + RoomTemplateTileSize/2,
+			Type: marker.Type,
+		})
+	}
+	return refs
+}
@@ -0,0 +1,77 @@
+package world
+
+import (
+	"math/rand"
+	"testing"
+
+	"singlefantasy/app/gamedata"
+)
+
+var propTestLayout = []string{
+	"########",
+	"#.P....#",
+	"#......#",
+	"D......D",
+	"#....P.#",
+	"#......#",
+	"#..P...#",
+	"########",
+}
+
+func TestLoadRoomTemplatePairTypesPropMarkers(t *testing.T) {
+	layoutPath, metaPath := writeHazardTestTemplate(t, propTestLayout, `{
+  "id":"hazard_room",
+  "biome":"forest",
+  "type":"combat",
+  "doors":[{"x":0,"y":3,"dir":"west"},{"x":7,"y":3,"dir":"east"}],
+  "props":[{"x":5,"y":4,"type":"explosive_urn"}]
+}`)
+
+	template, err := loadRoomTemplatePair(layoutPath, metaPath)
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	pool := DefaultPropTypes("forest")
+	want := []gamedata.PropType{pool[0], gamedata.PropExplosiveUrn, pool[2]}
+	for i, marker := range template.PropMarkers {
+		if marker.Type != want[i] {
+			t.Fatalf("marker %d at (%d,%d): expected type %v, got %v", i, marker.X, marker.Y, want[i], marker.Type)
+		}
+	}
+
+	rotated, err := RotateRoomTemplate(template, 90)
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if rotated.PropMarkers[1].Type != gamedata.PropExplosiveUrn {
+		t.Fatalf("expected rotation to keep prop types, got %+v", rotated.PropMarkers)
+	}
+
+	room := buildRoomFromTemplate(template, 0, 100, 200, 0, rand.New(rand.NewSource(1)))
+	if len(room.Props) != 3 {
+		t.Fatalf("expected 3 prop refs, got %d", len(room.Props))
+	}
+	urn := room.Props[1]
+	if urn.Type != gamedata.PropExplosiveUrn || urn.X != 100+5.5*RoomTemplateTileSize || urn.Y != 200+4.5*RoomTemplateTileSize {
+		t.Fatalf("expected urn centered on its tile, got %+v", urn)
+	}
+}
+
+func TestLoadRoomTemplatePairRejectsInvalidProps(t *testing.T) {
+	cases := map[string]string{
+		"unknown type":   `"props":[{"x":2,"y":1,"type":"statue"}]`,
+		"missing marker": `"props":[{"x":3,"y":3,"type":"crate"}]`,
+	}
+	for name, block := range cases {
+		layoutPath, metaPath := writeHazardTestTemplate(t, propTestLayout, `{
+  "id":"hazard_room",
+  "biome":"forest",
+  "type":"combat",
+  "doors":[{"x":0,"y":3,"dir":"west"},{"x":7,"y":3,"dir":"east"}],
+  `+block+`
+}`)
+		if _, err := loadRoomTemplatePair(layoutPath, metaPath); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
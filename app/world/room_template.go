@@ -1,6 +1,10 @@
 package world
 
-import "strings"
+import (
+	"strings"
+
+	"singlefantasy/app/gamedata"
+)
 
 const (
 	RoomTemplateMinSize  = 8
@@ -21,8 +25,9 @@ type SpawnMarker struct {
 }
 
 type PropMarker struct {
-	X int
-	Y int
+	X    int
+	Y    int
+	Type gamedata.PropType
 }
 
 type EventMarker struct {
@@ -53,6 +58,9 @@ type RoomTemplate struct {
 	EventMarkers  []EventMarker
 	HazardMarkers []HazardMarker
 	TrapMarkers   []TrapMarker
+	Hazard        *HazardConfig
+	Trap          *TrapConfig
+	Event         *EventConfig
 	Tags          []string
 	Weight        int
 	Difficulty    int
@@ -76,6 +84,18 @@ func (t *RoomTemplate) Clone() *RoomTemplate {
 	clone.EventMarkers = append([]EventMarker(nil), t.EventMarkers...)
 	clone.HazardMarkers = append([]HazardMarker(nil), t.HazardMarkers...)
 	clone.TrapMarkers = append([]TrapMarker(nil), t.TrapMarkers...)
+	if t.Hazard != nil {
+		hazard := t.Hazard.clone()
+		clone.Hazard = &hazard
+	}
+	if t.Trap != nil {
+		trap := t.Trap.clone()
+		clone.Trap = &trap
+	}
+	if t.Event != nil {
+		event := *t.Event
+		clone.Event = &event
+	}
 	clone.Tags = append([]string(nil), t.Tags...)
 	return &clone
 }
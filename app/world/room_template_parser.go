@@ -21,14 +21,24 @@ type layoutParseResult struct {
 }
 
 type roomMetaFile struct {
-	ID            string         `json:"id"`
-	Biome         string         `json:"biome"`
-	Type          string         `json:"type"`
-	Difficulty    int            `json:"difficulty"`
-	Weight        int            `json:"weight"`
-	AllowRotation bool           `json:"allow_rotation"`
-	Tags          []string       `json:"tags"`
-	Doors         []roomMetaDoor `json:"doors"`
+	ID            string          `json:"id"`
+	Biome         string          `json:"biome"`
+	Type          string          `json:"type"`
+	Difficulty    int             `json:"difficulty"`
+	Weight        int             `json:"weight"`
+	AllowRotation bool            `json:"allow_rotation"`
+	Tags          []string        `json:"tags"`
+	Doors         []roomMetaDoor  `json:"doors"`
+	Props         []roomMetaProp  `json:"props"`
+	Hazard        *roomMetaHazard `json:"hazard"`
+	Trap          *roomMetaTrap   `json:"trap"`
+	Event         *roomMetaEvent  `json:"event"`
+}
+
+type roomMetaProp struct {
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+	Type string `json:"type"`
 }
 
 type roomMetaDoor struct {
@@ -211,6 +221,41 @@ func loadRoomTemplatePair(layoutPath, metaPath string) (*RoomTemplate, error) {
 		difficulty = 1
 	}
 
+	props, err := resolvePropMarkers(layoutResult.propMarkers, meta.Props, meta.Biome)
+	if err != nil {
+		return nil, fmt.Errorf("metadata %q: %w", metaPath, err)
+	}
+
+	var hazard *HazardConfig
+	if len(layoutResult.hazardMarkers) > 0 || meta.Hazard != nil {
+		config, err := resolveHazardConfig(meta.Hazard, meta.Biome)
+		if err != nil {
+			return nil, fmt.Errorf("metadata %q: %w", metaPath, err)
+		}
+		hazard = &config
+	}
+	var trap *TrapConfig
+	if len(layoutResult.trapMarkers) > 0 || meta.Trap != nil {
+		config, err := resolveTrapConfig(meta.Trap, meta.Biome)
+		if err != nil {
+			return nil, fmt.Errorf("metadata %q: %w", metaPath, err)
+		}
+		trap = &config
+	}
+	var event *EventConfig
+	if roomType == RoomTypeEvent {
+		config, err := resolveEventConfig(meta.Event)
+		if err != nil {
+			return nil, fmt.Errorf("metadata %q: %w", metaPath, err)
+		}
+		if config.NeedsMarker() && len(layoutResult.eventMarkers) == 0 {
+			return nil, fmt.Errorf("metadata %q: event kind %q requires an R marker in layout", metaPath, config.Kind)
+		}
+		event = &config
+	} else if meta.Event != nil {
+		return nil, fmt.Errorf("metadata %q: event block requires room type event", metaPath)
+	}
+
 	template := &RoomTemplate{
 		ID:            meta.ID,
 		Biome:         strings.ToLower(meta.Biome),
@@ -220,10 +265,13 @@ func loadRoomTemplatePair(layoutPath, metaPath string) (*RoomTemplate, error) {
 		Tiles:         layoutResult.tiles,
 		Doors:         doors,
 		SpawnMarkers:  layoutResult.spawnMarkers,
-		PropMarkers:   layoutResult.propMarkers,
+		PropMarkers:   props,
 		EventMarkers:  layoutResult.eventMarkers,
 		HazardMarkers: layoutResult.hazardMarkers,
 		TrapMarkers:   layoutResult.trapMarkers,
+		Hazard:        hazard,
+		Trap:          trap,
+		Event:         event,
 		Tags:          append([]string(nil), meta.Tags...),
 		Weight:        weight,
 		Difficulty:    difficulty,
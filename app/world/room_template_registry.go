@@ -29,7 +29,7 @@ func LoadRoomTemplateRegistry(root string) (*RoomTemplateRegistry, error) {
 	if root == "" {
 		root = DefaultRoomsRoot
 	}
-	root = resolveRoomsRoot(root)
+	root = ResolveRoomsRoot(root)
 
 	rootEntries, err := os.ReadDir(root)
 	if err != nil {
@@ -178,7 +178,7 @@ func templateHasDoors(template *RoomTemplate, required []DoorDirection) bool {
 	return true
 }
 
-func resolveRoomsRoot(root string) string {
+func ResolveRoomsRoot(root string) string {
 	candidates := []string{
 		root,
 		filepath.Join("..", root),
@@ -63,6 +63,9 @@ func rotateRoomTemplate90(template *RoomTemplate) *RoomTemplate {
 	rotated.EventMarkers = rotateEventsCW(template.EventMarkers, width, height)
 	rotated.HazardMarkers = rotateHazardsCW(template.HazardMarkers, width, height)
 	rotated.TrapMarkers = rotateTrapsCW(template.TrapMarkers, width, height)
+	if rotated.Trap != nil {
+		rotated.Trap.Direction = rotateDoorDirectionCW(rotated.Trap.Direction)
+	}
 	return rotated
 }
 
@@ -116,8 +119,9 @@ func rotatePropsCW(input []PropMarker, width, height int) []PropMarker {
 	for _, marker := range input {
 		nx, ny := rotateCoordCW(marker.X, marker.Y, width, height)
 		result = append(result, PropMarker{
-			X: nx,
-			Y: ny,
+			X:    nx,
+			Y:    ny,
+			Type: marker.Type,
 		})
 	}
 	return result